@@ -1,15 +1,21 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"os"
+	"strings"
 	"task-manager-app/backend/internal/application"
 	"task-manager-app/backend/internal/config"
 	"task-manager-app/backend/internal/database"
+	"task-manager-app/backend/internal/domain"
 	"task-manager-app/backend/internal/infrastructure"
 	"task-manager-app/backend/internal/interfaces"
 	"task-manager-app/backend/internal/interfaces/graphql/generated"
 	resolvers "task-manager-app/backend/internal/interfaces/graphql/resolver"
 	"task-manager-app/backend/internal/middleware"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/playground"
@@ -21,12 +27,118 @@ func graphqlHandler(resolver *resolvers.Resolver) gin.HandlerFunc {
 	h := handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{
 		Resolvers: resolver,
 	}))
+	h.AroundOperations(resolver.Authenticate)
+	h.SetErrorPresenter(resolvers.PresentError)
 
 	return func(c *gin.Context) {
 		h.ServeHTTP(c.Writer, c.Request)
 	}
 }
 
+// newBlobStore picks where attachment contents are kept.
+func newBlobStore(cfg *config.Config) (domain.BlobStore, error) {
+	if cfg.Storage.Driver == "s3" {
+		return infrastructure.NewS3BlobStore(infrastructure.S3Config{
+			Endpoint:  cfg.Storage.S3Endpoint,
+			Region:    cfg.Storage.S3Region,
+			Bucket:    cfg.Storage.S3Bucket,
+			AccessKey: cfg.Storage.S3AccessKey,
+			SecretKey: cfg.Storage.S3SecretKey,
+		}, nil), nil
+	}
+	return infrastructure.NewLocalBlobStore(cfg.Storage.LocalDir)
+}
+
+// signingKeys loads the keys access tokens are signed with, falling back to an HS256 key
+// made from the JWT secret when no RS256 or EdDSA keys are configured.
+func signingKeys(cfg *config.Config) (*application.KeySet, error) {
+	if len(cfg.JWT.Keys) == 0 {
+		return application.NewKeySet([]application.SigningKey{application.NewHMACKey("hs256", []byte(cfg.JWT.Secret))}, cfg.JWT.KeyGrace)
+	}
+	var keys []application.SigningKey
+	for _, spec := range cfg.JWT.Keys {
+		id, source, ok := strings.Cut(strings.TrimSpace(spec), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid signing key %q, expected kid=source", spec)
+		}
+		var activeFrom time.Time
+		if at := strings.LastIndex(source, "@"); at >= 0 {
+			var err error
+			if activeFrom, err = time.Parse(time.RFC3339, source[at+1:]); err != nil {
+				return nil, fmt.Errorf("invalid activation time of signing key %s: %w", id, err)
+			}
+			source = source[:at]
+		}
+		var data []byte
+		switch {
+		case strings.HasPrefix(source, "file:"):
+			var err error
+			if data, err = os.ReadFile(strings.TrimPrefix(source, "file:")); err != nil {
+				return nil, fmt.Errorf("failed to read signing key %s: %w", id, err)
+			}
+		case strings.HasPrefix(source, "env:"):
+			data = []byte(os.Getenv(strings.TrimPrefix(source, "env:")))
+		default:
+			return nil, fmt.Errorf("invalid source of signing key %s, expected file: or env:", id)
+		}
+		key, err := application.ParseSigningKey(id, data, activeFrom)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return application.NewKeySet(keys, cfg.JWT.KeyGrace)
+}
+
+// purgeTrash periodically deletes for good whatever has outlived the trash retention.
+func purgeTrash(service *application.TrashService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		if err := service.PurgeExpired(context.Background(), now); err != nil {
+			log.Printf("Failed to purge trash: %v", err)
+		}
+	}
+}
+
+// autoArchive periodically archives the tasks matching the rule.
+func autoArchive(service *application.TaskService, rule domain.ArchiveRule, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		if _, err := service.AutoArchive(rule, now); err != nil {
+			log.Printf("Failed to archive tasks: %v", err)
+		}
+	}
+}
+
+// pruneTokens periodically deletes the refresh tokens and the revocation entries that have expired.
+func pruneTokens(users *application.UserService, revocations *application.RevocationService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		if _, err := users.PruneRefreshTokens(now); err != nil {
+			log.Printf("Failed to prune refresh tokens: %v", err)
+		}
+		if _, err := revocations.Prune(now); err != nil {
+			log.Printf("Failed to prune revoked tokens: %v", err)
+		}
+	}
+}
+
+// archiveRule builds the auto-archive rule from the configuration, rejecting unknown statuses.
+func archiveRule(cfg *config.Config) (domain.ArchiveRule, error) {
+	rule := domain.ArchiveRule{After: cfg.Archive.After}
+	for _, name := range cfg.Archive.Statuses {
+		status := domain.TaskStatus(strings.TrimSpace(name))
+		if !status.IsValid() {
+			return rule, fmt.Errorf("unknown archive status %q", name)
+		}
+		rule.Statuses = append(rule.Statuses, status)
+	}
+	return rule, nil
+}
+
 func playgroundHandler() gin.HandlerFunc {
 	h := playground.Handler("GraphQL", "/api/v1/graphql")
 
@@ -61,18 +173,81 @@ func main() {
 	// Initialize repositories
 	taskRepo := infrastructure.NewTaskRepository(db)
 	userRepo := infrastructure.NewUserRepository(db)
+	workspaceRepo := infrastructure.NewWorkspaceRepository(db)
+	labelRepo := infrastructure.NewLabelRepository(db)
+	projectRepo := infrastructure.NewProjectRepository(db)
+	dependencyRepo := infrastructure.NewDependencyRepository(db)
+	commentRepo := infrastructure.NewCommentRepository(db)
+	attachmentRepo := infrastructure.NewAttachmentRepository(db)
+	activityRepo := infrastructure.NewActivityRepository(db)
+	timeEntryRepo := infrastructure.NewTimeEntryRepository(db)
+	checklistRepo := infrastructure.NewChecklistRepository(db)
+	customFieldRepo := infrastructure.NewCustomFieldRepository(db)
+	templateRepo := infrastructure.NewTemplateRepository(db)
+	boardRepo := infrastructure.NewBoardRepository(db)
+	sprintRepo := infrastructure.NewSprintRepository(db)
+	rule, err := archiveRule(cfg)
+	if err != nil {
+		log.Fatalf("Failed to load archive rule: %v", err)
+	}
+	blobs, err := newBlobStore(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize attachment storage: %v", err)
+	}
+	keys, err := signingKeys(cfg)
+	if err != nil {
+		log.Fatalf("Failed to load signing keys: %v", err)
+	}
 
 	// Initialize services
-	taskService := application.NewTaskService(taskRepo)
-	userService := application.NewUserService(userRepo)
+	accessPolicy := application.NewAccessPolicy(userRepo, workspaceRepo)
+	taskService := application.NewTaskService(taskRepo, workspaceRepo, activityRepo, accessPolicy)
+	revocationService := application.NewRevocationService(infrastructure.NewRevocationRepository(db), 30*time.Second)
+	tokenService := application.NewTokenService(application.TokenConfig{
+		Keys:      keys,
+		Issuer:    cfg.JWT.Issuer,
+		Audience:  cfg.JWT.Audience,
+		Expiry:    cfg.JWT.TokenExpiry,
+		ClockSkew: cfg.JWT.ClockSkew,
+	}, revocationService)
+	userService := application.NewUserService(userRepo, infrastructure.NewRefreshTokenRepository(db), tokenService, cfg.JWT.RefreshExpiry, accessPolicy)
+	workspaceService := application.NewWorkspaceService(workspaceRepo, accessPolicy)
+	labelService := application.NewLabelService(labelRepo, taskRepo, accessPolicy)
+	projectService := application.NewProjectService(projectRepo, taskRepo, accessPolicy)
+	dependencyService := application.NewDependencyService(dependencyRepo, taskRepo, projectRepo, accessPolicy)
+	commentService := application.NewCommentService(commentRepo, taskRepo, userRepo, accessPolicy)
+	attachmentService := application.NewAttachmentService(attachmentRepo, taskRepo, blobs, application.AttachmentLimits{
+		MaxSize:      cfg.Storage.MaxUploadSize,
+		AllowedTypes: cfg.Storage.AllowedTypes,
+	}, accessPolicy)
+	timeService := application.NewTimeService(timeEntryRepo, taskRepo, projectRepo, accessPolicy)
+	checklistService := application.NewChecklistService(checklistRepo, taskRepo, accessPolicy)
+	customFieldService := application.NewCustomFieldService(customFieldRepo, taskRepo, userRepo, accessPolicy)
+	templateService := application.NewTemplateService(templateRepo, labelRepo, accessPolicy)
+	boardService := application.NewBoardService(boardRepo, projectRepo, taskRepo, taskService, accessPolicy)
+	sprintService := application.NewSprintService(sprintRepo, projectRepo, taskService, accessPolicy)
+	trashService := application.NewTrashService(taskRepo, userRepo, blobs, cfg.Trash.Retention, accessPolicy)
 
 	// Initialize GraphQL resolver
-	resolver := resolvers.NewResolver(taskService, userService)
+	resolver := resolvers.NewResolver(taskService, userService, labelService, projectService, dependencyService, commentService, attachmentService, trashService, timeService, checklistService, customFieldService, templateService, boardService, sprintService, tokenService)
 
 	// Initialize handlers
-	authHandler := interfaces.NewAuthHandler(userService, []byte(cfg.JWT.Secret))
+	authHandler := interfaces.NewAuthHandler(userService, tokenService)
 	userHandler := interfaces.NewUserHandler(userService)
 	taskHandler := interfaces.NewTaskHandler(taskService)
+	workspaceHandler := interfaces.NewWorkspaceHandler(workspaceService)
+	labelHandler := interfaces.NewLabelHandler(labelService)
+	projectHandler := interfaces.NewProjectHandler(projectService)
+	dependencyHandler := interfaces.NewDependencyHandler(dependencyService)
+	commentHandler := interfaces.NewCommentHandler(commentService)
+	attachmentHandler := interfaces.NewAttachmentHandler(attachmentService)
+	trashHandler := interfaces.NewTrashHandler(trashService)
+	timeHandler := interfaces.NewTimeHandler(timeService)
+	checklistHandler := interfaces.NewChecklistHandler(checklistService)
+	customFieldHandler := interfaces.NewCustomFieldHandler(customFieldService)
+	templateHandler := interfaces.NewTemplateHandler(templateService, taskService)
+	boardHandler := interfaces.NewBoardHandler(boardService)
+	sprintHandler := interfaces.NewSprintHandler(sprintService)
 
 	// Public routes
 	router.GET("/playground", playgroundHandler())
@@ -83,10 +258,12 @@ func main() {
 	router.POST("/api/v1/login", authHandler.Login)
 	router.POST("/api/v1/refresh-token", authHandler.RefreshToken)
 	router.POST("/api/v1/logout", authHandler.Logout)
+	router.POST("/api/v1/logout-all", authHandler.LogoutEverywhere)
+	router.GET("/.well-known/jwks.json", authHandler.JWKS)
 
 	// Protected routes
 	protected := router.Group("/api/v1/protected")
-	protected.Use(middleware.AuthMiddleware([]byte(cfg.JWT.Secret)))
+	protected.Use(middleware.AuthMiddleware(tokenService))
 	protected.POST("/graphql", graphqlHandler(resolver)) // For authenticated operations
 
 	// User routes
@@ -101,6 +278,129 @@ func main() {
 	protected.GET("/tasks/:id", taskHandler.GetTaskByID)
 	protected.PUT("/tasks/:id", taskHandler.UpdateTask)
 	protected.DELETE("/tasks/:id", taskHandler.DeleteTask)
+	protected.POST("/tasks/:id/archive", taskHandler.ArchiveTask)
+	protected.DELETE("/tasks/:id/archive", taskHandler.UnarchiveTask)
+	protected.PUT("/tasks/:id/status", taskHandler.ChangeTaskStatus)
+	protected.GET("/tasks/:id/subtasks", taskHandler.GetSubtasks)
+	protected.GET("/tasks/:id/history", taskHandler.GetTaskHistory)
+	protected.GET("/tasks/:id/occurrences", taskHandler.GetOccurrences)
+	protected.POST("/tasks/:id/recurrence/skip", taskHandler.SkipOccurrence)
+	protected.DELETE("/tasks/:id/recurrence", taskHandler.EndSeries)
+	protected.GET("/tasks/:id/comments", commentHandler.GetComments)
+	protected.POST("/tasks/:id/comments", commentHandler.CreateComment)
+	protected.GET("/tasks/:id/time", timeHandler.GetTaskTime)
+	protected.GET("/tasks/:id/time-entries", timeHandler.GetTaskTimeEntries)
+	protected.POST("/tasks/:id/time-entries", timeHandler.LogTime)
+	protected.POST("/tasks/:id/timer", timeHandler.StartTimer)
+	protected.GET("/tasks/:id/checklist", checklistHandler.GetChecklist)
+	protected.POST("/tasks/:id/checklist", checklistHandler.AddChecklistItem)
+	protected.PUT("/tasks/:id/checklist/order", checklistHandler.ReorderChecklist)
+	protected.GET("/tasks/:id/attachments", attachmentHandler.GetAttachments)
+	protected.POST("/tasks/:id/attachments", attachmentHandler.UploadAttachment)
+	protected.POST("/tasks/:id/assignees/:userId", taskHandler.AssignTask)
+	protected.DELETE("/tasks/:id/assignees/:userId", taskHandler.UnassignTask)
+	protected.POST("/tasks/:id/watchers/:userId", taskHandler.WatchTask)
+	protected.DELETE("/tasks/:id/watchers/:userId", taskHandler.UnwatchTask)
+	protected.POST("/tasks/:id/labels/:labelId", labelHandler.AddLabelToTask)
+	protected.DELETE("/tasks/:id/labels/:labelId", labelHandler.RemoveLabelFromTask)
+	protected.PUT("/tasks/:id/custom-fields/:fieldId", customFieldHandler.SetCustomFieldValue)
+	protected.DELETE("/tasks/:id/custom-fields/:fieldId", customFieldHandler.ClearCustomFieldValue)
+	protected.POST("/tasks/:id/move", boardHandler.MoveTask)
+	protected.GET("/tasks/:id/dependencies", dependencyHandler.GetBlockers)
+	protected.POST("/tasks/:id/dependencies/:blockerId", dependencyHandler.AddDependency)
+	protected.DELETE("/tasks/:id/dependencies/:blockerId", dependencyHandler.RemoveDependency)
+
+	// Comment routes
+	protected.GET("/comments/:id", commentHandler.GetCommentByID)
+	protected.PUT("/comments/:id", commentHandler.UpdateComment)
+	protected.DELETE("/comments/:id", commentHandler.DeleteComment)
+	protected.GET("/comments/:id/history", commentHandler.GetCommentHistory)
+
+	// Checklist routes
+	protected.POST("/checklist-items/:id/toggle", checklistHandler.ToggleChecklistItem)
+	protected.DELETE("/checklist-items/:id", checklistHandler.DeleteChecklistItem)
+
+	// Attachment routes
+	protected.GET("/attachments/:id", attachmentHandler.GetAttachment)
+	protected.GET("/attachments/:id/content", attachmentHandler.DownloadAttachment)
+	protected.DELETE("/attachments/:id", attachmentHandler.DeleteAttachment)
+
+	// Time tracking routes
+	protected.GET("/timer", timeHandler.GetRunningTimer)
+	protected.POST("/timer/stop", timeHandler.StopTimer)
+	protected.GET("/time-entries", timeHandler.GetTimeEntries)
+	protected.GET("/time-entries/report", timeHandler.GetTimeReport)
+	protected.GET("/time-entries/:id", timeHandler.GetTimeEntry)
+	protected.PUT("/time-entries/:id", timeHandler.UpdateTimeEntry)
+	protected.DELETE("/time-entries/:id", timeHandler.DeleteTimeEntry)
+
+	// Trash routes
+	protected.GET("/trash/tasks", trashHandler.GetDeletedTasks)
+	protected.POST("/trash/tasks/:id/restore", trashHandler.RestoreTask)
+	protected.DELETE("/trash/tasks/:id", trashHandler.PurgeTask)
+	protected.GET("/trash/users", trashHandler.GetDeletedUsers)
+	protected.POST("/trash/users/:id/restore", trashHandler.RestoreUser)
+	protected.DELETE("/trash/users/:id", trashHandler.PurgeUser)
+
+	// Project routes
+	protected.GET("/projects", projectHandler.GetProjects)
+	protected.POST("/projects", projectHandler.CreateProject)
+	protected.GET("/projects/:id", projectHandler.GetProjectByID)
+	protected.PUT("/projects/:id", projectHandler.UpdateProject)
+	protected.DELETE("/projects/:id", projectHandler.DeleteProject)
+	protected.GET("/projects/:id/tasks", projectHandler.GetProjectTasks)
+	protected.POST("/projects/:id/tasks", projectHandler.MoveTasks)
+	protected.GET("/projects/:id/dependencies", dependencyHandler.GetProjectGraph)
+	protected.GET("/projects/:id/board", boardHandler.GetBoard)
+	protected.GET("/projects/:id/board/columns", boardHandler.GetBoardColumns)
+	protected.PUT("/projects/:id/board/columns", boardHandler.SetBoardColumns)
+	protected.GET("/projects/:id/sprints", sprintHandler.GetSprints)
+	protected.POST("/projects/:id/sprints", sprintHandler.CreateSprint)
+
+	// Sprint routes
+	protected.GET("/sprints/:id", sprintHandler.GetSprintByID)
+	protected.PUT("/sprints/:id", sprintHandler.UpdateSprint)
+	protected.DELETE("/sprints/:id", sprintHandler.DeleteSprint)
+	protected.POST("/sprints/:id/tasks", sprintHandler.AddSprintTasks)
+	protected.DELETE("/sprints/:id/tasks/:taskId", sprintHandler.RemoveSprintTask)
+	protected.GET("/sprints/:id/burndown", sprintHandler.GetBurndown)
+
+	// Label routes
+	protected.GET("/labels", labelHandler.GetLabels)
+	protected.POST("/labels", labelHandler.CreateLabel)
+	protected.GET("/labels/:id", labelHandler.GetLabelByID)
+	protected.PUT("/labels/:id", labelHandler.UpdateLabel)
+	protected.DELETE("/labels/:id", labelHandler.DeleteLabel)
+
+	// Template routes
+	protected.GET("/templates", templateHandler.GetTemplates)
+	protected.POST("/templates", templateHandler.CreateTemplate)
+	protected.GET("/templates/:id", templateHandler.GetTemplateByID)
+	protected.PUT("/templates/:id", templateHandler.UpdateTemplate)
+	protected.DELETE("/templates/:id", templateHandler.DeleteTemplate)
+	protected.POST("/templates/:id/instantiate", templateHandler.InstantiateTemplate)
+
+	// Workspace routes
+	protected.POST("/workspaces", workspaceHandler.CreateWorkspace)
+	protected.GET("/workspaces/:id", workspaceHandler.GetWorkspaceByID)
+	protected.GET("/workspaces/:id/workflow", workspaceHandler.GetWorkflow)
+	protected.PUT("/workspaces/:id/workflow", workspaceHandler.UpdateWorkflow)
+	protected.GET("/workspaces/:id/members", workspaceHandler.GetMembers)
+	protected.PUT("/workspaces/:id/members/:userId", workspaceHandler.SetMember)
+	protected.DELETE("/workspaces/:id/members/:userId", workspaceHandler.RemoveMember)
+
+	// Custom field routes
+	protected.GET("/workspaces/:id/custom-fields", customFieldHandler.GetCustomFields)
+	protected.POST("/workspaces/:id/custom-fields", customFieldHandler.CreateCustomField)
+	protected.GET("/custom-fields/:id", customFieldHandler.GetCustomFieldByID)
+	protected.PUT("/custom-fields/:id", customFieldHandler.UpdateCustomField)
+	protected.DELETE("/custom-fields/:id", customFieldHandler.DeleteCustomField)
+
+	go purgeTrash(trashService, cfg.Trash.PurgeInterval)
+	go pruneTokens(userService, revocationService, time.Hour)
+	if rule.Enabled() {
+		go autoArchive(taskService, rule, cfg.Archive.Interval)
+	}
 
 	log.Printf("Server running on http://%s:%s", cfg.Server.Host, cfg.Server.Port)
 	log.Printf("GraphQL playground available at http://%s:%s/playground", cfg.Server.Host, cfg.Server.Port)
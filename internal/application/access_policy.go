@@ -0,0 +1,235 @@
+package application
+
+import "task-manager-app/backend/internal/domain"
+
+// AccessPolicy decides what an actor may do, applying the permission matrix of the
+// workspace roles. Services check it before acting; the server itself, acting as the
+// system actor, and superadmins may do anything.
+type AccessPolicy struct {
+	users      domain.UserRepository
+	workspaces domain.WorkspaceRepository
+}
+
+func NewAccessPolicy(users domain.UserRepository, workspaces domain.WorkspaceRepository) *AccessPolicy {
+	return &AccessPolicy{users: users, workspaces: workspaces}
+}
+
+// unrestricted reports whether the actor bypasses the permission matrix.
+func (p *AccessPolicy) unrestricted(actor domain.Actor) (bool, error) {
+	if actor.Source == domain.SourceSystem {
+		return true, nil
+	}
+	if actor.UserID == 0 {
+		return false, nil
+	}
+	user, err := p.users.FindByID(actor.UserID)
+	if err != nil {
+		return false, nil // Users that cannot be found, deleted ones included, get no extra rights
+	}
+	return user.Superadmin, nil
+}
+
+// WorkspaceRole returns the user's role in the workspace. Only memberships count: the
+// owner recorded on the workspace loses their rights once their membership is removed.
+func (p *AccessPolicy) WorkspaceRole(userID, workspaceID int) (domain.Role, bool, error) {
+	if userID == 0 {
+		return "", false, nil
+	}
+	memberships, err := p.workspaces.FindMemberships(userID)
+	if err != nil {
+		return "", false, err
+	}
+	for _, membership := range memberships {
+		if membership.WorkspaceID == workspaceID {
+			return membership.Role, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// CheckWorkspace fails with domain.ErrForbidden unless the actor's role in the workspace
+// grants the permission. Labels, templates and the like kept outside any workspace, with
+// workspace 0, are shared by every signed in user as a member; managing them takes a superadmin.
+func (p *AccessPolicy) CheckWorkspace(actor domain.Actor, workspaceID int, perm domain.Permission) error {
+	if workspaceID == 0 && domain.RoleMember.Can(perm) {
+		return p.CheckCreate(actor, 0)
+	}
+	if workspaceID == 0 {
+		return p.CheckSuperadmin(actor)
+	}
+	if ok, err := p.unrestricted(actor); ok || err != nil {
+		return err
+	}
+	role, ok, err := p.WorkspaceRole(actor.UserID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if !ok || !role.Can(perm) {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// CheckCreate fails with domain.ErrForbidden unless the actor may create tasks in the
+// workspace. Any signed in user may create personal tasks, which have workspace 0.
+func (p *AccessPolicy) CheckCreate(actor domain.Actor, workspaceID int) error {
+	if workspaceID != 0 {
+		return p.CheckWorkspace(actor, workspaceID, domain.PermCreateTasks)
+	}
+	if actor.UserID == 0 && actor.Source != domain.SourceSystem {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// CheckSuperadmin fails with domain.ErrForbidden unless the actor may do anything, as
+// needed for managing other people's accounts.
+func (p *AccessPolicy) CheckSuperadmin(actor domain.Actor) error {
+	ok, err := p.unrestricted(actor)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// CheckTask fails with domain.ErrForbidden unless the actor may act on the task: through
+// their role in its workspace or, for a personal task, their relation to it. Members may
+// delete the tasks they created.
+func (p *AccessPolicy) CheckTask(actor domain.Actor, task *domain.Task, perm domain.Permission) error {
+	if ok, err := p.unrestricted(actor); ok || err != nil {
+		return err
+	}
+	var role domain.Role
+	var ok bool
+	if task.WorkspaceID != 0 {
+		var err error
+		if role, ok, err = p.WorkspaceRole(actor.UserID, task.WorkspaceID); err != nil {
+			return err
+		}
+	} else {
+		role, ok = task.PersonalRole(actor.UserID)
+	}
+	if !ok {
+		return domain.ErrForbidden
+	}
+	if role.Can(perm) || perm == domain.PermDeleteTasks && role.Can(domain.PermEditTasks) && task.UserID == actor.UserID {
+		return nil
+	}
+	return domain.ErrForbidden
+}
+
+// CheckProject fails with domain.ErrForbidden unless the actor's role in the project's
+// workspace grants the permission. A project outside any workspace is its owner's alone.
+func (p *AccessPolicy) CheckProject(actor domain.Actor, project *domain.Project, perm domain.Permission) error {
+	if project.WorkspaceID != 0 {
+		return p.CheckWorkspace(actor, project.WorkspaceID, perm)
+	}
+	if ok, err := p.unrestricted(actor); ok || err != nil {
+		return err
+	}
+	if actor.UserID == 0 || project.OwnerID != actor.UserID {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// loadTask loads a task through the repository of the calling service and checks the actor
+// has the permission on it, for features attached to tasks such as comments or checklists.
+func (p *AccessPolicy) loadTask(tasks domain.TaskRepository, id int, actor domain.Actor, perm domain.Permission) (*domain.Task, error) {
+	task, err := tasks.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckTask(actor, task, perm); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// loadProject is loadTask for projects and what hangs off them, such as boards and sprints.
+func (p *AccessPolicy) loadProject(projects domain.ProjectRepository, id int, actor domain.Actor, perm domain.Permission) (*domain.Project, error) {
+	project, err := projects.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckProject(actor, project, perm); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// CheckUser fails with domain.ErrForbidden unless the actor may act on the user's account.
+// Users manage their own account; seeing someone else's needs a workspace in common.
+func (p *AccessPolicy) CheckUser(actor domain.Actor, userID int, edit bool) error {
+	if ok, err := p.unrestricted(actor); ok || err != nil {
+		return err
+	}
+	if actor.UserID != 0 && actor.UserID == userID {
+		return nil
+	}
+	if edit {
+		return domain.ErrForbidden
+	}
+	visible, err := p.VisibleUsers(actor)
+	if err != nil {
+		return err
+	}
+	if !visible[userID] {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// VisibleUsers returns the IDs of the users the actor may see: themselves and the members
+// of their workspaces. It returns nil when the actor may see everyone.
+func (p *AccessPolicy) VisibleUsers(actor domain.Actor) (map[int]bool, error) {
+	if ok, err := p.unrestricted(actor); ok || err != nil {
+		return nil, err
+	}
+	visible := map[int]bool{actor.UserID: true}
+	workspaceIDs, err := p.workspaceIDs(actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range workspaceIDs {
+		members, err := p.workspaces.FindMembers(id)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			visible[member.UserID] = true
+		}
+	}
+	return visible, nil
+}
+
+// TaskAccess returns what limits the tasks the actor may see, nil when they may see all.
+func (p *AccessPolicy) TaskAccess(actor domain.Actor) (*domain.TaskAccess, error) {
+	if ok, err := p.unrestricted(actor); ok || err != nil {
+		return nil, err
+	}
+	workspaceIDs, err := p.workspaceIDs(actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.TaskAccess{UserID: actor.UserID, WorkspaceIDs: workspaceIDs}, nil
+}
+
+// workspaceIDs returns the workspaces the user is a member of.
+func (p *AccessPolicy) workspaceIDs(userID int) ([]int, error) {
+	if userID == 0 {
+		return nil, nil
+	}
+	memberships, err := p.workspaces.FindMemberships(userID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, 0, len(memberships))
+	for _, membership := range memberships {
+		ids = append(ids, membership.WorkspaceID)
+	}
+	return ids, nil
+}
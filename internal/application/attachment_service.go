@@ -0,0 +1,250 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"task-manager-app/backend/internal/domain"
+	"unicode"
+)
+
+// sniffLength is how much content http.DetectContentType looks at.
+const sniffLength = 512
+
+// AttachmentLimits bounds what can be uploaded.
+type AttachmentLimits struct {
+	MaxSize      int64
+	AllowedTypes []string // Content type prefixes such as "image/" or "application/pdf"; empty allows every type
+}
+
+type AttachmentService struct {
+	repo     domain.AttachmentRepository
+	taskRepo domain.TaskRepository
+	blobs    domain.BlobStore
+	limits   AttachmentLimits
+	policy   *AccessPolicy
+}
+
+func NewAttachmentService(repo domain.AttachmentRepository, taskRepo domain.TaskRepository, blobs domain.BlobStore, limits AttachmentLimits, policy *AccessPolicy) *AttachmentService {
+	return &AttachmentService{repo: repo, taskRepo: taskRepo, blobs: blobs, limits: limits, policy: policy}
+}
+
+// MaxSize is the largest accepted upload in bytes, 0 when unlimited.
+func (s *AttachmentService) MaxSize() int64 {
+	return s.limits.MaxSize
+}
+
+// Upload streams a file into the blob store and records its metadata. The content type is
+// sniffed from the first bytes instead of trusting the client. Uploading takes editing
+// rights on the task.
+func (s *AttachmentService) Upload(ctx context.Context, taskID int, filename string, content io.Reader, size int64, actor domain.Actor) (*domain.Attachment, error) {
+	if size <= 0 {
+		return nil, domain.ErrEmptyAttachment
+	}
+	if s.limits.MaxSize > 0 && size > s.limits.MaxSize {
+		return nil, domain.ErrAttachmentTooLarge
+	}
+	if _, err := s.policy.loadTask(s.taskRepo, taskID, actor, domain.PermEditTasks); err != nil {
+		return nil, err
+	}
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	head = head[:n]
+	contentType := http.DetectContentType(head)
+	if !s.allowed(contentType) {
+		return nil, domain.ErrAttachmentType
+	}
+
+	key, err := newBlobKey(taskID)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.New()
+	counter := &countingWriter{}
+	body := io.TeeReader(io.LimitReader(io.MultiReader(bytes.NewReader(head), content), size), io.MultiWriter(hash, counter))
+	if err := s.blobs.Put(ctx, key, body, size, contentType); err != nil {
+		return nil, err
+	}
+	if counter.n != size {
+		s.blobs.Delete(ctx, key)
+		return nil, domain.ErrAttachmentSizeMismatch
+	}
+
+	attachment := &domain.Attachment{
+		TaskID:      taskID,
+		Filename:    cleanFilename(filename),
+		Size:        size,
+		ContentType: contentType,
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+		StorageKey:  key,
+		UploaderID:  actor.UserID,
+	}
+	if err := s.repo.Create(attachment); err != nil {
+		s.blobs.Delete(ctx, key)
+		return nil, err
+	}
+	return attachment, nil
+}
+
+func (s *AttachmentService) GetAttachment(id int, actor domain.Actor) (*domain.Attachment, error) {
+	attachment, _, err := s.load(id, actor, domain.PermViewTasks)
+	return attachment, err
+}
+
+func (s *AttachmentService) GetAttachments(taskID int, actor domain.Actor) ([]domain.Attachment, error) {
+	if _, err := s.policy.loadTask(s.taskRepo, taskID, actor, domain.PermViewTasks); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByTaskID(taskID)
+}
+
+// Open returns the attachment with a seekable reader over its content, so downloads can
+// serve byte ranges without reading the whole blob.
+func (s *AttachmentService) Open(ctx context.Context, id int, actor domain.Actor) (*domain.Attachment, io.ReadSeekCloser, error) {
+	attachment, _, err := s.load(id, actor, domain.PermViewTasks)
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, &blobReader{ctx: ctx, store: s.blobs, key: attachment.StorageKey, size: attachment.Size}, nil
+}
+
+// DeleteAttachment removes an attachment; only its uploader or the task owner may do so,
+// and only while they may edit the task.
+func (s *AttachmentService) DeleteAttachment(ctx context.Context, id int, actor domain.Actor) error {
+	attachment, task, err := s.load(id, actor, domain.PermEditTasks)
+	if err != nil {
+		return err
+	}
+	if actor.UserID == 0 || (actor.UserID != attachment.UploaderID && actor.UserID != task.UserID) {
+		return domain.ErrAttachmentForbidden
+	}
+	if err := s.repo.Delete(id); err != nil {
+		return err
+	}
+	return s.blobs.Delete(ctx, attachment.StorageKey)
+}
+
+// load finds an attachment and checks the actor has the permission on its task.
+func (s *AttachmentService) load(id int, actor domain.Actor, perm domain.Permission) (*domain.Attachment, *domain.Task, error) {
+	attachment, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	task, err := s.policy.loadTask(s.taskRepo, attachment.TaskID, actor, perm)
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, task, nil
+}
+
+func (s *AttachmentService) allowed(contentType string) bool {
+	if len(s.limits.AllowedTypes) == 0 {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, prefix := range s.limits.AllowedTypes {
+		if strings.HasPrefix(mediaType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func newBlobKey(taskID int) (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("tasks/%d/%s", taskID, hex.EncodeToString(id)), nil
+}
+
+// cleanFilename drops directories and control characters from a client supplied filename.
+func cleanFilename(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "" || name == "." || name == "/" {
+		return "file"
+	}
+	return name
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// blobReader reads a blob lazily from the current offset, reopening it after a seek.
+type blobReader struct {
+	ctx    context.Context
+	store  domain.BlobStore
+	key    string
+	size   int64
+	offset int64
+	body   io.ReadCloser
+}
+
+func (r *blobReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+	if r.body == nil {
+		body, err := r.store.Get(r.ctx, r.key, r.offset, -1)
+		if err != nil {
+			return 0, err
+		}
+		r.body = body
+	}
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	return n, err
+}
+
+func (r *blobReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	}
+	if offset < 0 {
+		return 0, errors.New("seek before start of blob")
+	}
+	if offset != r.offset && r.body != nil {
+		r.body.Close()
+		r.body = nil
+	}
+	r.offset = offset
+	return offset, nil
+}
+
+func (r *blobReader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+	return r.body.Close()
+}
@@ -0,0 +1,151 @@
+package application
+
+import (
+	"strconv"
+	"strings"
+	"task-manager-app/backend/internal/domain"
+)
+
+// BoardService provides the kanban view of projects and manages their custom column sets.
+// Moves are carried out by TaskService so workflow rules and the activity log apply.
+type BoardService struct {
+	repo     domain.BoardRepository
+	projects domain.ProjectRepository
+	taskRepo domain.TaskRepository
+	tasks    *TaskService
+	policy   *AccessPolicy
+}
+
+func NewBoardService(repo domain.BoardRepository, projects domain.ProjectRepository, taskRepo domain.TaskRepository, tasks *TaskService, policy *AccessPolicy) *BoardService {
+	return &BoardService{repo: repo, projects: projects, taskRepo: taskRepo, tasks: tasks, policy: policy}
+}
+
+// GetBoard returns the columns of a project's board with up to limit tasks each, in manual
+// order. Projects without a custom column set get one column per status. Lanes only hold
+// the tasks the actor may see.
+func (s *BoardService) GetBoard(projectID, limit int, actor domain.Actor) (*domain.Board, error) {
+	if _, err := s.policy.loadProject(s.projects, projectID, actor, domain.PermViewTasks); err != nil {
+		return nil, err
+	}
+	access, err := s.policy.TaskAccess(actor)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := s.repo.FindColumns(projectID)
+	if err != nil {
+		return nil, err
+	}
+	board := &domain.Board{ProjectID: projectID, GroupBy: domain.GroupByStatus}
+	if len(columns) > 0 {
+		board.GroupBy = domain.GroupByCustom
+		for i, column := range columns {
+			board.Columns = append(board.Columns, domain.BoardLane{Key: strconv.Itoa(column.ID), Name: column.Name, ColumnID: column.ID})
+			if err := s.fillLane(&board.Columns[i], domain.BoardTarget{ColumnID: column.ID, Default: i == 0}, projectID, limit, access); err != nil {
+				return nil, err
+			}
+		}
+		return board, nil
+	}
+	for i, status := range domain.TaskStatuses {
+		board.Columns = append(board.Columns, domain.BoardLane{Key: string(status), Name: statusName(status), Status: status})
+		if err := s.fillLane(&board.Columns[i], domain.BoardTarget{Status: status}, projectID, limit, access); err != nil {
+			return nil, err
+		}
+	}
+	return board, nil
+}
+
+func (s *BoardService) fillLane(lane *domain.BoardLane, target domain.BoardTarget, projectID, limit int, access *domain.TaskAccess) error {
+	filter := target.Filter(projectID)
+	filter.Page, filter.Limit = 1, limit
+	filter.Access = access
+	tasks, err := s.taskRepo.FindAll(filter)
+	if err != nil {
+		return err
+	}
+	lane.Tasks = *tasks
+	return nil
+}
+
+func (s *BoardService) GetColumns(projectID int, actor domain.Actor) ([]domain.BoardColumn, error) {
+	if _, err := s.policy.loadProject(s.projects, projectID, actor, domain.PermViewTasks); err != nil {
+		return nil, err
+	}
+	return s.repo.FindColumns(projectID)
+}
+
+// SetColumns replaces the custom column set of a project, in the given order. Columns keep
+// their tasks when listed with their ID; an empty set brings back the status columns.
+// Changing the board takes the right to manage the project's workspace.
+func (s *BoardService) SetColumns(projectID int, columns []domain.BoardColumn, actor domain.Actor) ([]domain.BoardColumn, error) {
+	if _, err := s.policy.loadProject(s.projects, projectID, actor, domain.PermManageWorkspace); err != nil {
+		return nil, err
+	}
+	current, err := s.repo.FindColumns(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateBoardColumns(columns); err != nil {
+		return nil, err
+	}
+	existing := map[int]bool{}
+	for _, column := range current {
+		existing[column.ID] = true
+	}
+	for _, column := range columns {
+		if column.ID != 0 && !existing[column.ID] {
+			return nil, domain.ErrUnknownColumn
+		}
+	}
+	if err := s.repo.ReplaceColumns(projectID, columns, actor); err != nil {
+		return nil, err
+	}
+	return s.repo.FindColumns(projectID)
+}
+
+// MoveTask moves a task to a column of its project's board, given by status or by custom
+// column ID, between the neighbours before and after.
+func (s *BoardService) MoveTask(id int, column string, beforeID, afterID int, actor domain.Actor) (*domain.Task, error) {
+	task, err := s.policy.loadTask(s.taskRepo, id, actor, domain.PermEditTasks)
+	if err != nil {
+		return nil, err
+	}
+	if task.ProjectID == 0 {
+		return nil, domain.ErrNoBoard
+	}
+	target, err := s.target(task.ProjectID, column)
+	if err != nil {
+		return nil, err
+	}
+	return s.tasks.MoveTask(id, target, beforeID, afterID, actor)
+}
+
+func (s *BoardService) target(projectID int, column string) (domain.BoardTarget, error) {
+	columns, err := s.repo.FindColumns(projectID)
+	if err != nil {
+		return domain.BoardTarget{}, err
+	}
+	if len(columns) == 0 {
+		status := domain.TaskStatus(strings.ToLower(column))
+		if !status.IsValid() {
+			return domain.BoardTarget{}, domain.ErrUnknownColumn
+		}
+		return domain.BoardTarget{Status: status}, nil
+	}
+	id, err := strconv.Atoi(column)
+	if err != nil {
+		return domain.BoardTarget{}, domain.ErrUnknownColumn
+	}
+	for i, c := range columns {
+		if c.ID == id {
+			return domain.BoardTarget{ColumnID: id, Default: i == 0}, nil
+		}
+	}
+	return domain.BoardTarget{}, domain.ErrUnknownColumn
+}
+
+// statusName is the column title of a status, e.g. "In progress".
+func statusName(status domain.TaskStatus) string {
+	name := strings.ReplaceAll(string(status), "_", " ")
+	return strings.ToUpper(name[:1]) + name[1:]
+}
@@ -0,0 +1,105 @@
+package application
+
+import (
+	"strings"
+	"task-manager-app/backend/internal/domain"
+	"time"
+)
+
+type ChecklistService struct {
+	repo     domain.ChecklistRepository
+	taskRepo domain.TaskRepository
+	policy   *AccessPolicy
+}
+
+func NewChecklistService(repo domain.ChecklistRepository, taskRepo domain.TaskRepository, policy *AccessPolicy) *ChecklistService {
+	return &ChecklistService{repo: repo, taskRepo: taskRepo, policy: policy}
+}
+
+// GetChecklist returns the checklist of a task in order.
+func (s *ChecklistService) GetChecklist(taskID int, actor domain.Actor) ([]domain.ChecklistItem, error) {
+	if _, err := s.policy.loadTask(s.taskRepo, taskID, actor, domain.PermViewTasks); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByTask(taskID)
+}
+
+// AddItem appends an unchecked item to the end of a task's checklist.
+func (s *ChecklistService) AddItem(taskID int, text string, actor domain.Actor) (*domain.ChecklistItem, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, domain.ErrEmptyChecklistItem
+	}
+	if _, err := s.policy.loadTask(s.taskRepo, taskID, actor, domain.PermEditTasks); err != nil {
+		return nil, err
+	}
+	item := &domain.ChecklistItem{TaskID: taskID, Text: text}
+	if err := s.repo.Create(item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (s *ChecklistService) GetItem(id int, actor domain.Actor) (*domain.ChecklistItem, error) {
+	return s.loadItem(id, actor, domain.PermViewTasks)
+}
+
+// loadItem finds a checklist item and checks the actor has the permission on its task.
+func (s *ChecklistService) loadItem(id int, actor domain.Actor, perm domain.Permission) (*domain.ChecklistItem, error) {
+	item, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.policy.loadTask(s.taskRepo, item.TaskID, actor, perm); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// ToggleItem checks or unchecks an item on behalf of the actor.
+func (s *ChecklistService) ToggleItem(id int, actor domain.Actor) (*domain.ChecklistItem, error) {
+	item, err := s.loadItem(id, actor, domain.PermEditTasks)
+	if err != nil {
+		return nil, err
+	}
+	item.Toggle(actor.UserID, time.Now())
+	if err := s.repo.Update(item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// ReorderChecklist puts the items of a task in the given order, which must name each of them once.
+func (s *ChecklistService) ReorderChecklist(taskID int, itemIDs []int, actor domain.Actor) ([]domain.ChecklistItem, error) {
+	if _, err := s.policy.loadTask(s.taskRepo, taskID, actor, domain.PermEditTasks); err != nil {
+		return nil, err
+	}
+	items, err := s.repo.FindByTask(taskID)
+	if err != nil {
+		return nil, err
+	}
+	if len(itemIDs) != len(items) {
+		return nil, domain.ErrChecklistOrder
+	}
+	remaining := make(map[int]bool, len(items))
+	for _, item := range items {
+		remaining[item.ID] = true
+	}
+	for _, id := range itemIDs {
+		if !remaining[id] {
+			return nil, domain.ErrChecklistOrder
+		}
+		delete(remaining, id)
+	}
+	if err := s.repo.Reorder(taskID, itemIDs); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByTask(taskID)
+}
+
+func (s *ChecklistService) DeleteItem(id int, actor domain.Actor) error {
+	if _, err := s.loadItem(id, actor, domain.PermEditTasks); err != nil {
+		return err
+	}
+	return s.repo.Delete(id)
+}
@@ -0,0 +1,178 @@
+package application
+
+import (
+	"strings"
+	"task-manager-app/backend/internal/domain"
+	"time"
+)
+
+type CommentService struct {
+	repo     domain.CommentRepository
+	taskRepo domain.TaskRepository
+	userRepo domain.UserRepository
+	policy   *AccessPolicy
+}
+
+func NewCommentService(repo domain.CommentRepository, taskRepo domain.TaskRepository, userRepo domain.UserRepository, policy *AccessPolicy) *CommentService {
+	return &CommentService{repo: repo, taskRepo: taskRepo, userRepo: userRepo, policy: policy}
+}
+
+// AddComment posts a comment by the actor on a task, or a reply when parentID is set.
+// Anyone who can see the task may comment on it.
+func (s *CommentService) AddComment(taskID, parentID int, body string, actor domain.Actor) (*domain.Comment, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, domain.ErrEmptyComment
+	}
+	task, err := s.policy.loadTask(s.taskRepo, taskID, actor, domain.PermViewTasks)
+	if err != nil {
+		return nil, err
+	}
+	if parentID != 0 {
+		parent, err := s.repo.FindByID(parentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.TaskID != taskID {
+			return nil, domain.ErrCommentParent
+		}
+		if parent.ParentID != 0 {
+			parentID = parent.ParentID
+		}
+	}
+
+	comment := &domain.Comment{
+		TaskID:   taskID,
+		AuthorID: actor.UserID,
+		ParentID: parentID,
+		Body:     body,
+		Mentions: s.resolveMentions(body, task),
+	}
+	if err := s.repo.Create(comment); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+func (s *CommentService) GetComment(id int, actor domain.Actor) (*domain.Comment, error) {
+	comment, _, err := s.load(id, actor)
+	return comment, err
+}
+
+// GetComments lists the top-level comments of a task, each with its replies.
+func (s *CommentService) GetComments(filter domain.CommentFilter, actor domain.Actor) (*domain.CommentConnection, error) {
+	if _, err := s.policy.loadTask(s.taskRepo, filter.TaskID, actor, domain.PermViewTasks); err != nil {
+		return nil, err
+	}
+	connection, err := s.repo.FindByTask(filter)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, len(connection.Edges))
+	for i, edge := range connection.Edges {
+		ids[i] = edge.Node.ID
+	}
+	replies, err := s.repo.FindReplies(ids)
+	if err != nil {
+		return nil, err
+	}
+	byParent := make(map[int][]domain.Comment)
+	for _, reply := range replies {
+		byParent[reply.ParentID] = append(byParent[reply.ParentID], reply)
+	}
+	for i := range connection.Edges {
+		connection.Edges[i].Node.Replies = byParent[connection.Edges[i].Node.ID]
+	}
+	return connection, nil
+}
+
+func (s *CommentService) GetReplies(id int, actor domain.Actor) ([]domain.Comment, error) {
+	if _, _, err := s.load(id, actor); err != nil {
+		return nil, err
+	}
+	return s.repo.FindReplies([]int{id})
+}
+
+// UpdateComment changes the body of a comment, keeping the previous body in its history.
+func (s *CommentService) UpdateComment(id int, body string, actor domain.Actor) (*domain.Comment, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, domain.ErrEmptyComment
+	}
+	comment, task, err := s.authorize(id, actor)
+	if err != nil {
+		return nil, err
+	}
+	if body == comment.Body {
+		return comment, nil
+	}
+
+	revision := &domain.CommentRevision{CommentID: id, Body: comment.Body, EditedBy: actor.UserID}
+	now := time.Now()
+	comment.Body = body
+	comment.EditedAt = &now
+	comment.Mentions = s.resolveMentions(body, task)
+	if err := s.repo.Update(comment, revision); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// DeleteComment removes a comment together with its replies.
+func (s *CommentService) DeleteComment(id int, actor domain.Actor) error {
+	if _, _, err := s.authorize(id, actor); err != nil {
+		return err
+	}
+	return s.repo.Delete(id)
+}
+
+// GetHistory returns the previous bodies of a comment, oldest first.
+func (s *CommentService) GetHistory(id int, actor domain.Actor) ([]domain.CommentRevision, error) {
+	if _, _, err := s.load(id, actor); err != nil {
+		return nil, err
+	}
+	return s.repo.FindRevisions(id)
+}
+
+// load finds a comment on a task the actor can see, together with that task.
+func (s *CommentService) load(id int, actor domain.Actor) (*domain.Comment, *domain.Task, error) {
+	comment, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	task, err := s.policy.loadTask(s.taskRepo, comment.TaskID, actor, domain.PermViewTasks)
+	if err != nil {
+		return nil, nil, err
+	}
+	return comment, task, nil
+}
+
+// authorize loads a comment the actor is allowed to change, together with its task.
+func (s *CommentService) authorize(id int, actor domain.Actor) (*domain.Comment, *domain.Task, error) {
+	comment, task, err := s.load(id, actor)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !comment.CanBeChangedBy(actor.UserID, task) {
+		return nil, nil, domain.ErrCommentForbidden
+	}
+	return comment, task, nil
+}
+
+// resolveMentions turns the @email mentions of a body into mention records. Emails of
+// people who cannot see the task are ignored like unknown ones, so mentions neither reveal
+// who has an account nor notify anyone about a task they cannot open.
+func (s *CommentService) resolveMentions(body string, task *domain.Task) []domain.CommentMention {
+	mentions := []domain.CommentMention{}
+	for _, email := range domain.ParseMentions(body) {
+		user, err := s.userRepo.FindByEmail(email)
+		if err != nil {
+			continue
+		}
+		if s.policy.CheckTask(domain.Actor{UserID: user.ID}, task, domain.PermViewTasks) != nil {
+			continue
+		}
+		mentions = append(mentions, domain.CommentMention{UserID: user.ID, Email: user.Email})
+	}
+	return mentions
+}
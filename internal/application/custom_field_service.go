@@ -0,0 +1,161 @@
+package application
+
+import (
+	"strings"
+	"task-manager-app/backend/internal/domain"
+)
+
+type CustomFieldService struct {
+	repo     domain.CustomFieldRepository
+	taskRepo domain.TaskRepository
+	userRepo domain.UserRepository
+	policy   *AccessPolicy
+}
+
+func NewCustomFieldService(repo domain.CustomFieldRepository, taskRepo domain.TaskRepository, userRepo domain.UserRepository, policy *AccessPolicy) *CustomFieldService {
+	return &CustomFieldService{repo: repo, taskRepo: taskRepo, userRepo: userRepo, policy: policy}
+}
+
+// loadField finds a field and checks the actor has the permission in its workspace.
+func (s *CustomFieldService) loadField(id int, actor domain.Actor, perm domain.Permission) (*domain.CustomField, error) {
+	field, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.policy.CheckWorkspace(actor, field.WorkspaceID, perm); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+// CreateField defines a field in a workspace; defining fields takes the right to manage it.
+func (s *CustomFieldService) CreateField(field *domain.CustomField, actor domain.Actor) error {
+	if err := s.policy.CheckWorkspace(actor, field.WorkspaceID, domain.PermManageWorkspace); err != nil {
+		return err
+	}
+	if err := s.validate(field); err != nil {
+		return err
+	}
+	return s.repo.Create(field)
+}
+
+func (s *CustomFieldService) GetField(id int, actor domain.Actor) (*domain.CustomField, error) {
+	return s.loadField(id, actor, domain.PermViewTasks)
+}
+
+func (s *CustomFieldService) GetFields(workspaceID int, actor domain.Actor) ([]domain.CustomField, error) {
+	if err := s.policy.CheckWorkspace(actor, workspaceID, domain.PermViewTasks); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByWorkspaceID(workspaceID)
+}
+
+// UpdateField renames a field or changes its options; its workspace and type stay as they are.
+func (s *CustomFieldService) UpdateField(field *domain.CustomField, actor domain.Actor) error {
+	current, err := s.loadField(field.ID, actor, domain.PermManageWorkspace)
+	if err != nil {
+		return err
+	}
+	field.WorkspaceID = current.WorkspaceID
+	field.Type = current.Type
+	field.CreatedAt = current.CreatedAt
+	if err := s.validate(field); err != nil {
+		return err
+	}
+	return s.repo.Update(field)
+}
+
+// DeleteField removes a field definition together with its values on every task.
+func (s *CustomFieldService) DeleteField(id int, actor domain.Actor) error {
+	if _, err := s.loadField(id, actor, domain.PermManageWorkspace); err != nil {
+		return err
+	}
+	return s.repo.Delete(id)
+}
+
+// SetValue validates a value against its field and stores it on a task of the same workspace.
+func (s *CustomFieldService) SetValue(taskID, fieldID int, value domain.CustomFieldValue, actor domain.Actor) (*domain.Task, error) {
+	field, task, err := s.findPair(taskID, fieldID, actor)
+	if err != nil {
+		return nil, err
+	}
+	normalized, err := field.Normalize(value)
+	if err != nil {
+		return nil, err
+	}
+	if normalized.UserID != 0 {
+		if err := s.checkUser(field, task, normalized.UserID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.SetValue(taskID, normalized); err != nil {
+		return nil, err
+	}
+	return s.taskRepo.FindByID(taskID)
+}
+
+func (s *CustomFieldService) ClearValue(taskID, fieldID int, actor domain.Actor) (*domain.Task, error) {
+	if _, _, err := s.findPair(taskID, fieldID, actor); err != nil {
+		return nil, err
+	}
+	if err := s.repo.ClearValue(taskID, fieldID); err != nil {
+		return nil, err
+	}
+	return s.taskRepo.FindByID(taskID)
+}
+
+// findPair loads the field for a value on a task the actor may edit, and the task.
+func (s *CustomFieldService) findPair(taskID, fieldID int, actor domain.Actor) (*domain.CustomField, *domain.Task, error) {
+	task, err := s.policy.loadTask(s.taskRepo, taskID, actor, domain.PermEditTasks)
+	if err != nil {
+		return nil, nil, err
+	}
+	field, err := s.repo.FindByID(fieldID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if task.WorkspaceID != field.WorkspaceID {
+		return nil, nil, domain.ErrCustomFieldWorkspace
+	}
+	return field, task, nil
+}
+
+// checkUser rejects a user value pointing outside the field's workspace, or for a field kept
+// outside any workspace, at someone who cannot see the task. Such users are reported like
+// missing ones, so the field cannot be used to find out who has an account.
+func (s *CustomFieldService) checkUser(field *domain.CustomField, task *domain.Task, userID int) error {
+	if _, err := s.userRepo.FindByID(userID); err != nil {
+		return domain.ErrInvalidFieldValue
+	}
+	if field.WorkspaceID == 0 {
+		if s.policy.CheckTask(domain.Actor{UserID: userID}, task, domain.PermViewTasks) != nil {
+			return domain.ErrInvalidFieldValue
+		}
+		return nil
+	}
+	_, ok, err := s.policy.WorkspaceRole(userID, field.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.ErrInvalidFieldValue
+	}
+	return nil
+}
+
+// validate checks the definition and that no other field of the workspace has the same name.
+func (s *CustomFieldService) validate(field *domain.CustomField) error {
+	if err := field.Validate(); err != nil {
+		return err
+	}
+	existing, err := s.repo.FindByWorkspaceID(field.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	for _, other := range existing {
+		if other.ID != field.ID && strings.EqualFold(other.Name, field.Name) {
+			return domain.ErrDuplicateCustomField
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package application
+
+import (
+	"task-manager-app/backend/internal/domain"
+	"time"
+)
+
+type DependencyService struct {
+	repo     domain.DependencyRepository
+	taskRepo domain.TaskRepository
+	projects domain.ProjectRepository
+	policy   *AccessPolicy
+}
+
+func NewDependencyService(repo domain.DependencyRepository, taskRepo domain.TaskRepository, projects domain.ProjectRepository, policy *AccessPolicy) *DependencyService {
+	return &DependencyService{repo: repo, taskRepo: taskRepo, projects: projects, policy: policy}
+}
+
+// AddDependency marks taskID as blocked by blockedByID, rejecting edges that would create a cycle.
+func (s *DependencyService) AddDependency(taskID, blockedByID int, actor domain.Actor) (*domain.Task, error) {
+	if taskID == blockedByID {
+		return nil, domain.ErrSelfDependency
+	}
+	task, err := s.authorize(taskID, blockedByID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkCycle(taskID, blockedByID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(&domain.TaskDependency{TaskID: taskID, BlockedByID: blockedByID}); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (s *DependencyService) RemoveDependency(taskID, blockedByID int, actor domain.Actor) error {
+	if _, err := s.authorize(taskID, blockedByID, actor); err != nil {
+		return err
+	}
+	return s.repo.Delete(taskID, blockedByID)
+}
+
+// GetBlockers lists the tasks blocking a task, open or not.
+func (s *DependencyService) GetBlockers(taskID int, actor domain.Actor) ([]domain.Task, error) {
+	if _, err := s.policy.loadTask(s.taskRepo, taskID, actor, domain.PermViewTasks); err != nil {
+		return nil, err
+	}
+	return s.taskRepo.FindBlockers(taskID)
+}
+
+// GetProjectGraph returns the dependencies between the tasks of a project the actor can
+// see and its critical path.
+func (s *DependencyService) GetProjectGraph(projectID int, actor domain.Actor) (*domain.DependencyGraph, error) {
+	if _, err := s.policy.loadProject(s.projects, projectID, actor, domain.PermViewTasks); err != nil {
+		return nil, err
+	}
+	access, err := s.policy.TaskAccess(actor)
+	if err != nil {
+		return nil, err
+	}
+	connection, err := s.taskRepo.FindAll(domain.TaskFilter{ProjectID: projectID, Access: access})
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]domain.Task, 0, len(connection.Edges))
+	ids := make([]int, 0, len(connection.Edges))
+	for _, edge := range connection.Edges {
+		tasks = append(tasks, edge.Node)
+		ids = append(ids, edge.Node.ID)
+	}
+	dependencies, err := s.repo.FindByTaskIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	return domain.BuildDependencyGraph(tasks, dependencies, time.Now()), nil
+}
+
+// authorize loads a blocked task the actor may edit, checking they can also see its blocker.
+func (s *DependencyService) authorize(taskID, blockedByID int, actor domain.Actor) (*domain.Task, error) {
+	task, err := s.policy.loadTask(s.taskRepo, taskID, actor, domain.PermEditTasks)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.policy.loadTask(s.taskRepo, blockedByID, actor, domain.PermViewTasks); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// checkCycle walks the blockers of blockedByID; reaching taskID means the new edge closes a cycle.
+func (s *DependencyService) checkCycle(taskID, blockedByID int) error {
+	seen := map[int]bool{blockedByID: true}
+	level := []int{blockedByID}
+	for len(level) > 0 {
+		dependencies, err := s.repo.FindByTaskIDs(level)
+		if err != nil {
+			return err
+		}
+		level = level[:0]
+		for _, dep := range dependencies {
+			if dep.BlockedByID == taskID {
+				return domain.ErrDependencyCycle
+			}
+			if !seen[dep.BlockedByID] {
+				seen[dep.BlockedByID] = true
+				level = append(level, dep.BlockedByID)
+			}
+		}
+	}
+	return nil
+}
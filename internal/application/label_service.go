@@ -0,0 +1,126 @@
+package application
+
+import (
+	"strings"
+	"task-manager-app/backend/internal/domain"
+)
+
+type LabelService struct {
+	repo     domain.LabelRepository
+	taskRepo domain.TaskRepository
+	policy   *AccessPolicy
+}
+
+func NewLabelService(repo domain.LabelRepository, taskRepo domain.TaskRepository, policy *AccessPolicy) *LabelService {
+	return &LabelService{repo: repo, taskRepo: taskRepo, policy: policy}
+}
+
+// loadLabel finds a label and checks the actor has the permission in its workspace.
+func (s *LabelService) loadLabel(id int, actor domain.Actor, perm domain.Permission) (*domain.Label, error) {
+	label, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.policy.CheckWorkspace(actor, label.WorkspaceID, perm); err != nil {
+		return nil, err
+	}
+	return label, nil
+}
+
+// CreateLabel adds a label to a workspace; managing labels takes the right to manage it.
+func (s *LabelService) CreateLabel(label *domain.Label, actor domain.Actor) error {
+	if err := s.policy.CheckWorkspace(actor, label.WorkspaceID, domain.PermManageWorkspace); err != nil {
+		return err
+	}
+	if err := s.validate(label); err != nil {
+		return err
+	}
+	return s.repo.Create(label)
+}
+
+func (s *LabelService) GetLabelByID(id int, actor domain.Actor) (*domain.Label, error) {
+	return s.loadLabel(id, actor, domain.PermViewTasks)
+}
+
+func (s *LabelService) GetLabelsByWorkspaceID(workspaceID int, actor domain.Actor) ([]domain.Label, error) {
+	if err := s.policy.CheckWorkspace(actor, workspaceID, domain.PermViewTasks); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByWorkspaceID(workspaceID)
+}
+
+func (s *LabelService) UpdateLabel(label *domain.Label, actor domain.Actor) error {
+	current, err := s.loadLabel(label.ID, actor, domain.PermManageWorkspace)
+	if err != nil {
+		return err
+	}
+	label.WorkspaceID = current.WorkspaceID
+	label.CreatedAt = current.CreatedAt
+	if err := s.validate(label); err != nil {
+		return err
+	}
+	return s.repo.Update(label)
+}
+
+func (s *LabelService) DeleteLabel(id int, actor domain.Actor) error {
+	if _, err := s.loadLabel(id, actor, domain.PermManageWorkspace); err != nil {
+		return err
+	}
+	return s.repo.Delete(id)
+}
+
+// AddLabelToTask tags a task with a label from the same workspace.
+func (s *LabelService) AddLabelToTask(taskID, labelID int, actor domain.Actor) (*domain.Task, error) {
+	task, label, err := s.findPair(taskID, labelID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if task.WorkspaceID != label.WorkspaceID {
+		return nil, domain.ErrLabelWorkspace
+	}
+	if err := s.repo.AddToTask(taskID, labelID); err != nil {
+		return nil, err
+	}
+	return s.taskRepo.FindByID(taskID)
+}
+
+func (s *LabelService) RemoveLabelFromTask(taskID, labelID int, actor domain.Actor) (*domain.Task, error) {
+	if _, _, err := s.findPair(taskID, labelID, actor); err != nil {
+		return nil, err
+	}
+	if err := s.repo.RemoveFromTask(taskID, labelID); err != nil {
+		return nil, err
+	}
+	return s.taskRepo.FindByID(taskID)
+}
+
+// findPair loads a task the actor may edit and the label to tag it with.
+func (s *LabelService) findPair(taskID, labelID int, actor domain.Actor) (*domain.Task, *domain.Label, error) {
+	task, err := s.policy.loadTask(s.taskRepo, taskID, actor, domain.PermEditTasks)
+	if err != nil {
+		return nil, nil, err
+	}
+	label, err := s.repo.FindByID(labelID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return task, label, nil
+}
+
+// validate checks the label fields and that its name is unique within the workspace.
+func (s *LabelService) validate(label *domain.Label) error {
+	label.Name = strings.TrimSpace(label.Name)
+	if err := label.Validate(); err != nil {
+		return err
+	}
+	existing, err := s.repo.FindByWorkspaceID(label.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	for _, other := range existing {
+		if other.ID != label.ID && strings.EqualFold(other.Name, label.Name) {
+			return domain.ErrDuplicateLabel
+		}
+	}
+	return nil
+}
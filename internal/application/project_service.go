@@ -0,0 +1,157 @@
+package application
+
+import (
+	"errors"
+	"strings"
+	"task-manager-app/backend/internal/domain"
+)
+
+type ProjectService struct {
+	repo     domain.ProjectRepository
+	taskRepo domain.TaskRepository
+	policy   *AccessPolicy
+}
+
+func NewProjectService(repo domain.ProjectRepository, taskRepo domain.TaskRepository, policy *AccessPolicy) *ProjectService {
+	return &ProjectService{repo: repo, taskRepo: taskRepo, policy: policy}
+}
+
+// CreateProject creates a project on behalf of the actor, who becomes its owner. Creating
+// it in a workspace takes the right to manage the workspace; personal projects are free.
+func (s *ProjectService) CreateProject(project *domain.Project, actor domain.Actor) error {
+	if actor.UserID != 0 {
+		project.OwnerID = actor.UserID
+	}
+	if err := s.policy.CheckProject(actor, project, domain.PermManageWorkspace); err != nil {
+		return err
+	}
+	project.Name = strings.TrimSpace(project.Name)
+	if err := project.Validate(); err != nil {
+		return err
+	}
+	return s.repo.Create(project)
+}
+
+func (s *ProjectService) GetProjectByID(id int, actor domain.Actor) (*domain.Project, error) {
+	return s.policy.loadProject(s.repo, id, actor, domain.PermViewTasks)
+}
+
+// GetProjects lists the projects matching the filter that the actor may see.
+func (s *ProjectService) GetProjects(filter domain.ProjectFilter, actor domain.Actor) ([]domain.Project, error) {
+	projects, err := s.repo.FindAll(filter)
+	if err != nil {
+		return nil, err
+	}
+	visible := make([]domain.Project, 0, len(projects))
+	for _, project := range projects {
+		err := s.policy.CheckProject(actor, &project, domain.PermViewTasks)
+		if errors.Is(err, domain.ErrForbidden) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		visible = append(visible, project)
+	}
+	return visible, nil
+}
+
+// UpdateProject saves a project, which takes the right to manage its workspace, and that of
+// the workspace it moves to, if any. Only projects without tasks move to another workspace.
+func (s *ProjectService) UpdateProject(project *domain.Project, actor domain.Actor) error {
+	current, err := s.policy.loadProject(s.repo, project.ID, actor, domain.PermManageWorkspace)
+	if err != nil {
+		return err
+	}
+	if project.WorkspaceID != current.WorkspaceID {
+		if err := s.policy.CheckWorkspace(actor, project.WorkspaceID, domain.PermManageWorkspace); err != nil {
+			return err
+		}
+		hasTasks, err := s.repo.HasTasks(project.ID)
+		if err != nil {
+			return err
+		}
+		if hasTasks {
+			return domain.ErrProjectScope
+		}
+	}
+	project.Name = strings.TrimSpace(project.Name)
+	if err := project.Validate(); err != nil {
+		return err
+	}
+	project.OwnerID = current.OwnerID
+	project.CreatedAt = current.CreatedAt
+	return s.repo.Update(project)
+}
+
+func (s *ProjectService) DeleteProject(id int, actor domain.Actor) error {
+	if _, err := s.policy.loadProject(s.repo, id, actor, domain.PermManageWorkspace); err != nil {
+		return err
+	}
+	return s.repo.Delete(id, actor)
+}
+
+// GetProjectTasks lists the tasks of a project the actor may see, using the regular task
+// filters and pagination.
+func (s *ProjectService) GetProjectTasks(projectID int, filter domain.TaskFilter, actor domain.Actor) (*domain.TaskConnection, error) {
+	if _, err := s.policy.loadProject(s.repo, projectID, actor, domain.PermViewTasks); err != nil {
+		return nil, err
+	}
+	access, err := s.policy.TaskAccess(actor)
+	if err != nil {
+		return nil, err
+	}
+	filter.ProjectID = projectID
+	filter.Access = access
+	return s.taskRepo.FindAll(filter)
+}
+
+// MoveTasks moves tasks into a project atomically. A projectID of 0 removes them from their project.
+// The actor needs to be able to edit the tasks and to create tasks in the project, which must be
+// in the tasks' workspace.
+func (s *ProjectService) MoveTasks(taskIDs []int, projectID int, actor domain.Actor) ([]domain.Task, error) {
+	var project *domain.Project
+	if projectID != 0 {
+		var err error
+		if project, err = s.policy.loadProject(s.repo, projectID, actor, domain.PermCreateTasks); err != nil {
+			return nil, err
+		}
+		if project.Archived {
+			return nil, domain.ErrProjectArchived
+		}
+	}
+	taskIDs = uniqueIDs(taskIDs)
+	for _, id := range taskIDs {
+		task, err := s.policy.loadTask(s.taskRepo, id, actor, domain.PermEditTasks)
+		if err != nil {
+			return nil, err
+		}
+		if project != nil && task.WorkspaceID != project.WorkspaceID {
+			return nil, domain.ErrProjectScope
+		}
+	}
+	if err := s.repo.MoveTasks(taskIDs, projectID, actor); err != nil {
+		return nil, err
+	}
+	tasks := make([]domain.Task, 0, len(taskIDs))
+	for _, id := range taskIDs {
+		task, err := s.taskRepo.FindByID(id)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, *task)
+	}
+	return tasks, nil
+}
+
+func uniqueIDs(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	unique := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,129 @@
+package application
+
+import (
+	"sync"
+	"task-manager-app/backend/internal/domain"
+	"time"
+)
+
+// RevocationService keeps the list of revoked access tokens. The list lives in the database
+// and is cached in memory, since it is checked on every authenticated request. The cache is
+// reloaded once it is older than ttl, so revocations made by other instances apply too.
+type RevocationService struct {
+	repo domain.RevocationRepository
+	ttl  time.Duration
+
+	mu       sync.RWMutex
+	tokens   map[string]time.Time // Expiry by JTI
+	sessions map[string]time.Time // Tokens of the session issued up to this time are revoked
+	users    map[int]time.Time    // Tokens of the user issued up to this time are revoked
+	loadedAt time.Time
+}
+
+func NewRevocationService(repo domain.RevocationRepository, ttl time.Duration) *RevocationService {
+	return &RevocationService{repo: repo, ttl: ttl}
+}
+
+// IsRevoked reports whether the token with the given ID, issued to the user for the session
+// at issuedAt, has been revoked. Token times have a resolution of one second, so tokens
+// issued in the same second as a "log out everywhere" count as revoked too.
+func (s *RevocationService) IsRevoked(jti, sessionID string, userID int, issuedAt time.Time) (bool, error) {
+	now := time.Now()
+	if err := s.ensureLoaded(now); err != nil {
+		return false, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if expiresAt, ok := s.tokens[jti]; ok && jti != "" && !now.After(expiresAt) {
+		return true, nil
+	}
+	if revokedAt, ok := s.sessions[sessionID]; ok && sessionID != "" && !issuedAt.After(revokedAt) {
+		return true, nil
+	}
+	if revokedAt, ok := s.users[userID]; ok && !issuedAt.After(revokedAt) {
+		return true, nil
+	}
+	return false, nil
+}
+
+// RevokeToken revokes one access token until it expires.
+func (s *RevocationService) RevokeToken(jti string, userID int, expiresAt time.Time) error {
+	return s.add(&domain.RevokedToken{JTI: jti, UserID: userID, RevokedAt: time.Now(), ExpiresAt: expiresAt})
+}
+
+// RevokeSession revokes every access token issued for the session so far. Tokens live at
+// most maxAge, so the entry is not needed after that.
+func (s *RevocationService) RevokeSession(sessionID string, userID int, maxAge time.Duration) error {
+	now := time.Now()
+	return s.add(&domain.RevokedToken{SessionID: sessionID, UserID: userID, RevokedAt: now, ExpiresAt: now.Add(maxAge)})
+}
+
+// RevokeUser revokes every access token issued to the user so far. Tokens live at most
+// maxAge, so the entry is not needed after that.
+func (s *RevocationService) RevokeUser(userID int, maxAge time.Duration) error {
+	now := time.Now()
+	return s.add(&domain.RevokedToken{UserID: userID, RevokedAt: now, ExpiresAt: now.Add(maxAge)})
+}
+
+// Prune deletes the entries whose tokens have expired by now and returns how many there were.
+func (s *RevocationService) Prune(now time.Time) (int, error) {
+	n, err := s.repo.DeleteExpiredBefore(now)
+	if err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	s.loadedAt = time.Time{} // Reload on the next check
+	s.mu.Unlock()
+	return n, nil
+}
+
+func (s *RevocationService) add(entry *domain.RevokedToken) error {
+	if err := s.repo.Create(entry); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tokens != nil {
+		s.cache(*entry)
+	}
+	return nil
+}
+
+func (s *RevocationService) ensureLoaded(now time.Time) error {
+	s.mu.RLock()
+	fresh := !s.loadedAt.IsZero() && now.Sub(s.loadedAt) < s.ttl
+	s.mu.RUnlock()
+	if fresh {
+		return nil
+	}
+	entries, err := s.repo.FindActive(now)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = map[string]time.Time{}
+	s.sessions = map[string]time.Time{}
+	s.users = map[int]time.Time{}
+	for _, entry := range entries {
+		s.cache(entry)
+	}
+	s.loadedAt = now
+	return nil
+}
+
+func (s *RevocationService) cache(entry domain.RevokedToken) {
+	if entry.JTI != "" {
+		s.tokens[entry.JTI] = entry.ExpiresAt
+		return
+	}
+	if entry.SessionID != "" {
+		if entry.RevokedAt.After(s.sessions[entry.SessionID]) {
+			s.sessions[entry.SessionID] = entry.RevokedAt
+		}
+		return
+	}
+	if entry.RevokedAt.After(s.users[entry.UserID]) {
+		s.users[entry.UserID] = entry.RevokedAt
+	}
+}
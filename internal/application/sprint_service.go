@@ -0,0 +1,121 @@
+package application
+
+import (
+	"task-manager-app/backend/internal/domain"
+	"time"
+)
+
+// SprintService manages the sprints of projects and the tasks planned for them.
+// Membership changes go through TaskService so they end up in the activity log.
+type SprintService struct {
+	repo     domain.SprintRepository
+	projects domain.ProjectRepository
+	tasks    *TaskService
+	policy   *AccessPolicy
+}
+
+func NewSprintService(repo domain.SprintRepository, projects domain.ProjectRepository, tasks *TaskService, policy *AccessPolicy) *SprintService {
+	return &SprintService{repo: repo, projects: projects, tasks: tasks, policy: policy}
+}
+
+// loadSprint finds a sprint and checks the actor has the permission on its project.
+func (s *SprintService) loadSprint(id int, actor domain.Actor, perm domain.Permission) (*domain.Sprint, error) {
+	sprint, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.policy.loadProject(s.projects, sprint.ProjectID, actor, perm); err != nil {
+		return nil, err
+	}
+	return sprint, nil
+}
+
+// CreateSprint plans a sprint in a project; planning takes editing rights on its tasks.
+func (s *SprintService) CreateSprint(sprint *domain.Sprint, actor domain.Actor) error {
+	project, err := s.policy.loadProject(s.projects, sprint.ProjectID, actor, domain.PermEditTasks)
+	if err != nil {
+		return err
+	}
+	if project.Archived {
+		return domain.ErrProjectArchived
+	}
+	if err := sprint.Validate(); err != nil {
+		return err
+	}
+	return s.repo.Create(sprint)
+}
+
+func (s *SprintService) GetSprint(id int, actor domain.Actor) (*domain.Sprint, error) {
+	return s.loadSprint(id, actor, domain.PermViewTasks)
+}
+
+func (s *SprintService) GetSprints(projectID int, actor domain.Actor) ([]domain.Sprint, error) {
+	if _, err := s.policy.loadProject(s.projects, projectID, actor, domain.PermViewTasks); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByProjectID(projectID)
+}
+
+// UpdateSprint changes name, goal, dates and timezone; a sprint stays in its project.
+func (s *SprintService) UpdateSprint(sprint *domain.Sprint, actor domain.Actor) error {
+	current, err := s.loadSprint(sprint.ID, actor, domain.PermEditTasks)
+	if err != nil {
+		return err
+	}
+	if err := sprint.Validate(); err != nil {
+		return err
+	}
+	sprint.ProjectID = current.ProjectID
+	sprint.CreatedAt = current.CreatedAt
+	return s.repo.Update(sprint)
+}
+
+// DeleteSprint removes the sprint; its tasks stay in the project without a sprint.
+func (s *SprintService) DeleteSprint(id int, actor domain.Actor) error {
+	if _, err := s.loadSprint(id, actor, domain.PermEditTasks); err != nil {
+		return err
+	}
+	return s.repo.Delete(id, actor)
+}
+
+// AddTasks plans tasks of the sprint's project into the sprint, moving them out of any
+// other sprint they were in.
+func (s *SprintService) AddTasks(sprintID int, taskIDs []int, actor domain.Actor) ([]domain.Task, error) {
+	sprint, err := s.loadSprint(sprintID, actor, domain.PermEditTasks)
+	if err != nil {
+		return nil, err
+	}
+	return s.tasks.SetSprint(taskIDs, sprint, actor)
+}
+
+func (s *SprintService) RemoveTask(sprintID, taskID int, actor domain.Actor) (*domain.Task, error) {
+	if _, err := s.loadSprint(sprintID, actor, domain.PermEditTasks); err != nil {
+		return nil, err
+	}
+	task, err := s.tasks.GetTaskByID(taskID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if task.SprintID != sprintID {
+		return nil, domain.ErrNotInSprint
+	}
+	tasks, err := s.tasks.SetSprint([]int{taskID}, nil, actor)
+	if err != nil {
+		return nil, err
+	}
+	return &tasks[0], nil
+}
+
+// GetBurndown computes the daily burndown and burnup series of a sprint from the history
+// of its tasks, up to now.
+func (s *SprintService) GetBurndown(sprintID int, actor domain.Actor) (*domain.Burndown, error) {
+	sprint, err := s.loadSprint(sprintID, actor, domain.PermViewTasks)
+	if err != nil {
+		return nil, err
+	}
+	tasks, activities, err := s.repo.FindHistory(sprintID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.SprintBurndown(sprint, tasks, activities, time.Now()), nil
+}
@@ -1,37 +1,739 @@
 package application
 
 import (
+	"errors"
 	"task-manager-app/backend/internal/domain"
+	"time"
 )
 
 type TaskService struct {
-	repo domain.TaskRepository
+	repo       domain.TaskRepository
+	workspaces domain.WorkspaceRepository
+	activities domain.ActivityRepository
+	policy     *AccessPolicy
 }
 
-func NewTaskService(repo domain.TaskRepository) *TaskService {
-	return &TaskService{repo: repo}
+func NewTaskService(repo domain.TaskRepository, workspaces domain.WorkspaceRepository, activities domain.ActivityRepository, policy *AccessPolicy) *TaskService {
+	return &TaskService{repo: repo, workspaces: workspaces, activities: activities, policy: policy}
 }
 
-func (s *TaskService) CreateTask(task *domain.Task) error {
+// Authorize loads a task and checks the actor has the permission on it. Features attached
+// to tasks, such as comments or time entries, use it before acting on the task.
+func (s *TaskService) Authorize(id int, actor domain.Actor, perm domain.Permission) (*domain.Task, error) {
+	task, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.policy.CheckTask(actor, task, perm); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// CreateTask creates a task on behalf of the actor, who becomes its creator. Creating it in
+// a workspace or under a parent task needs permission to create tasks there.
+func (s *TaskService) CreateTask(task *domain.Task, actor domain.Actor) error {
+	if actor.UserID != 0 {
+		task.UserID = actor.UserID
+	}
+	if err := task.ValidateSchedule(); err != nil {
+		return err
+	}
+	if err := task.ValidateRecurrence(); err != nil {
+		return err
+	}
+	if task.Status == "" {
+		task.Status = domain.StatusFromCompletion(task.IsCompleted)
+	}
+	if !task.Status.IsValid() {
+		return domain.ErrUnknownStatus
+	}
+	if !task.Priority.IsValid() {
+		return domain.ErrUnknownPriority
+	}
+	task.IsCompleted = task.Status == domain.StatusDone
+	task.ColumnID, task.Rank = 0, "" // Tasks are placed on the board by moving them
+	task.SprintID = 0                // and planned into sprints through SprintService
+	if task.ParentID != 0 {
+		if err := s.checkParent(task, actor); err != nil {
+			return err
+		}
+	} else if err := s.policy.CheckCreate(actor, task.WorkspaceID); err != nil {
+		return err
+	}
+	task.NormalizeSchedule()
 	return s.repo.Create(task)
 }
 
-func (s *TaskService) GetTaskByID(id int) (*domain.Task, error) {
-	return s.repo.FindByID(id)
+// InstantiateTemplate creates the task described by a template together with its subtasks,
+// labels and checklists, filling in placeholders. Either the whole tree is created or nothing.
+func (s *TaskService) InstantiateTemplate(template *domain.TaskTemplate, params domain.TemplateParams, actor domain.Actor) (*domain.Task, error) {
+	if params.Now.IsZero() {
+		params.Now = time.Now()
+	}
+	params.UserID = actor.UserID
+	tree, err := template.Build(params)
+	if err != nil {
+		return nil, err
+	}
+	if params.ParentID != 0 {
+		if err := s.checkParent(tree.Task, actor); err != nil {
+			return nil, err
+		}
+	} else if err := s.policy.CheckCreate(actor, template.WorkspaceID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateTree(tree); err != nil {
+		return nil, err
+	}
+	var recordErr error
+	tree.Walk(func(task *domain.Task) {
+		if recordErr == nil {
+			recordErr = s.record(task.ID, actor, domain.ActivityCreated, domain.DiffTasks(&domain.Task{}, task))
+		}
+	})
+	if recordErr != nil {
+		return nil, recordErr
+	}
+	return s.repo.FindByID(tree.Task.ID)
+}
+
+func (s *TaskService) GetTaskByID(id int, actor domain.Actor) (*domain.Task, error) {
+	return s.Authorize(id, actor, domain.PermViewTasks)
 }
 
-func (s *TaskService) GetAllTasks(filter domain.TaskFilter) (*domain.TaskConnection, error) {
+// GetAllTasks lists the tasks matching the filter among those the actor may see.
+func (s *TaskService) GetAllTasks(filter domain.TaskFilter, actor domain.Actor) (*domain.TaskConnection, error) {
+	access, err := s.policy.TaskAccess(actor)
+	if err != nil {
+		return nil, err
+	}
+	filter.Access = access
 	return s.repo.FindAll(filter)
 }
 
-func (s *TaskService) UpdateTask(task *domain.Task) error {
-	return s.repo.Update(task)
+// UpdateTask saves the task, enforcing the workspace workflow when its status changes.
+// A change to IsCompleted alone is treated as a move to done or back to todo.
+func (s *TaskService) UpdateTask(task *domain.Task, actor domain.Actor) error {
+	current, err := s.Authorize(task.ID, actor, domain.PermEditTasks)
+	if err != nil {
+		return err
+	}
+	if task.WorkspaceID != current.WorkspaceID {
+		if err := s.checkWorkspaceMove(current, task.WorkspaceID, actor); err != nil {
+			return err
+		}
+	}
+	writes, err := s.update(task, actor, nil)
+	if err != nil {
+		return err
+	}
+	return s.repo.Apply(writes)
+}
+
+// checkWorkspaceMove checks a task may move to another workspace: the actor needs the right to
+// create tasks there, and the task may neither be in a project nor have subtasks, which stay
+// in the workspace of their project or parent.
+func (s *TaskService) checkWorkspaceMove(current *domain.Task, workspaceID int, actor domain.Actor) error {
+	if err := s.policy.CheckCreate(actor, workspaceID); err != nil {
+		return err
+	}
+	if current.ProjectID != 0 {
+		return domain.ErrProjectScope
+	}
+	children, err := s.repo.FindChildren(current.ID)
+	if err != nil {
+		return err
+	}
+	if len(children) > 0 {
+		return domain.ErrParentScope
+	}
+	return nil
+}
+
+// update prepares the writes saving a task: the task itself and, when completing it opens
+// the next occurrence of a recurring task, that occurrence. Blockers listed in completing
+// are being closed in the same operation and do not prevent the task from being completed.
+func (s *TaskService) update(task *domain.Task, actor domain.Actor, completing map[int]bool) ([]domain.TaskWrite, error) {
+	if err := task.ValidateSchedule(); err != nil {
+		return nil, err
+	}
+	if !task.Priority.IsValid() {
+		return nil, domain.ErrUnknownPriority
+	}
+	current, err := s.repo.FindByID(task.ID)
+	if err != nil {
+		return nil, err
+	}
+	task.ProjectID = current.ProjectID // Tasks change project through ProjectService.MoveTasks only
+	if task.ParentID != 0 && (task.ParentID != current.ParentID || task.WorkspaceID != current.WorkspaceID) {
+		if err := s.checkParent(task, actor); err != nil {
+			return nil, err
+		}
+	}
+	task.SeriesID = current.SeriesID
+	task.Occurrence = current.Occurrence
+	if err := task.ValidateRecurrence(); err != nil {
+		return nil, err
+	}
+	if err := s.applyStatus(current, task, actor.UserID, completing); err != nil {
+		return nil, err
+	}
+	task.UserID = current.UserID // The creator never changes; it decides who may act on personal tasks
+	task.CreatedAt = current.CreatedAt
+	task.ArchivedAt = current.ArchivedAt
+	task.ColumnID, task.Rank = current.ColumnID, current.Rank
+	task.SprintID = current.SprintID
+	task.NormalizeSchedule()
+
+	// Completing a recurring task opens the next occurrence, which carries the rule from now on
+	var next *domain.Task
+	if task.Recurrence != "" && task.Status == domain.StatusDone && current.CurrentStatus() != domain.StatusDone {
+		next, err = task.NextOccurrence()
+		if err != nil && !errors.Is(err, domain.ErrSeriesEnded) {
+			return nil, err
+		}
+		task.Recurrence = ""
+	}
+	writes := []domain.TaskWrite{{Task: task, Activity: activity(actor, domain.ActivityUpdated, domain.DiffTasks(current, task))}}
+	if next != nil {
+		change := domain.DiffTasks(&domain.Task{}, next)
+		next.Assignees, next.Watchers = current.Assignees, current.Watchers
+		writes = append(writes, domain.TaskWrite{Task: next, Create: true, Activity: activity(domain.SystemActor(), domain.ActivityCreated, change)})
+	}
+	return writes, nil
+}
+
+// ChangeStatus moves a task to a new status. When cascade is set and the task is being
+// completed, its open subtasks are completed as well, all or nothing.
+func (s *TaskService) ChangeStatus(id int, status domain.TaskStatus, actor domain.Actor, cascade bool) (*domain.Task, error) {
+	task, err := s.Authorize(id, actor, domain.PermEditTasks)
+	if err != nil {
+		return nil, err
+	}
+
+	var descendants []domain.Task
+	completing := map[int]bool{id: true}
+	if cascade && status == domain.StatusDone {
+		if descendants, err = s.repo.FindDescendants(id); err != nil {
+			return nil, err
+		}
+		for _, d := range descendants {
+			completing[d.ID] = true
+		}
+		// Check every transition before changing anything
+		for i := range descendants {
+			if err := s.checkTransition(&descendants[i], domain.StatusDone); err != nil {
+				return nil, err
+			}
+			if err := s.checkBlockers(descendants[i].ID, completing); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	task.Status = status
+	writes, err := s.update(task, actor, completing)
+	if err != nil {
+		return nil, err
+	}
+	for i := range descendants {
+		d := &descendants[i]
+		if d.CurrentStatus() == domain.StatusDone || d.CurrentStatus() == domain.StatusCancelled {
+			continue
+		}
+		d.Status = domain.StatusDone
+		more, err := s.update(d, actor, completing)
+		if err != nil {
+			return nil, err
+		}
+		writes = append(writes, more...)
+	}
+	if err := s.repo.Apply(writes); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// PreviewOccurrences returns the next n due dates of a recurring task.
+func (s *TaskService) PreviewOccurrences(id, n int, actor domain.Actor) ([]time.Time, error) {
+	task, err := s.Authorize(id, actor, domain.PermViewTasks)
+	if err != nil {
+		return nil, err
+	}
+	return task.UpcomingOccurrences(n)
+}
+
+// SkipOccurrence moves a recurring task to its next occurrence without completing it.
+func (s *TaskService) SkipOccurrence(id int, actor domain.Actor) (*domain.Task, error) {
+	task, err := s.Authorize(id, actor, domain.PermEditTasks)
+	if err != nil {
+		return nil, err
+	}
+	if task.Recurrence == "" {
+		return nil, domain.ErrNotRecurring
+	}
+	next, err := task.NextOccurrence()
+	if err != nil {
+		return nil, err
+	}
+	before := *task
+	task.StartAt, task.DueAt, task.Occurrence = next.StartAt, next.DueAt, next.Occurrence
+	return s.save(&before, task, actor)
+}
+
+// EndSeries stops a recurring task from repeating; the current occurrence stays as a regular task.
+func (s *TaskService) EndSeries(id int, actor domain.Actor) (*domain.Task, error) {
+	task, err := s.Authorize(id, actor, domain.PermEditTasks)
+	if err != nil {
+		return nil, err
+	}
+	if task.Recurrence == "" {
+		return nil, domain.ErrNotRecurring
+	}
+	before := *task
+	task.Recurrence = ""
+	return s.save(&before, task, actor)
+}
+
+// ArchiveTask hides a task from the default listings without deleting it.
+func (s *TaskService) ArchiveTask(id int, actor domain.Actor) (*domain.Task, error) {
+	task, err := s.Authorize(id, actor, domain.PermEditTasks)
+	if err != nil {
+		return nil, err
+	}
+	if task.ArchivedAt != nil {
+		return nil, domain.ErrAlreadyArchived
+	}
+	before := *task
+	now := time.Now().UTC()
+	task.ArchivedAt = &now
+	return s.save(&before, task, actor)
+}
+
+func (s *TaskService) UnarchiveTask(id int, actor domain.Actor) (*domain.Task, error) {
+	task, err := s.Authorize(id, actor, domain.PermEditTasks)
+	if err != nil {
+		return nil, err
+	}
+	if task.ArchivedAt == nil {
+		return nil, domain.ErrNotArchived
+	}
+	before := *task
+	task.ArchivedAt = nil
+	return s.save(&before, task, actor)
+}
+
+// save writes a task changed from before together with the activity entry logging the change.
+func (s *TaskService) save(before, task *domain.Task, actor domain.Actor) (*domain.Task, error) {
+	write := domain.TaskWrite{Task: task, Activity: activity(actor, domain.ActivityUpdated, domain.DiffTasks(before, task))}
+	if err := s.repo.Apply([]domain.TaskWrite{write}); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// AutoArchive archives the tasks matching the rule and logs it as a system change.
+// It returns how many tasks were archived.
+func (s *TaskService) AutoArchive(rule domain.ArchiveRule, now time.Time) (int, error) {
+	if !rule.Enabled() {
+		return 0, nil
+	}
+	ids, err := s.repo.ArchiveStale(rule, now)
+	if err != nil {
+		return 0, err
+	}
+	archivedAt := now.UTC()
+	for _, id := range ids {
+		change := domain.DiffTasks(&domain.Task{}, &domain.Task{ArchivedAt: &archivedAt})
+		if err := s.record(id, domain.SystemActor(), domain.ActivityUpdated, change); err != nil {
+			return 0, err
+		}
+	}
+	return len(ids), nil
+}
+
+// MoveTask places a task in a board column between two neighbours: before is the task that
+// ends up right above it and after the one right below, either may be 0. Moving to a status
+// column changes the task's status under the workflow rules. Only the moved task gets a new
+// rank unless the column has tasks that were never ranked, which are ranked first.
+func (s *TaskService) MoveTask(id int, target domain.BoardTarget, beforeID, afterID int, actor domain.Actor) (*domain.Task, error) {
+	task, err := s.Authorize(id, actor, domain.PermEditTasks)
+	if err != nil {
+		return nil, err
+	}
+	if task.ProjectID == 0 {
+		return nil, domain.ErrNoBoard
+	}
+	if beforeID == id || afterID == id {
+		return nil, domain.ErrInvalidMove
+	}
+	column, err := s.columnRanks(task.ProjectID, target, id)
+	if err != nil {
+		return nil, err
+	}
+	lower, upper, err := neighbourRanks(column, beforeID, afterID)
+	if err != nil {
+		return nil, err
+	}
+	rank, err := domain.RankBetween(lower, upper)
+	if err != nil {
+		return nil, err
+	}
+
+	if target.Status != "" && target.Status != task.CurrentStatus() {
+		if task, err = s.ChangeStatus(id, target.Status, actor, false); err != nil {
+			return nil, err
+		}
+	}
+	before := *task
+	task.ColumnID, task.Rank = target.ColumnID, rank
+	if err := s.repo.UpdateRank(id, task.ColumnID, task.Rank); err != nil {
+		return nil, err
+	}
+	if err := s.record(id, actor, domain.ActivityUpdated, domain.DiffTasks(&before, task)); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByID(id)
+}
+
+// SetSprint puts tasks into a sprint, or takes them out of theirs when sprint is nil, all or
+// nothing. Tasks must belong to the sprint's project. Each change is logged, which is what
+// sprint burndowns are computed from.
+func (s *TaskService) SetSprint(ids []int, sprint *domain.Sprint, actor domain.Actor) ([]domain.Task, error) {
+	sprintID := 0
+	if sprint != nil {
+		sprintID = sprint.ID
+	}
+	ids = uniqueIDs(ids)
+	before := make([]*domain.Task, len(ids))
+	for i, id := range ids {
+		task, err := s.Authorize(id, actor, domain.PermEditTasks)
+		if err != nil {
+			return nil, err
+		}
+		if sprint != nil && task.ProjectID != sprint.ProjectID {
+			return nil, domain.ErrSprintProject
+		}
+		before[i] = task
+	}
+	if err := s.repo.UpdateSprint(ids, sprintID); err != nil {
+		return nil, err
+	}
+	tasks := make([]domain.Task, len(ids))
+	for i, task := range before {
+		tasks[i] = *task
+		tasks[i].SprintID = sprintID
+		if err := s.record(task.ID, actor, domain.ActivityUpdated, domain.DiffTasks(task, &tasks[i])); err != nil {
+			return nil, err
+		}
+	}
+	return tasks, nil
+}
+
+// columnRanks lists the tasks of a board column in order, leaving out the task being moved.
+// Columns holding unranked or equally ranked tasks are renumbered first.
+func (s *TaskService) columnRanks(projectID int, target domain.BoardTarget, moving int) ([]domain.TaskRank, error) {
+	tasks, err := s.repo.FindAll(target.Filter(projectID))
+	if err != nil {
+		return nil, err
+	}
+	var column []domain.TaskRank
+	ordered := true
+	for _, edge := range tasks.Edges {
+		if edge.Node.ID == moving {
+			continue
+		}
+		if edge.Node.Rank == "" || (len(column) > 0 && column[len(column)-1].Rank >= edge.Node.Rank) {
+			ordered = false
+		}
+		column = append(column, domain.TaskRank{ID: edge.Node.ID, Rank: edge.Node.Rank})
+	}
+	if ordered {
+		return column, nil
+	}
+	for i, rank := range domain.SpreadRanks(len(column)) {
+		column[i].Rank = rank
+	}
+	return column, s.repo.Rerank(column)
+}
+
+// neighbourRanks finds the ranks the moved task goes between. Without neighbours it goes to
+// the end of the column; with both they must be adjacent.
+func neighbourRanks(column []domain.TaskRank, beforeID, afterID int) (string, string, error) {
+	index := func(id int) int {
+		for i, task := range column {
+			if task.ID == id {
+				return i
+			}
+		}
+		return -1
+	}
+	switch {
+	case beforeID != 0:
+		i := index(beforeID)
+		if i < 0 {
+			return "", "", domain.ErrInvalidMove
+		}
+		upper := ""
+		if i+1 < len(column) {
+			upper = column[i+1].Rank
+		}
+		if afterID != 0 && (i+1 >= len(column) || column[i+1].ID != afterID) {
+			return "", "", domain.ErrInvalidMove
+		}
+		return column[i].Rank, upper, nil
+	case afterID != 0:
+		i := index(afterID)
+		if i < 0 {
+			return "", "", domain.ErrInvalidMove
+		}
+		lower := ""
+		if i > 0 {
+			lower = column[i-1].Rank
+		}
+		return lower, column[i].Rank, nil
+	case len(column) > 0:
+		return column[len(column)-1].Rank, "", nil
+	default:
+		return "", "", nil
+	}
+}
+
+// DeleteTask removes a task; the policy decides whether its subtasks are deleted or moved up a level.
+func (s *TaskService) DeleteTask(id int, policy domain.DeletePolicy, actor domain.Actor) error {
+	if _, err := s.Authorize(id, actor, domain.PermDeleteTasks); err != nil {
+		return err
+	}
+	if policy == "" {
+		policy = domain.DeleteReparent
+	}
+	return s.repo.Delete(id, policy)
+}
+
+func (s *TaskService) GetSubtasks(id int, actor domain.Actor) ([]domain.Task, error) {
+	if _, err := s.Authorize(id, actor, domain.PermViewTasks); err != nil {
+		return nil, err
+	}
+	return s.repo.FindChildren(id)
+}
+
+// GetProgress returns the completion percentage of a task rolled up from its subtasks.
+func (s *TaskService) GetProgress(id int, actor domain.Actor) (float64, error) {
+	task, err := s.Authorize(id, actor, domain.PermViewTasks)
+	if err != nil {
+		return 0, err
+	}
+	descendants, err := s.repo.FindDescendants(id)
+	if err != nil {
+		return 0, err
+	}
+	return domain.Progress(*task, descendants), nil
+}
+
+// AssignTask makes the user one of the people responsible for the task.
+func (s *TaskService) AssignTask(taskID, userID int, actor domain.Actor) (*domain.Task, error) {
+	return s.changeMembers(taskID, userID, actor, domain.PermEditTasks, true, s.repo.AddAssignee)
+}
+
+func (s *TaskService) UnassignTask(taskID, userID int, actor domain.Actor) (*domain.Task, error) {
+	return s.changeMembers(taskID, userID, actor, domain.PermEditTasks, false, s.repo.RemoveAssignee)
+}
+
+// WatchTask lets a user follow a task they neither created nor are assigned to. Anyone who
+// can see a task may watch it; adding someone else needs permission to edit it.
+func (s *TaskService) WatchTask(taskID, userID int, actor domain.Actor) (*domain.Task, error) {
+	return s.changeMembers(taskID, userID, actor, watchPermission(userID, actor), true, s.repo.AddWatcher)
+}
+
+func (s *TaskService) UnwatchTask(taskID, userID int, actor domain.Actor) (*domain.Task, error) {
+	return s.changeMembers(taskID, userID, actor, watchPermission(userID, actor), false, s.repo.RemoveWatcher)
+}
+
+func watchPermission(userID int, actor domain.Actor) domain.Permission {
+	if userID == actor.UserID {
+		return domain.PermViewTasks
+	}
+	return domain.PermEditTasks
+}
+
+func (s *TaskService) changeMembers(taskID, userID int, actor domain.Actor, perm domain.Permission, adding bool, change func(taskID, userID int) error) (*domain.Task, error) {
+	before, err := s.Authorize(taskID, actor, perm)
+	if err != nil {
+		return nil, err
+	}
+	if adding {
+		if err := s.checkNewMember(before, userID, actor); err != nil {
+			return nil, err
+		}
+	}
+	if err := change(taskID, userID); err != nil {
+		return nil, err
+	}
+	task, err := s.repo.FindByID(taskID)
+	if err != nil {
+		return nil, err
+	}
+	changes := append(domain.DiffUsers("assignees", before.Assignees, task.Assignees), domain.DiffUsers("watchers", before.Watchers, task.Watchers)...)
+	if err := s.record(taskID, actor, domain.ActivityUpdated, changes); err != nil {
+		return nil, err
+	}
+	return task, nil
 }
 
-func (s *TaskService) DeleteTask(id int) error {
-	return s.repo.Delete(id)
+// checkNewMember checks a user may be added to a task. Workspace tasks only take members of
+// their workspace. Assignees and watchers gain rights on personal tasks, so only the creator
+// brings other people in there.
+func (s *TaskService) checkNewMember(task *domain.Task, userID int, actor domain.Actor) error {
+	if task.WorkspaceID == 0 {
+		if userID == actor.UserID {
+			return nil
+		}
+		return s.policy.CheckTask(actor, task, domain.PermManageMembers)
+	}
+	_, ok, err := s.policy.WorkspaceRole(userID, task.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.ErrNotMember
+	}
+	return nil
+}
+
+// GetHistory returns the change log of a task, newest entries first.
+func (s *TaskService) GetHistory(filter domain.ActivityFilter, actor domain.Actor) (*domain.ActivityConnection, error) {
+	if _, err := s.Authorize(filter.TaskID, actor, domain.PermViewTasks); err != nil {
+		return nil, err
+	}
+	return s.activities.FindByTask(filter)
+}
+
+// record appends an entry to the task's activity log; updates that changed nothing are not logged.
+func (s *TaskService) record(taskID int, actor domain.Actor, action domain.ActivityAction, changes []domain.FieldChange) error {
+	entry := activity(actor, action, changes)
+	if entry == nil {
+		return nil
+	}
+	entry.TaskID = taskID
+	return s.activities.Create(entry)
+}
+
+// activity builds an entry of a task's activity log, nil for an update that changed nothing.
+func activity(actor domain.Actor, action domain.ActivityAction, changes []domain.FieldChange) *domain.Activity {
+	if action == domain.ActivityUpdated && len(changes) == 0 {
+		return nil
+	}
+	return &domain.Activity{ActorID: actor.UserID, Source: actor.Source, Action: action, Changes: changes}
 }
 
 func (s *TaskService) GetTasksByUserID(userID int) ([]domain.Task, error) {
 	return s.repo.FindByUserID(userID)
 }
+
+func (s *TaskService) applyStatus(current, task *domain.Task, actorID int, completing map[int]bool) error {
+	from := current.CurrentStatus()
+	to := task.Status
+	switch {
+	case to != "" && to != from:
+	case task.IsCompleted != current.IsCompleted:
+		to = domain.StatusFromCompletion(task.IsCompleted)
+	default:
+		to = from
+	}
+
+	task.Status = to
+	task.IsCompleted = to == domain.StatusDone
+	if to == from {
+		task.StatusChangedBy = current.StatusChangedBy
+		task.StatusChangedAt = current.StatusChangedAt
+		return nil
+	}
+
+	workflow, err := s.workflowFor(current.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	if err := workflow.Validate(from, to); err != nil {
+		return err
+	}
+	if to == domain.StatusDone {
+		if err := s.checkBlockers(current.ID, completing); err != nil {
+			return err
+		}
+	}
+	now := time.Now()
+	task.StatusChangedBy = actorID
+	task.StatusChangedAt = &now
+	return nil
+}
+
+// checkParent rejects a parent the actor may not add subtasks to, one in another workspace
+// or project, which would leak its progress rollup there, or one that would make the tree cyclic.
+func (s *TaskService) checkParent(task *domain.Task, actor domain.Actor) error {
+	parent, err := s.Authorize(task.ParentID, actor, domain.PermCreateTasks)
+	if err != nil {
+		return err
+	}
+	if parent.WorkspaceID != task.WorkspaceID || parent.ProjectID != task.ProjectID {
+		return domain.ErrParentScope
+	}
+	seen := map[int]bool{task.ID: true}
+	for ancestor := task.ParentID; ancestor != 0; {
+		if seen[ancestor] {
+			return domain.ErrTaskCycle
+		}
+		seen[ancestor] = true
+		parent, err := s.repo.FindByID(ancestor)
+		if err != nil {
+			return err
+		}
+		ancestor = parent.ParentID
+	}
+	return nil
+}
+
+// checkBlockers rejects completing a task while any task blocking it is still open.
+func (s *TaskService) checkBlockers(taskID int, completing map[int]bool) error {
+	blockers, err := s.repo.FindBlockers(taskID)
+	if err != nil {
+		return err
+	}
+	var open []int
+	for _, blocker := range blockers {
+		if blocker.IsOpen() && !completing[blocker.ID] {
+			open = append(open, blocker.ID)
+		}
+	}
+	if len(open) > 0 {
+		return &domain.BlockedError{BlockerIDs: open}
+	}
+	return nil
+}
+
+// checkTransition reports whether a task may move to the given status, without changing it.
+func (s *TaskService) checkTransition(task *domain.Task, to domain.TaskStatus) error {
+	from := task.CurrentStatus()
+	if from == to || from == domain.StatusCancelled {
+		return nil
+	}
+	workflow, err := s.workflowFor(task.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	return workflow.Validate(from, to)
+}
+
+func (s *TaskService) workflowFor(workspaceID int) (*domain.Workflow, error) {
+	if workspaceID == 0 {
+		return domain.DefaultWorkflow(), nil
+	}
+	transitions, err := s.workspaces.FindTransitions(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if len(transitions) == 0 {
+		return domain.DefaultWorkflow(), nil
+	}
+	return domain.NewWorkflow(transitions)
+}
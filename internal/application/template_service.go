@@ -0,0 +1,87 @@
+package application
+
+import (
+	"task-manager-app/backend/internal/domain"
+)
+
+// TemplateService manages the task templates of a workspace. Instantiating a template is
+// done by TaskService, which owns task creation.
+type TemplateService struct {
+	repo   domain.TemplateRepository
+	labels domain.LabelRepository
+	policy *AccessPolicy
+}
+
+func NewTemplateService(repo domain.TemplateRepository, labels domain.LabelRepository, policy *AccessPolicy) *TemplateService {
+	return &TemplateService{repo: repo, labels: labels, policy: policy}
+}
+
+// loadTemplate finds a template and checks the actor has the permission in its workspace.
+func (s *TemplateService) loadTemplate(id int, actor domain.Actor, perm domain.Permission) (*domain.TaskTemplate, error) {
+	template, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.policy.CheckWorkspace(actor, template.WorkspaceID, perm); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// CreateTemplate adds a template to a workspace; managing templates takes the right to
+// manage the workspace.
+func (s *TemplateService) CreateTemplate(template *domain.TaskTemplate, actor domain.Actor) error {
+	if err := s.policy.CheckWorkspace(actor, template.WorkspaceID, domain.PermManageWorkspace); err != nil {
+		return err
+	}
+	if err := s.validate(template); err != nil {
+		return err
+	}
+	return s.repo.Create(template)
+}
+
+func (s *TemplateService) GetTemplate(id int, actor domain.Actor) (*domain.TaskTemplate, error) {
+	return s.loadTemplate(id, actor, domain.PermViewTasks)
+}
+
+func (s *TemplateService) GetTemplates(workspaceID int, actor domain.Actor) ([]domain.TaskTemplate, error) {
+	if err := s.policy.CheckWorkspace(actor, workspaceID, domain.PermViewTasks); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByWorkspaceID(workspaceID)
+}
+
+// UpdateTemplate replaces the content of a template; it stays in its workspace.
+func (s *TemplateService) UpdateTemplate(template *domain.TaskTemplate, actor domain.Actor) error {
+	current, err := s.loadTemplate(template.ID, actor, domain.PermManageWorkspace)
+	if err != nil {
+		return err
+	}
+	template.WorkspaceID = current.WorkspaceID
+	template.CreatedAt = current.CreatedAt
+	if err := s.validate(template); err != nil {
+		return err
+	}
+	return s.repo.Update(template)
+}
+
+func (s *TemplateService) DeleteTemplate(id int, actor domain.Actor) error {
+	if _, err := s.loadTemplate(id, actor, domain.PermManageWorkspace); err != nil {
+		return err
+	}
+	return s.repo.Delete(id)
+}
+
+// validate checks the template and that the labels it uses belong to its workspace.
+func (s *TemplateService) validate(template *domain.TaskTemplate) error {
+	if err := template.Validate(); err != nil {
+		return err
+	}
+	for _, id := range template.LabelIDs() {
+		label, err := s.labels.FindByID(id)
+		if err != nil || label.WorkspaceID != template.WorkspaceID {
+			return domain.ErrTemplateLabel
+		}
+	}
+	return nil
+}
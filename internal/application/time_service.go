@@ -0,0 +1,194 @@
+package application
+
+import (
+	"errors"
+	"task-manager-app/backend/internal/domain"
+	"time"
+)
+
+// TimeService tracks the time users spend on tasks with timers and hand-logged entries.
+type TimeService struct {
+	repo     domain.TimeEntryRepository
+	taskRepo domain.TaskRepository
+	projects domain.ProjectRepository
+	policy   *AccessPolicy
+}
+
+func NewTimeService(repo domain.TimeEntryRepository, taskRepo domain.TaskRepository, projects domain.ProjectRepository, policy *AccessPolicy) *TimeService {
+	return &TimeService{repo: repo, taskRepo: taskRepo, projects: projects, policy: policy}
+}
+
+// StartTimer starts measuring the actor's time on a task. A user runs one timer at a time;
+// the repository enforces it, so concurrent starts fail with domain.ErrTimerRunning.
+func (s *TimeService) StartTimer(taskID int, note string, actor domain.Actor) (*domain.TimeEntry, error) {
+	// Tracking time takes editing rights on the task
+	if _, err := s.policy.loadTask(s.taskRepo, taskID, actor, domain.PermEditTasks); err != nil {
+		return nil, err
+	}
+	entry := &domain.TimeEntry{TaskID: taskID, UserID: actor.UserID, StartedAt: time.Now().UTC(), Note: note}
+	if err := s.repo.Create(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// StopTimer ends the user's running timer.
+func (s *TimeService) StopTimer(userID int) (*domain.TimeEntry, error) {
+	entry, err := s.repo.FindRunning(userID)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, domain.ErrNoRunningTimer
+	}
+	if err := entry.Stop(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// GetRunningTimer returns the user's running timer, or nil when there is none.
+func (s *TimeService) GetRunningTimer(userID int) (*domain.TimeEntry, error) {
+	return s.repo.FindRunning(userID)
+}
+
+// LogTime records time the actor spent on a task by hand, e.g. work done away from the computer.
+func (s *TimeService) LogTime(taskID int, startedAt, endedAt time.Time, note string, actor domain.Actor) (*domain.TimeEntry, error) {
+	// Tracking time takes editing rights on the task
+	if _, err := s.policy.loadTask(s.taskRepo, taskID, actor, domain.PermEditTasks); err != nil {
+		return nil, err
+	}
+	entry := &domain.TimeEntry{TaskID: taskID, UserID: actor.UserID, StartedAt: startedAt.UTC(), Note: note, Manual: true}
+	if !endedAt.After(startedAt) {
+		return nil, domain.ErrInvalidTimeEntry
+	}
+	if err := entry.Stop(endedAt); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// GetEntry returns one of the actor's entries or an entry on a task they can see.
+func (s *TimeService) GetEntry(id int, actor domain.Actor) (*domain.TimeEntry, error) {
+	entry, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if entry.UserID == actor.UserID {
+		return entry, nil
+	}
+	if _, err := s.policy.loadTask(s.taskRepo, entry.TaskID, actor, domain.PermViewTasks); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// UpdateEntry corrects an entry of the user. A running timer keeps running unless an end is given.
+func (s *TimeService) UpdateEntry(id, userID int, update domain.TimeEntryUpdate) (*domain.TimeEntry, error) {
+	entry, err := s.ownEntry(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if update.StartedAt != nil {
+		entry.StartedAt = update.StartedAt.UTC()
+	}
+	if update.Note != nil {
+		entry.Note = *update.Note
+	}
+	switch {
+	case update.EndedAt != nil:
+		if !update.EndedAt.After(entry.StartedAt) {
+			return nil, domain.ErrInvalidTimeEntry
+		}
+		err = entry.Stop(*update.EndedAt)
+	case !entry.IsRunning():
+		err = entry.Stop(*entry.EndedAt)
+	case entry.StartedAt.After(time.Now()):
+		err = domain.ErrInvalidTimeEntry
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (s *TimeService) DeleteEntry(id, userID int) error {
+	if _, err := s.ownEntry(id, userID); err != nil {
+		return err
+	}
+	return s.repo.Delete(id)
+}
+
+// GetEntries lists time entries, newest first, within the scope the actor may query.
+func (s *TimeService) GetEntries(filter domain.TimeEntryFilter, actor domain.Actor) (*domain.TimeEntryConnection, error) {
+	if err := s.scope(&filter, actor); err != nil {
+		return nil, err
+	}
+	return s.repo.FindAll(filter)
+}
+
+// GetReport sums the tracked time matching the filter per task, project and user, within
+// the scope the actor may query.
+func (s *TimeService) GetReport(filter domain.TimeEntryFilter, actor domain.Actor) (*domain.TimeReport, error) {
+	if err := s.scope(&filter, actor); err != nil {
+		return nil, err
+	}
+	return s.repo.Report(filter)
+}
+
+// scope limits a time entry query to a task or project the actor can see or, without
+// either, to the actor's own entries. Only superadmins query other users' time at large.
+func (s *TimeService) scope(filter *domain.TimeEntryFilter, actor domain.Actor) error {
+	var err error
+	switch {
+	case filter.TaskID != 0:
+		_, err = s.policy.loadTask(s.taskRepo, filter.TaskID, actor, domain.PermViewTasks)
+	case filter.ProjectID != 0:
+		_, err = s.policy.loadProject(s.projects, filter.ProjectID, actor, domain.PermViewTasks)
+	case filter.UserID == 0:
+		if errors.Is(s.policy.CheckSuperadmin(actor), domain.ErrForbidden) {
+			filter.UserID = actor.UserID
+		}
+	case filter.UserID != actor.UserID:
+		err = s.policy.CheckSuperadmin(actor)
+	}
+	return err
+}
+
+// GetTaskTime puts the estimates of a task next to the time tracked on it.
+func (s *TimeService) GetTaskTime(taskID int, actor domain.Actor) (*domain.TaskTime, error) {
+	task, err := s.policy.loadTask(s.taskRepo, taskID, actor, domain.PermViewTasks)
+	if err != nil {
+		return nil, err
+	}
+	report, err := s.repo.Report(domain.TimeEntryFilter{TaskID: taskID})
+	if err != nil {
+		return nil, err
+	}
+	return &domain.TaskTime{
+		TaskID:           taskID,
+		EstimateMinutes:  task.EstimateMinutes,
+		RemainingMinutes: task.RemainingMinutes,
+		TrackedSeconds:   report.Seconds,
+	}, nil
+}
+
+func (s *TimeService) ownEntry(id, userID int) (*domain.TimeEntry, error) {
+	entry, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if entry.UserID != userID {
+		return nil, domain.ErrTimeEntryForbidden
+	}
+	return entry, nil
+}
@@ -0,0 +1,177 @@
+package application
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// SigningKey is a key access tokens are signed with, identified in them by the kid header.
+// A key signs from ActiveFrom until the next key of its set becomes active, and is still
+// accepted for the grace period of the set after that.
+type SigningKey struct {
+	ID         string
+	Algorithm  string    // HS256, RS256 or EdDSA
+	ActiveFrom time.Time // Zero for a key active from the start
+	private    interface{}
+	public     interface{}
+}
+
+// NewHMACKey returns an HS256 key. Its secret is shared with whoever verifies the tokens,
+// so it is never published.
+func NewHMACKey(id string, secret []byte) SigningKey {
+	return SigningKey{ID: id, Algorithm: jwt.SigningMethodHS256.Alg(), private: secret, public: secret}
+}
+
+// ParseSigningKey reads a PEM encoded RSA or Ed25519 private key, in PKCS #8 or, for RSA,
+// PKCS #1 form. RSA keys sign with RS256 and Ed25519 keys with EdDSA.
+func ParseSigningKey(id string, data []byte, activeFrom time.Time) (SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return SigningKey{}, fmt.Errorf("key %s: no PEM data found", id)
+	}
+	var parsed interface{}
+	var err error
+	if block.Type == "RSA PRIVATE KEY" {
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	} else {
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("key %s: %w", id, err)
+	}
+	key := SigningKey{ID: id, ActiveFrom: activeFrom, private: parsed}
+	switch private := parsed.(type) {
+	case *rsa.PrivateKey:
+		if private.N.BitLen() < 2048 {
+			return SigningKey{}, fmt.Errorf("key %s: RSA keys must have at least 2048 bits", id)
+		}
+		key.Algorithm, key.public = jwt.SigningMethodRS256.Alg(), &private.PublicKey
+	case ed25519.PrivateKey:
+		key.Algorithm, key.public = jwt.SigningMethodEdDSA.Alg(), private.Public()
+	default:
+		return SigningKey{}, fmt.Errorf("key %s: only RSA and Ed25519 keys are supported", id)
+	}
+	return key, nil
+}
+
+func (k *SigningKey) method() jwt.SigningMethod {
+	return jwt.GetSigningMethod(k.Algorithm)
+}
+
+// KeySet holds the signing keys in the order they become active, which is how keys are
+// rotated: a new key is added with an ActiveFrom in the future, published ahead of time,
+// and takes over signing once that time comes.
+type KeySet struct {
+	keys  []SigningKey
+	grace time.Duration // How long a key is still accepted after its successor became active
+}
+
+func NewKeySet(keys []SigningKey, grace time.Duration) (*KeySet, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("at least one signing key is required")
+	}
+	seen := map[string]bool{}
+	for _, key := range keys {
+		if key.ID == "" || seen[key.ID] {
+			return nil, fmt.Errorf("signing key IDs must be unique and not empty: %q", key.ID)
+		}
+		seen[key.ID] = true
+	}
+	sorted := append([]SigningKey(nil), keys...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ActiveFrom.Before(sorted[j].ActiveFrom) })
+	return &KeySet{keys: sorted, grace: grace}, nil
+}
+
+// Signing returns the key to sign with at now: the one that became active last.
+func (s *KeySet) Signing(now time.Time) (*SigningKey, error) {
+	for i := len(s.keys) - 1; i >= 0; i-- {
+		if !s.keys[i].ActiveFrom.After(now) {
+			return &s.keys[i], nil
+		}
+	}
+	return nil, errors.New("no signing key is active yet")
+}
+
+// Verifying returns the key with the given ID if tokens signed with it are accepted at now.
+func (s *KeySet) Verifying(id string, now time.Time) (*SigningKey, bool) {
+	for i := range s.keys {
+		if s.keys[i].ID == id {
+			return &s.keys[i], s.accepted(i, now)
+		}
+	}
+	return nil, false
+}
+
+// Algorithms returns the algorithms of the keys in the set.
+func (s *KeySet) Algorithms() []string {
+	var algorithms []string
+	seen := map[string]bool{}
+	for _, key := range s.keys {
+		if !seen[key.Algorithm] {
+			seen[key.Algorithm] = true
+			algorithms = append(algorithms, key.Algorithm)
+		}
+	}
+	return algorithms
+}
+
+// accepted reports whether the key at index i has become active and its successor, if
+// any, has not been active for longer than the grace period.
+func (s *KeySet) accepted(i int, now time.Time) bool {
+	if s.keys[i].ActiveFrom.After(now) {
+		return false
+	}
+	return i == len(s.keys)-1 || now.Before(s.keys[i+1].ActiveFrom.Add(s.grace))
+}
+
+// JWK is a public key in JSON Web Key form (RFC 7517).
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`   // RSA modulus
+	E         string `json:"e,omitempty"`   // RSA exponent
+	Curve     string `json:"crv,omitempty"` // OKP curve
+	X         string `json:"x,omitempty"`   // OKP public key
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys tokens may be verified with at now, together with the keys
+// scheduled to become active, so verifiers know them before the first token arrives.
+// HS256 keys are secret and left out.
+func (s *KeySet) JWKS(now time.Time) JWKS {
+	set := JWKS{Keys: []JWK{}}
+	for i, key := range s.keys {
+		if !s.accepted(i, now) && !key.ActiveFrom.After(now) {
+			continue
+		}
+		jwk := JWK{KeyID: key.ID, Use: "sig", Algorithm: key.Algorithm}
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType, jwk.Curve = "OKP", "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		default:
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
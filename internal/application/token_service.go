@@ -0,0 +1,152 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"task-manager-app/backend/internal/domain"
+	"task-manager-app/backend/pkg/utils"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// TokenConfig holds the settings of the access tokens.
+type TokenConfig struct {
+	Keys      *KeySet       // Keys to sign and verify with
+	Issuer    string        // iss, checked on validation
+	Audience  string        // aud, checked on validation
+	Expiry    time.Duration // Lifetime of an access token
+	ClockSkew time.Duration // Tolerance for exp, iat and nbf, for clocks of other machines
+}
+
+// TokenService issues and validates access tokens. It is the only place that knows how
+// they are signed and what their claims look like.
+type TokenService struct {
+	cfg         TokenConfig
+	revocations *RevocationService
+}
+
+func NewTokenService(cfg TokenConfig, revocations *RevocationService) *TokenService {
+	return &TokenService{cfg: cfg, revocations: revocations}
+}
+
+type tokenClaims struct {
+	SessionID string   `json:"sid,omitempty"`
+	Roles     []string `json:"roles,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Expiry returns the lifetime of an access token.
+func (s *TokenService) Expiry() time.Duration {
+	return s.cfg.Expiry
+}
+
+// Issue signs a new access token for the user, tied to the given session.
+func (s *TokenService) Issue(userID int, roles []string, sessionID string) (string, *domain.AccessClaims, error) {
+	jti, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return "", nil, err
+	}
+	now := time.Now()
+	key, err := s.cfg.Keys.Signing(now)
+	if err != nil {
+		return "", nil, err
+	}
+	claims := &tokenClaims{
+		SessionID: sessionID,
+		Roles:     roles,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.Itoa(userID),
+			ID:        jti,
+			Issuer:    s.cfg.Issuer,
+			Audience:  jwt.ClaimStrings{s.cfg.Audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.cfg.Expiry)),
+		},
+	}
+	token := jwt.NewWithClaims(key.method(), claims)
+	token.Header["kid"] = key.ID
+	signed, err := token.SignedString(key.private)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to sign token: %w", err)
+	}
+	return signed, toAccessClaims(claims, userID), nil
+}
+
+// Validate checks the token's signature, issuer, audience and lifetime and that it was not
+// revoked. The token must name a key of the set that is still accepted and use its
+// algorithm. It fails with domain.ErrInvalidToken or domain.ErrTokenRevoked for tokens that
+// must be rejected, and with other errors when the check itself could not be made.
+func (s *TokenService) Validate(raw string) (*domain.AccessClaims, error) {
+	claims := &tokenClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, s.verificationKey,
+		jwt.WithValidMethods(s.cfg.Keys.Algorithms()),
+		jwt.WithIssuer(s.cfg.Issuer),
+		jwt.WithAudience(s.cfg.Audience),
+		jwt.WithLeeway(s.cfg.ClockSkew),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		return nil, domain.ErrInvalidToken
+	}
+	userID, err := strconv.Atoi(claims.Subject)
+	if err != nil || claims.ID == "" || claims.IssuedAt == nil {
+		return nil, domain.ErrInvalidToken
+	}
+	access := toAccessClaims(claims, userID)
+	revoked, err := s.revocations.IsRevoked(access.TokenID, access.SessionID, access.UserID, access.IssuedAt)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, domain.ErrTokenRevoked
+	}
+	return access, nil
+}
+
+// JWKS returns the public keys tokens can be verified with, for other services.
+func (s *TokenService) JWKS() JWKS {
+	return s.cfg.Keys.JWKS(time.Now())
+}
+
+func (s *TokenService) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := s.cfg.Keys.Verifying(kid, time.Now())
+	if !ok || token.Method.Alg() != key.Algorithm {
+		return nil, errors.New("unknown or retired signing key")
+	}
+	return key.public, nil
+}
+
+// Revoke makes the token stop working right away.
+func (s *TokenService) Revoke(claims *domain.AccessClaims) error {
+	return s.revocations.RevokeToken(claims.TokenID, claims.UserID, claims.ExpiresAt.Add(s.cfg.ClockSkew))
+}
+
+// RevokeSession makes every token issued for the session so far stop working.
+func (s *TokenService) RevokeSession(sessionID string, userID int) error {
+	return s.revocations.RevokeSession(sessionID, userID, s.cfg.Expiry+s.cfg.ClockSkew)
+}
+
+// RevokeUser makes every token issued to the user so far stop working.
+func (s *TokenService) RevokeUser(userID int) error {
+	return s.revocations.RevokeUser(userID, s.cfg.Expiry+s.cfg.ClockSkew)
+}
+
+func toAccessClaims(claims *tokenClaims, userID int) *domain.AccessClaims {
+	access := &domain.AccessClaims{
+		UserID:    userID,
+		TokenID:   claims.ID,
+		SessionID: claims.SessionID,
+		Roles:     claims.Roles,
+	}
+	if claims.IssuedAt != nil {
+		access.IssuedAt = claims.IssuedAt.Time
+	}
+	if claims.ExpiresAt != nil {
+		access.ExpiresAt = claims.ExpiresAt.Time
+	}
+	return access
+}
@@ -0,0 +1,121 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"task-manager-app/backend/internal/domain"
+	"time"
+)
+
+// TrashService manages soft-deleted tasks and users: listing, restoring and purging them,
+// including the automatic purge once they have been in the trash longer than the retention.
+// Tasks are restored and purged by those allowed to delete them; deleted users are managed
+// by superadmins only.
+type TrashService struct {
+	tasks     domain.TaskRepository
+	users     domain.UserRepository
+	blobs     domain.BlobStore
+	retention time.Duration
+	policy    *AccessPolicy
+}
+
+// NewTrashService creates the service; a retention of zero keeps trashed items until purged by hand.
+func NewTrashService(tasks domain.TaskRepository, users domain.UserRepository, blobs domain.BlobStore, retention time.Duration, policy *AccessPolicy) *TrashService {
+	return &TrashService{tasks: tasks, users: users, blobs: blobs, retention: retention, policy: policy}
+}
+
+// GetDeletedTasks lists the trashed tasks the actor may see.
+func (s *TrashService) GetDeletedTasks(filter domain.TaskFilter, actor domain.Actor) (*domain.TaskConnection, error) {
+	access, err := s.policy.TaskAccess(actor)
+	if err != nil {
+		return nil, err
+	}
+	filter.Access = access
+	return s.tasks.FindDeleted(filter)
+}
+
+func (s *TrashService) GetDeletedUsers(actor domain.Actor) ([]domain.User, error) {
+	if err := s.policy.CheckSuperadmin(actor); err != nil {
+		return nil, err
+	}
+	return s.users.FindDeleted()
+}
+
+func (s *TrashService) RestoreTask(id int, actor domain.Actor) (*domain.Task, error) {
+	if err := s.authorizeDeleted(id, actor); err != nil {
+		return nil, err
+	}
+	if err := s.tasks.Restore(id); err != nil {
+		return nil, err
+	}
+	return s.tasks.FindByID(id)
+}
+
+func (s *TrashService) RestoreUser(id int, actor domain.Actor) (*domain.User, error) {
+	if err := s.policy.CheckSuperadmin(actor); err != nil {
+		return nil, err
+	}
+	if err := s.users.Restore(id); err != nil {
+		return nil, err
+	}
+	return s.users.FindByID(id)
+}
+
+// PurgeTask permanently deletes a trashed task and the content of its attachments.
+func (s *TrashService) PurgeTask(ctx context.Context, id int, actor domain.Actor) error {
+	if err := s.authorizeDeleted(id, actor); err != nil {
+		return err
+	}
+	attachments, err := s.tasks.Purge(id)
+	if err != nil {
+		return err
+	}
+	return s.deleteBlobs(ctx, attachments)
+}
+
+func (s *TrashService) PurgeUser(id int, actor domain.Actor) error {
+	if err := s.policy.CheckSuperadmin(actor); err != nil {
+		return err
+	}
+	return s.users.Purge(id)
+}
+
+// authorizeDeleted checks the actor may delete the trashed task, which is what it takes to
+// restore or purge it.
+func (s *TrashService) authorizeDeleted(id int, actor domain.Actor) error {
+	task, err := s.tasks.FindDeletedByID(id)
+	if err != nil {
+		return err
+	}
+	return s.policy.CheckTask(actor, task, domain.PermDeleteTasks)
+}
+
+// PurgeExpired permanently deletes everything that has been in the trash longer than the retention.
+func (s *TrashService) PurgeExpired(ctx context.Context, now time.Time) error {
+	if s.retention <= 0 {
+		return nil
+	}
+	cutoff := now.Add(-s.retention)
+	attachments, err := s.tasks.PurgeDeletedBefore(cutoff)
+	if err != nil {
+		return err
+	}
+	if _, err := s.users.PurgeDeletedBefore(cutoff); err != nil {
+		return err
+	}
+	return s.deleteBlobs(ctx, attachments)
+}
+
+// deleteBlobs removes attachment contents, carrying on past failures so one bad blob does not keep the rest.
+func (s *TrashService) deleteBlobs(ctx context.Context, attachments []domain.Attachment) error {
+	if s.blobs == nil {
+		return nil
+	}
+	var errs []error
+	for _, attachment := range attachments {
+		if err := s.blobs.Delete(ctx, attachment.StorageKey); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
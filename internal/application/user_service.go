@@ -2,24 +2,28 @@ package application
 
 import (
 	"errors"
-	"strconv"
 	"task-manager-app/backend/internal/domain"
 	"task-manager-app/backend/pkg/utils"
+	"time"
 )
 
 type UserService struct {
-	repo domain.UserRepository
+	repo          domain.UserRepository
+	refreshTokens domain.RefreshTokenRepository
+	tokens        *TokenService
+	refreshExpiry time.Duration
+	policy        *AccessPolicy
 }
 
-func NewUserService(repo domain.UserRepository) *UserService {
-	return &UserService{repo: repo}
+func NewUserService(repo domain.UserRepository, refreshTokens domain.RefreshTokenRepository, tokens *TokenService, refreshExpiry time.Duration, policy *AccessPolicy) *UserService {
+	return &UserService{repo: repo, refreshTokens: refreshTokens, tokens: tokens, refreshExpiry: refreshExpiry, policy: policy}
 }
 
 func (s *UserService) Register(user *domain.User) error {
 	// Verificar se o email já existe
 	existingUser, err := s.repo.FindByEmail(user.Email)
 	if err == nil && existingUser != nil {
-		return errors.New("email already in use")
+		return domain.ErrEmailTaken
 	}
 
 	if err := utils.ValidatePassword(user.PasswordHash); err != nil {
@@ -28,50 +32,203 @@ func (s *UserService) Register(user *domain.User) error {
 	return s.repo.Create(user)
 }
 
-func (s *UserService) Login(email, password string) (*domain.User, string, error) {
+// Login checks the credentials and starts a session: a short-lived access token plus a
+// refresh token beginning a new token family.
+func (s *UserService) Login(email, password string) (*domain.User, *domain.TokenPair, error) {
 	user, err := s.repo.FindByEmail(email)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
 	if err := user.CheckPassword(password); err != nil {
-		return nil, "", errors.New("invalid credentials")
+		return nil, nil, errors.New("invalid credentials")
+	}
+	familyID, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return nil, nil, err
 	}
-	token, err := utils.GenerateJWT(strconv.Itoa(user.ID))
+	refresh, raw, err := s.newRefreshToken(user.ID, familyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.refreshTokens.Create(refresh); err != nil {
+		return nil, nil, err
+	}
+	pair, err := s.tokenPair(user, familyID, raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, pair, nil
+}
+
+// Refresh exchanges a refresh token for a new access token and a new refresh token; the
+// presented one cannot be used again. Presenting an already used token revokes every
+// token of its family and the access tokens issued for it, ending the session for
+// whoever holds them.
+func (s *UserService) Refresh(token string) (*domain.User, *domain.TokenPair, error) {
+	current, err := s.refreshTokens.FindByHash(utils.HashToken(token))
+	if err != nil {
+		return nil, nil, domain.ErrInvalidRefreshToken
+	}
+	if current.UsedAt != nil {
+		return nil, nil, s.revokeReused(current)
+	}
+	if !current.Usable(time.Now()) {
+		return nil, nil, domain.ErrInvalidRefreshToken
+	}
+	user, err := s.repo.FindByID(current.UserID)
+	if err != nil {
+		return nil, nil, domain.ErrInvalidRefreshToken
+	}
+	next, raw, err := s.newRefreshToken(user.ID, current.FamilyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.refreshTokens.Rotate(current, next); err != nil {
+		if errors.Is(err, domain.ErrRefreshTokenReused) {
+			return nil, nil, s.revokeReused(current)
+		}
+		return nil, nil, err
+	}
+	pair, err := s.tokenPair(user, current.FamilyID, raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, pair, nil
+}
+
+// Logout ends the session of the access token with the given claims: the token stops
+// working right away and the refresh tokens of its session are revoked.
+func (s *UserService) Logout(claims *domain.AccessClaims) error {
+	if err := s.tokens.Revoke(claims); err != nil {
+		return err
+	}
+	if claims.SessionID == "" {
+		return nil
+	}
+	return s.refreshTokens.RevokeFamily(claims.SessionID)
+}
+
+// LogoutEverywhere ends every session of the user: all access tokens issued so far stop
+// working and all refresh tokens are revoked.
+func (s *UserService) LogoutEverywhere(userID int) error {
+	if err := s.tokens.RevokeUser(userID); err != nil {
+		return err
+	}
+	return s.refreshTokens.RevokeUser(userID)
+}
+
+// PruneRefreshTokens deletes the refresh tokens that expired before now and returns how many there were.
+func (s *UserService) PruneRefreshTokens(now time.Time) (int, error) {
+	return s.refreshTokens.DeleteExpiredBefore(now)
+}
+
+func (s *UserService) revokeReused(token *domain.RefreshToken) error {
+	if err := s.refreshTokens.RevokeFamily(token.FamilyID); err != nil {
+		return err
+	}
+	if err := s.tokens.RevokeSession(token.FamilyID, token.UserID); err != nil {
+		return err
+	}
+	return domain.ErrRefreshTokenReused
+}
+
+// newRefreshToken creates the record of a new refresh token and returns it with the token
+// to hand to the client.
+func (s *UserService) newRefreshToken(userID int, familyID string) (*domain.RefreshToken, string, error) {
+	raw, err := utils.GenerateOpaqueToken()
 	if err != nil {
 		return nil, "", err
 	}
+	return &domain.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: utils.HashToken(raw),
+		ExpiresAt: time.Now().Add(s.refreshExpiry),
+	}, raw, nil
+}
 
-	return user, token, nil
+// tokenPair issues the access token of a session. The roles claim only carries the global
+// role; workspace roles are looked up when needed so changes apply right away.
+func (s *UserService) tokenPair(user *domain.User, familyID, refreshToken string) (*domain.TokenPair, error) {
+	var roles []string
+	if user.Superadmin {
+		roles = []string{domain.RoleSuperadmin}
+	}
+	access, _, err := s.tokens.Issue(user.ID, roles, familyID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.TokenPair{
+		AccessToken:  access,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(s.tokens.Expiry().Seconds()),
+		RefreshToken: refreshToken,
+	}, nil
 }
 
-func (s *UserService) GetAllUsers() ([]*domain.User, error) {
+// GetAllUsers lists the users the actor may see: the members of their workspaces, or
+// everyone for a superadmin.
+func (s *UserService) GetAllUsers(actor domain.Actor) ([]*domain.User, error) {
+	visible, err := s.policy.VisibleUsers(actor)
+	if err != nil {
+		return nil, err
+	}
 	users, err := s.repo.FindAll()
 	if err != nil {
 		return nil, err
 	}
 
 	// Convert []domain.User to []*domain.User
-	userPtrs := make([]*domain.User, len(users))
-	for i, user := range users {
-		userPtrs[i] = &user
+	userPtrs := make([]*domain.User, 0, len(users))
+	for _, user := range users {
+		if visible == nil || visible[user.ID] {
+			userPtrs = append(userPtrs, &user)
+		}
 	}
 
 	return userPtrs, nil
 }
 
-func (s *UserService) GetUserByID(id int) (*domain.User, error) {
+func (s *UserService) GetUserByID(id int, actor domain.Actor) (*domain.User, error) {
+	if err := s.policy.CheckUser(actor, id, false); err != nil {
+		return nil, err
+	}
 	return s.repo.FindByID(id)
 }
 
-func (s *UserService) GetUserByEmail(email string) (*domain.User, error) {
-	return s.repo.FindByEmail(email)
+func (s *UserService) GetUserByEmail(email string, actor domain.Actor) (*domain.User, error) {
+	user, err := s.repo.FindByEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.policy.CheckUser(actor, user.ID, false); err != nil {
+		return nil, err
+	}
+	return user, nil
 }
 
-func (s *UserService) UpdateUser(user *domain.User) error {
-	return s.repo.Update(user)
+// UpdateUser changes the profile of an account: users edit their own, superadmins anyone's.
+// The password and the superadmin flag are not changed this way.
+func (s *UserService) UpdateUser(user *domain.User, actor domain.Actor) error {
+	if err := s.policy.CheckUser(actor, user.ID, true); err != nil {
+		return err
+	}
+	current, err := s.repo.FindByID(user.ID)
+	if err != nil {
+		return err
+	}
+	current.Email, current.Name, current.LastName, current.Avatar = user.Email, user.Name, user.LastName, user.Avatar
+	if err := s.repo.Update(current); err != nil {
+		return err
+	}
+	*user = *current
+	return nil
 }
 
-func (s *UserService) DeleteUser(id int) error {
+func (s *UserService) DeleteUser(id int, actor domain.Actor) error {
+	if err := s.policy.CheckUser(actor, id, true); err != nil {
+		return err
+	}
 	return s.repo.Delete(id)
 }
@@ -0,0 +1,154 @@
+package application
+
+import (
+	"errors"
+	"task-manager-app/backend/internal/domain"
+)
+
+type WorkspaceService struct {
+	repo   domain.WorkspaceRepository
+	policy *AccessPolicy
+}
+
+func NewWorkspaceService(repo domain.WorkspaceRepository, policy *AccessPolicy) *WorkspaceService {
+	return &WorkspaceService{repo: repo, policy: policy}
+}
+
+// CreateWorkspace creates a workspace owned by the actor, who becomes its first member.
+func (s *WorkspaceService) CreateWorkspace(workspace *domain.Workspace, actor domain.Actor) error {
+	if workspace.Name == "" {
+		return errors.New("workspace name is required")
+	}
+	if actor.UserID != 0 {
+		workspace.OwnerID = actor.UserID
+	}
+	if err := s.policy.CheckCreate(actor, 0); err != nil {
+		return err
+	}
+	if err := s.repo.Create(workspace); err != nil {
+		return err
+	}
+	if workspace.OwnerID == 0 {
+		return nil
+	}
+	return s.repo.SaveMember(&domain.WorkspaceMember{WorkspaceID: workspace.ID, UserID: workspace.OwnerID, Role: domain.RoleOwner})
+}
+
+func (s *WorkspaceService) GetWorkspaceByID(id int, actor domain.Actor) (*domain.Workspace, error) {
+	workspace, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.policy.CheckWorkspace(actor, id, domain.PermViewTasks); err != nil {
+		return nil, err
+	}
+	return workspace, nil
+}
+
+func (s *WorkspaceService) GetWorkspacesByOwnerID(ownerID int) ([]domain.Workspace, error) {
+	return s.repo.FindByOwnerID(ownerID)
+}
+
+// GetWorkflow returns the transitions configured for a workspace, or the default ones.
+func (s *WorkspaceService) GetWorkflow(workspaceID int, actor domain.Actor) ([]domain.WorkflowTransition, error) {
+	if err := s.policy.CheckWorkspace(actor, workspaceID, domain.PermViewTasks); err != nil {
+		return nil, err
+	}
+	transitions, err := s.repo.FindTransitions(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if len(transitions) == 0 {
+		return domain.DefaultTransitions(), nil
+	}
+	return transitions, nil
+}
+
+// SetWorkflow replaces the workflow of a workspace. An empty list restores the default workflow.
+func (s *WorkspaceService) SetWorkflow(workspaceID int, transitions []domain.WorkflowTransition, actor domain.Actor) error {
+	if _, err := s.repo.FindByID(workspaceID); err != nil {
+		return err
+	}
+	if err := s.policy.CheckWorkspace(actor, workspaceID, domain.PermManageWorkspace); err != nil {
+		return err
+	}
+	if _, err := domain.NewWorkflow(transitions); err != nil {
+		return err
+	}
+	return s.repo.ReplaceTransitions(workspaceID, transitions)
+}
+
+// GetMembers lists the members of a workspace and their roles.
+func (s *WorkspaceService) GetMembers(workspaceID int, actor domain.Actor) ([]domain.WorkspaceMember, error) {
+	if _, err := s.GetWorkspaceByID(workspaceID, actor); err != nil {
+		return nil, err
+	}
+	return s.repo.FindMembers(workspaceID)
+}
+
+// SetMember adds a user to a workspace or changes their role. Admins manage members but only
+// owners grant or take away the owner role, and the last owner cannot be demoted.
+func (s *WorkspaceService) SetMember(member *domain.WorkspaceMember, actor domain.Actor) error {
+	if !member.Role.IsValid() {
+		return domain.ErrInvalidRole
+	}
+	members, err := s.GetMembers(member.WorkspaceID, actor)
+	if err != nil {
+		return err
+	}
+	current := findMember(members, member.UserID)
+	if err := s.checkMemberChange(member.WorkspaceID, current, member.Role, actor); err != nil {
+		return err
+	}
+	if current != nil && current.Role == domain.RoleOwner && member.Role != domain.RoleOwner && countOwners(members) == 1 {
+		return domain.ErrLastOwner
+	}
+	return s.repo.SaveMember(member)
+}
+
+// RemoveMember takes a user out of a workspace under the same rules as changing their role.
+func (s *WorkspaceService) RemoveMember(workspaceID, userID int, actor domain.Actor) error {
+	members, err := s.GetMembers(workspaceID, actor)
+	if err != nil {
+		return err
+	}
+	current := findMember(members, userID)
+	if current == nil {
+		return domain.ErrNotMember
+	}
+	if err := s.checkMemberChange(workspaceID, current, "", actor); err != nil {
+		return err
+	}
+	if current.Role == domain.RoleOwner && countOwners(members) == 1 {
+		return domain.ErrLastOwner
+	}
+	return s.repo.RemoveMember(workspaceID, userID)
+}
+
+// checkMemberChange checks the actor may change a membership to the role, "" meaning removal.
+func (s *WorkspaceService) checkMemberChange(workspaceID int, current *domain.WorkspaceMember, role domain.Role, actor domain.Actor) error {
+	perm := domain.PermManageMembers
+	if role == domain.RoleOwner || current != nil && current.Role == domain.RoleOwner {
+		perm = domain.PermManageOwners
+	}
+	return s.policy.CheckWorkspace(actor, workspaceID, perm)
+}
+
+func findMember(members []domain.WorkspaceMember, userID int) *domain.WorkspaceMember {
+	for i := range members {
+		if members[i].UserID == userID {
+			return &members[i]
+		}
+	}
+	return nil
+}
+
+func countOwners(members []domain.WorkspaceMember) int {
+	owners := 0
+	for _, member := range members {
+		if member.Role == domain.RoleOwner {
+			owners++
+		}
+	}
+	return owners
+}
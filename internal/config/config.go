@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -27,8 +29,39 @@ type Config struct {
 
 	JWT struct {
 		Secret        string
+		Issuer        string
+		Audience      string
 		TokenExpiry   time.Duration
 		RefreshExpiry time.Duration
+		ClockSkew     time.Duration // Leeway when checking token times
+		// Keys are RS256/EdDSA signing keys as "kid=file:/path/key.pem" or "kid=env:VAR", each
+		// optionally followed by "@<RFC 3339 time>" when it takes over signing. Tokens are
+		// signed with HS256 and Secret when there are none.
+		Keys     []string
+		KeyGrace time.Duration // How long a key is still accepted after the next one took over
+	}
+
+	Storage struct {
+		Driver        string // "local" or "s3"
+		LocalDir      string
+		MaxUploadSize int64
+		AllowedTypes  []string // Content type prefixes accepted for attachments
+		S3Endpoint    string
+		S3Region      string
+		S3Bucket      string
+		S3AccessKey   string
+		S3SecretKey   string
+	}
+
+	Trash struct {
+		Retention     time.Duration // How long deleted items stay restorable; 0 disables the automatic purge
+		PurgeInterval time.Duration
+	}
+
+	Archive struct {
+		After    time.Duration // How long a task stays in one of Statuses before it is archived; 0 disables auto-archiving
+		Statuses []string
+		Interval time.Duration
 	}
 
 	Environment string
@@ -54,9 +87,67 @@ func Load() (*Config, error) {
 	cfg.Database.SSLMode = getEnv("DB_SSLMODE", "disable")
 
 	// JWT config
+	var err error
 	cfg.JWT.Secret = getEnv("JWT_SECRET", "your-secret-key")
-	cfg.JWT.TokenExpiry = time.Hour * 24    // 24 hours
-	cfg.JWT.RefreshExpiry = time.Hour * 168 // 7 days
+	cfg.JWT.Issuer = getEnv("JWT_ISSUER", "task-manager")
+	cfg.JWT.Audience = getEnv("JWT_AUDIENCE", "task-manager-api")
+	cfg.JWT.TokenExpiry, err = time.ParseDuration(getEnv("JWT_TOKEN_EXPIRY", "15m")) // Access tokens are short-lived, clients refresh them
+	if err != nil || cfg.JWT.TokenExpiry <= 0 {
+		return nil, fmt.Errorf("invalid JWT_TOKEN_EXPIRY: %v", getEnv("JWT_TOKEN_EXPIRY", "15m"))
+	}
+	cfg.JWT.RefreshExpiry, err = time.ParseDuration(getEnv("JWT_REFRESH_EXPIRY", "168h")) // 7 days
+	if err != nil || cfg.JWT.RefreshExpiry <= 0 {
+		return nil, fmt.Errorf("invalid JWT_REFRESH_EXPIRY: %v", getEnv("JWT_REFRESH_EXPIRY", "168h"))
+	}
+	cfg.JWT.ClockSkew, err = time.ParseDuration(getEnv("JWT_CLOCK_SKEW", "30s"))
+	if err != nil || cfg.JWT.ClockSkew < 0 {
+		return nil, fmt.Errorf("invalid JWT_CLOCK_SKEW: %v", getEnv("JWT_CLOCK_SKEW", "30s"))
+	}
+	if keys := getEnv("JWT_KEYS", ""); keys != "" {
+		cfg.JWT.Keys = strings.Split(keys, ",")
+	}
+	// Tokens signed right before a rotation must stay valid until they expire
+	defaultGrace := (cfg.JWT.TokenExpiry + cfg.JWT.ClockSkew).String()
+	cfg.JWT.KeyGrace, err = time.ParseDuration(getEnv("JWT_KEY_GRACE", defaultGrace))
+	if err != nil || cfg.JWT.KeyGrace < cfg.JWT.TokenExpiry {
+		return nil, fmt.Errorf("invalid JWT_KEY_GRACE, it must be at least JWT_TOKEN_EXPIRY: %v", getEnv("JWT_KEY_GRACE", defaultGrace))
+	}
+
+	// Attachment storage config
+	cfg.Storage.Driver = getEnv("STORAGE_DRIVER", "local")
+	cfg.Storage.LocalDir = getEnv("STORAGE_LOCAL_DIR", "./data/attachments")
+	maxUploadSize, err := strconv.ParseInt(getEnv("ATTACHMENT_MAX_SIZE", "26214400"), 10, 64) // 25 MiB
+	if err != nil {
+		return nil, fmt.Errorf("invalid ATTACHMENT_MAX_SIZE: %w", err)
+	}
+	cfg.Storage.MaxUploadSize = maxUploadSize
+	cfg.Storage.AllowedTypes = strings.Split(getEnv("ATTACHMENT_ALLOWED_TYPES", "image/,application/pdf,text/plain,application/zip,application/octet-stream"), ",")
+	cfg.Storage.S3Endpoint = getEnv("S3_ENDPOINT", "")
+	cfg.Storage.S3Region = getEnv("S3_REGION", "us-east-1")
+	cfg.Storage.S3Bucket = getEnv("S3_BUCKET", "")
+	cfg.Storage.S3AccessKey = getEnv("S3_ACCESS_KEY", "")
+	cfg.Storage.S3SecretKey = getEnv("S3_SECRET_KEY", "")
+
+	// Trash config
+	cfg.Trash.Retention, err = time.ParseDuration(getEnv("TRASH_RETENTION", "720h")) // 30 days
+	if err != nil {
+		return nil, fmt.Errorf("invalid TRASH_RETENTION: %w", err)
+	}
+	cfg.Trash.PurgeInterval, err = time.ParseDuration(getEnv("TRASH_PURGE_INTERVAL", "1h"))
+	if err != nil || cfg.Trash.PurgeInterval <= 0 {
+		return nil, fmt.Errorf("invalid TRASH_PURGE_INTERVAL: %v", getEnv("TRASH_PURGE_INTERVAL", "1h"))
+	}
+
+	// Auto-archive config
+	cfg.Archive.After, err = time.ParseDuration(getEnv("ARCHIVE_AFTER", "720h")) // 30 days
+	if err != nil {
+		return nil, fmt.Errorf("invalid ARCHIVE_AFTER: %w", err)
+	}
+	cfg.Archive.Statuses = strings.Split(getEnv("ARCHIVE_STATUSES", "done"), ",")
+	cfg.Archive.Interval, err = time.ParseDuration(getEnv("ARCHIVE_INTERVAL", "1h"))
+	if err != nil || cfg.Archive.Interval <= 0 {
+		return nil, fmt.Errorf("invalid ARCHIVE_INTERVAL: %v", getEnv("ARCHIVE_INTERVAL", "1h"))
+	}
 
 	cfg.Environment = getEnv("ENV", "development")
 
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrForbidden   = errors.New("you do not have permission to do this")
+	ErrInvalidRole = errors.New("role must be one of owner, admin, member or viewer")
+	ErrLastOwner   = errors.New("a workspace must keep at least one owner")
+	ErrNotMember   = errors.New("user is not a member of this workspace")
+)
+
+// Role is what a user may do in a workspace. Superadmins are not workspace members but
+// users flagged as such, and may do anything anywhere.
+type Role string
+
+const (
+	RoleOwner  Role = "owner"
+	RoleAdmin  Role = "admin"
+	RoleMember Role = "member"
+	RoleViewer Role = "viewer"
+
+	RoleSuperadmin = "superadmin" // Global role, carried in the roles claim of access tokens
+)
+
+func (r Role) IsValid() bool {
+	_, ok := permissions[r]
+	return ok
+}
+
+// Permission is an action the permission matrix grants to roles.
+type Permission string
+
+const (
+	PermViewTasks       Permission = "tasks:view"
+	PermCreateTasks     Permission = "tasks:create"
+	PermEditTasks       Permission = "tasks:edit"
+	PermDeleteTasks     Permission = "tasks:delete" // Any task; members may still delete the tasks they created
+	PermManageWorkspace Permission = "workspace:manage"
+	PermManageMembers   Permission = "members:manage"
+	PermManageOwners    Permission = "owners:manage" // Grant and revoke the owner role
+)
+
+// permissions is the permission matrix of the workspace roles.
+var permissions = map[Role][]Permission{
+	RoleViewer: {PermViewTasks},
+	RoleMember: {PermViewTasks, PermCreateTasks, PermEditTasks},
+	RoleAdmin:  {PermViewTasks, PermCreateTasks, PermEditTasks, PermDeleteTasks, PermManageWorkspace, PermManageMembers},
+	RoleOwner:  {PermViewTasks, PermCreateTasks, PermEditTasks, PermDeleteTasks, PermManageWorkspace, PermManageMembers, PermManageOwners},
+}
+
+// Can reports whether the role grants the permission.
+func (r Role) Can(perm Permission) bool {
+	for _, granted := range permissions[r] {
+		if granted == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkspaceMember gives a user a role in a workspace.
+type WorkspaceMember struct {
+	WorkspaceID int       `json:"workspaceId" gorm:"primaryKey;autoIncrement:false"`
+	UserID      int       `json:"userId" gorm:"primaryKey;autoIncrement:false;index"`
+	Role        Role      `json:"role"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// PersonalRole returns the role a user has on a task outside any workspace: its creator
+// owns it, assignees work on it and watchers can see it.
+func (t *Task) PersonalRole(userID int) (Role, bool) {
+	if userID == 0 {
+		return "", false
+	}
+	if t.UserID == userID {
+		return RoleOwner, true
+	}
+	for _, assignee := range t.Assignees {
+		if assignee.ID == userID {
+			return RoleMember, true
+		}
+	}
+	for _, watcher := range t.Watchers {
+		if watcher.ID == userID {
+			return RoleViewer, true
+		}
+	}
+	return "", false
+}
+
+// TaskAccess limits a task query to what a user may see: the tasks of the given
+// workspaces and the personal tasks they created, are assigned to or watch.
+type TaskAccess struct {
+	UserID       int
+	WorkspaceIDs []int
+}
@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ActivitySource tells through which channel a change was made.
+type ActivitySource string
+
+const (
+	SourceREST    ActivitySource = "rest"
+	SourceGraphQL ActivitySource = "graphql"
+	SourceSystem  ActivitySource = "system" // Changes made by the server itself, e.g. opening the next recurring occurrence
+)
+
+type ActivityAction string
+
+const (
+	ActivityCreated ActivityAction = "created"
+	ActivityUpdated ActivityAction = "updated"
+)
+
+// Actor is who made a change and through which channel.
+type Actor struct {
+	UserID int
+	Source ActivitySource
+}
+
+// SystemActor is the actor for changes the server makes on its own.
+func SystemActor() Actor {
+	return Actor{Source: SourceSystem}
+}
+
+// FieldChange is the old and new value of one task field, formatted as text. Empty means unset.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// Activity is an entry of a task's append-only change log.
+type Activity struct {
+	ID        int            `json:"id"`
+	TaskID    int            `json:"taskId" gorm:"index"`
+	ActorID   int            `json:"actorId"` // 0 for system changes
+	Source    ActivitySource `json:"source"`
+	Action    ActivityAction `json:"action"`
+	Changes   []FieldChange  `json:"changes" gorm:"serializer:json"`
+	CreatedAt time.Time      `json:"createdAt"`
+}
+
+// DiffTasks lists the fields that differ between two versions of a task, in a stable order.
+func DiffTasks(before, after *Task) []FieldChange {
+	var changes []FieldChange
+	add := func(field, old, new string) {
+		if old != new {
+			changes = append(changes, FieldChange{Field: field, Old: old, New: new})
+		}
+	}
+	add("title", before.Title, after.Title)
+	add("description", before.Description, after.Description)
+	add("status", string(before.CurrentStatus()), string(after.CurrentStatus()))
+	add("priority", before.Priority.String(), after.Priority.String())
+	add("userId", formatID(before.UserID), formatID(after.UserID))
+	add("workspaceId", formatID(before.WorkspaceID), formatID(after.WorkspaceID))
+	add("projectId", formatID(before.ProjectID), formatID(after.ProjectID))
+	add("parentId", formatID(before.ParentID), formatID(after.ParentID))
+	add("startAt", formatTime(before.StartAt), formatTime(after.StartAt))
+	add("dueAt", formatTime(before.DueAt), formatTime(after.DueAt))
+	add("timezone", before.Timezone, after.Timezone)
+	add("estimateMinutes", formatID(before.EstimateMinutes), formatID(after.EstimateMinutes))
+	add("remainingMinutes", formatOptionalInt(before.RemainingMinutes), formatOptionalInt(after.RemainingMinutes))
+	add("recurrence", before.Recurrence, after.Recurrence)
+	add("occurrence", formatID(before.Occurrence), formatID(after.Occurrence))
+	add("archivedAt", formatTime(before.ArchivedAt), formatTime(after.ArchivedAt))
+	add("columnId", formatID(before.ColumnID), formatID(after.ColumnID))
+	add("sprintId", formatID(before.SprintID), formatID(after.SprintID))
+	return changes
+}
+
+// DiffUsers records a change of a user list such as the assignees of a task.
+func DiffUsers(field string, before, after []User) []FieldChange {
+	old, new := formatUserIDs(before), formatUserIDs(after)
+	if old == new {
+		return nil
+	}
+	return []FieldChange{{Field: field, Old: old, New: new}}
+}
+
+func formatID(id int) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.Itoa(id)
+}
+
+// parseID reads back a value written by formatID.
+func parseID(s string) int {
+	id, _ := strconv.Atoi(s)
+	return id
+}
+
+func formatOptionalInt(n *int) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.Itoa(*n)
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatUserIDs(users []User) string {
+	ids := make([]string, len(users))
+	for i, user := range users {
+		ids[i] = strconv.Itoa(user.ID)
+	}
+	return strings.Join(ids, ",")
+}
+
+type ActivityFilter struct {
+	TaskID int `json:"taskId"`
+	Page   int `json:"page"`
+	Limit  int `json:"limit"`
+}
+
+type ActivityEdge struct {
+	Node Activity `json:"node"`
+}
+
+type ActivityConnection struct {
+	Edges    []ActivityEdge `json:"edges"`
+	PageInfo struct {
+		HasNextPage     bool `json:"hasNextPage"`
+		HasPreviousPage bool `json:"hasPreviousPage"`
+		TotalCount      int  `json:"totalCount"`
+	} `json:"pageInfo"`
+}
+
+// ActivityRepository only appends; entries are never changed once written.
+type ActivityRepository interface {
+	Create(activity *Activity) error
+	FindByTask(filter ActivityFilter) (*ActivityConnection, error) // Newest first
+}
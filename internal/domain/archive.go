@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrAlreadyArchived = errors.New("task is already archived")
+	ErrNotArchived     = errors.New("task is not archived")
+)
+
+// ArchiveRule decides which tasks the background job archives: those in one of the
+// statuses whose status has not changed for longer than After.
+type ArchiveRule struct {
+	Statuses []TaskStatus
+	After    time.Duration
+}
+
+// Enabled reports whether the rule archives anything at all.
+func (r ArchiveRule) Enabled() bool {
+	return r.After > 0 && len(r.Statuses) > 0
+}
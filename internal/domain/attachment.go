@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+var (
+	ErrEmptyAttachment        = errors.New("attachment is empty")
+	ErrAttachmentTooLarge     = errors.New("attachment exceeds the maximum upload size")
+	ErrAttachmentType         = errors.New("attachment file type is not allowed")
+	ErrAttachmentForbidden    = errors.New("only the uploader or the task owner can delete this attachment")
+	ErrAttachmentSizeMismatch = errors.New("attachment size does not match the uploaded content")
+	ErrBlobNotFound           = errors.New("blob not found")
+)
+
+// Attachment is the metadata of a file attached to a task; the content lives in a BlobStore.
+type Attachment struct {
+	ID          int       `json:"id"`
+	TaskID      int       `json:"taskId"`
+	Filename    string    `json:"filename"`
+	Size        int64     `json:"size"`
+	ContentType string    `json:"contentType"` // Sniffed from the content, not taken from the client
+	Checksum    string    `json:"checksum"`    // Hex encoded SHA-256 of the content
+	StorageKey  string    `json:"-"`
+	UploaderID  int       `json:"uploaderId"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type AttachmentRepository interface {
+	Create(attachment *Attachment) error
+	FindByID(id int) (*Attachment, error)
+	FindByTaskID(taskID int) ([]Attachment, error)
+	Delete(id int) error
+}
+
+// BlobStore keeps file contents by key.
+type BlobStore interface {
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+	// Get streams length bytes starting at offset; a negative length reads to the end.
+	Get(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidBoardColumns = errors.New("board columns need distinct, non-empty names")
+	ErrUnknownColumn       = errors.New("column is not part of the project's board")
+	ErrNoBoard             = errors.New("only tasks of a project can be placed on a board")
+	ErrInvalidMove         = errors.New("the tasks to move next to must be neighbours in the target column")
+	ErrInvalidRank         = errors.New("lower rank must sort before upper rank")
+)
+
+// BoardGrouping decides how a project's board splits its tasks into columns.
+type BoardGrouping string
+
+const (
+	GroupByStatus BoardGrouping = "status" // One column per task status, the default
+	GroupByCustom BoardGrouping = "custom" // The project's own column set
+)
+
+// BoardColumn is a column of a project's custom column set.
+type BoardColumn struct {
+	ID        int       `json:"id"`
+	ProjectID int       `json:"projectId" gorm:"index"`
+	Name      string    `json:"name"`
+	Position  int       `json:"position"` // Columns are shown by ascending position, starting at 1
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ValidateBoardColumns checks a column set before it replaces the current one.
+func ValidateBoardColumns(columns []BoardColumn) error {
+	seen := map[string]bool{}
+	for i := range columns {
+		columns[i].Name = strings.TrimSpace(columns[i].Name)
+		name := strings.ToLower(columns[i].Name)
+		if name == "" || seen[name] {
+			return ErrInvalidBoardColumns
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// BoardTarget is a column of a board as a place tasks can be moved to. Exactly one of
+// Status and ColumnID is set. The first custom column also holds the tasks not placed yet.
+type BoardTarget struct {
+	Status   TaskStatus
+	ColumnID int
+	Default  bool
+}
+
+// Filter selects the tasks of the project that belong to the column, in board order.
+func (t BoardTarget) Filter(projectID int) TaskFilter {
+	filter := TaskFilter{ProjectID: projectID, Sort: []TaskSort{{Field: SortByRank}}}
+	if t.Status != "" {
+		filter.Status = t.Status
+	} else {
+		filter.ColumnIDs = []int{t.ColumnID}
+		if t.Default {
+			filter.ColumnIDs = append(filter.ColumnIDs, 0)
+		}
+	}
+	return filter
+}
+
+// BoardLane is one column of a board with its tasks in manual order.
+type BoardLane struct {
+	Key      string         `json:"key"` // Status, or ID of the custom column
+	Name     string         `json:"name"`
+	Status   TaskStatus     `json:"status,omitempty"`
+	ColumnID int            `json:"columnId,omitempty"`
+	Tasks    TaskConnection `json:"tasks"`
+}
+
+// Board is the kanban view of a project.
+type Board struct {
+	ProjectID int           `json:"projectId"`
+	GroupBy   BoardGrouping `json:"groupBy"`
+	Columns   []BoardLane   `json:"columns"`
+}
+
+// TaskRank is the position of a task within its board column.
+type TaskRank struct {
+	ID   int
+	Rank string
+}
+
+// rankDigits are the digits of ranks. Ranks compare as plain strings and never end in the
+// lowest digit, so there is always room for another rank between two of them.
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// RankBetween returns a rank sorting after lower and before upper. An empty lower means
+// the start of the column and an empty upper its end.
+func RankBetween(lower, upper string) (string, error) {
+	if upper != "" && lower >= upper {
+		return "", ErrInvalidRank
+	}
+	return midRank(lower, upper), nil
+}
+
+func midRank(lower, upper string) string {
+	if upper != "" {
+		// Keep the common prefix and look for room after it
+		n := 0
+		for n < len(upper) && rankDigit(lower, n) == rankDigit(upper, n) {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(lower) {
+				rest = lower[n:]
+			}
+			return upper[:n] + midRank(rest, upper[n:])
+		}
+	}
+	low, high := rankDigit(lower, 0), len(rankDigits)
+	if upper != "" {
+		high = rankDigit(upper, 0)
+	}
+	if high-low > 1 {
+		return string(rankDigits[(low+high)/2])
+	}
+	if len(upper) > 1 {
+		return upper[:1]
+	}
+	rest := ""
+	if len(lower) > 1 {
+		rest = lower[1:]
+	}
+	return string(rankDigits[low]) + midRank(rest, "")
+}
+
+func rankDigit(rank string, i int) int {
+	if i >= len(rank) {
+		return 0
+	}
+	return strings.IndexByte(rankDigits, rank[i])
+}
+
+// SpreadRanks returns n increasing, evenly spaced ranks with room for plenty of moves
+// in between, used to renumber a column whose ranks are missing or out of order.
+func SpreadRanks(n int) []string {
+	width, capacity := 1, len(rankDigits)
+	for capacity <= n*len(rankDigits) {
+		width++
+		capacity *= len(rankDigits)
+	}
+	step := capacity / (n + 1)
+	ranks := make([]string, n)
+	for i := range ranks {
+		rank := strconv.FormatInt(int64((i+1)*step), len(rankDigits))
+		rank = strings.Repeat("0", width-len(rank)) + rank
+		ranks[i] = strings.TrimRight(rank, "0")
+	}
+	return ranks
+}
+
+// BoardRepository stores the custom column sets of project boards.
+type BoardRepository interface {
+	FindColumns(projectID int) ([]BoardColumn, error) // In position order
+	// ReplaceColumns makes columns the project's column set. Columns without an ID are
+	// created, missing ones are deleted and their tasks go back to the first column, which
+	// is recorded in their activity on behalf of actor.
+	ReplaceColumns(projectID int, columns []BoardColumn, actor Actor) error
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrEmptyChecklistItem = errors.New("checklist item text is required")
+	ErrChecklistOrder     = errors.New("the new order must list every checklist item of the task exactly once")
+)
+
+// ChecklistItem is a step inside a task too small to be a subtask of its own.
+type ChecklistItem struct {
+	ID        int        `json:"id"`
+	TaskID    int        `json:"taskId" gorm:"index"`
+	Text      string     `json:"text"`
+	Checked   bool       `json:"checked"`
+	Position  int        `json:"position"` // Items are listed by ascending position, starting at 1
+	CheckedBy int        `json:"checkedBy,omitempty"`
+	CheckedAt *time.Time `json:"checkedAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+// Toggle checks an unchecked item or unchecks a checked one, keeping who checked it and when.
+func (i *ChecklistItem) Toggle(userID int, now time.Time) {
+	i.Checked = !i.Checked
+	if i.Checked {
+		at := now.UTC()
+		i.CheckedBy, i.CheckedAt = userID, &at
+	} else {
+		i.CheckedBy, i.CheckedAt = 0, nil
+	}
+}
+
+// ChecklistProgress counts the checked items of a checklist, shown as "done of total".
+type ChecklistProgress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+type ChecklistRepository interface {
+	Create(item *ChecklistItem) error // Appends the item at the end of the task's checklist
+	FindByID(id int) (*ChecklistItem, error)
+	FindByTask(taskID int) ([]ChecklistItem, error)
+	Update(item *ChecklistItem) error
+	Delete(id int) error
+	// Reorder gives the items the positions of their IDs in itemIDs.
+	Reorder(taskID int, itemIDs []int) error
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEmptyComment     = errors.New("comment body is required")
+	ErrCommentForbidden = errors.New("only the comment author or the task owner can change this comment")
+	ErrCommentParent    = errors.New("a reply must belong to the same task as the comment it answers")
+	mentionPattern      = regexp.MustCompile(`(?:^|[^\w@])@([A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,})`)
+)
+
+// Comment is a message on a task. Replies point to a top-level comment through ParentID;
+// answering a reply joins the same thread, so threads are one level deep.
+type Comment struct {
+	ID        int              `json:"id"`
+	TaskID    int              `json:"taskId"`
+	AuthorID  int              `json:"authorId"`
+	ParentID  int              `json:"parentId,omitempty"`
+	Body      string           `json:"body"`
+	EditedAt  *time.Time       `json:"editedAt,omitempty"`
+	Mentions  []CommentMention `json:"mentions"`
+	Replies   []Comment        `json:"replies,omitempty" gorm:"-"`
+	CreatedAt time.Time        `json:"createdAt"`
+	UpdatedAt time.Time        `json:"updatedAt"`
+}
+
+// CommentMention is a user mentioned as @email in a comment body.
+type CommentMention struct {
+	ID        int    `json:"-"`
+	CommentID int    `json:"-"`
+	UserID    int    `json:"userId"`
+	Email     string `json:"email"`
+}
+
+// CommentRevision keeps the body a comment had before an edit.
+type CommentRevision struct {
+	ID        int       `json:"id"`
+	CommentID int       `json:"commentId"`
+	Body      string    `json:"body"`
+	EditedBy  int       `json:"editedBy"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// CanBeChangedBy reports whether the user may edit or delete the comment: its author or the
+// owner of the task it belongs to.
+func (c *Comment) CanBeChangedBy(userID int, task *Task) bool {
+	return userID != 0 && (userID == c.AuthorID || userID == task.UserID)
+}
+
+// ParseMentions returns the distinct emails mentioned as @email in a comment body, lowercased.
+func ParseMentions(body string) []string {
+	var emails []string
+	seen := map[string]bool{}
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		email := strings.ToLower(strings.TrimRight(match[1], "."))
+		if !seen[email] {
+			seen[email] = true
+			emails = append(emails, email)
+		}
+	}
+	return emails
+}
+
+type CommentFilter struct {
+	TaskID int `json:"taskId"`
+	Page   int `json:"page"`
+	Limit  int `json:"limit"`
+}
+
+type CommentEdge struct {
+	Node Comment `json:"node"`
+}
+
+type CommentConnection struct {
+	Edges    []CommentEdge `json:"edges"`
+	PageInfo struct {
+		HasNextPage     bool `json:"hasNextPage"`
+		HasPreviousPage bool `json:"hasPreviousPage"`
+		TotalCount      int  `json:"totalCount"`
+	} `json:"pageInfo"`
+}
+
+type CommentRepository interface {
+	Create(comment *Comment) error
+	FindByID(id int) (*Comment, error)
+	FindByTask(filter CommentFilter) (*CommentConnection, error) // Top-level comments, oldest first
+	FindReplies(parentIDs []int) ([]Comment, error)
+	Update(comment *Comment, revision *CommentRevision) error
+	Delete(id int) error
+	FindRevisions(commentID int) ([]CommentRevision, error)
+}
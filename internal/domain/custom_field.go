@@ -0,0 +1,216 @@
+package domain
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CustomFieldType decides what values a custom field holds and which part of CustomFieldValue carries them.
+type CustomFieldType string
+
+const (
+	FieldText        CustomFieldType = "text"         // Text
+	FieldNumber      CustomFieldType = "number"       // Number
+	FieldDate        CustomFieldType = "date"         // Date, a calendar day stored as midnight UTC
+	FieldSelect      CustomFieldType = "select"       // Text, one of the field's options
+	FieldMultiSelect CustomFieldType = "multi_select" // Options, any of the field's options
+	FieldUser        CustomFieldType = "user"         // UserID
+	FieldCheckbox    CustomFieldType = "checkbox"     // Checked
+)
+
+var CustomFieldTypes = []CustomFieldType{FieldText, FieldNumber, FieldDate, FieldSelect, FieldMultiSelect, FieldUser, FieldCheckbox}
+
+var (
+	ErrInvalidCustomField   = errors.New("custom field name is required and its type must be text, number, date, select, multi_select, user or checkbox")
+	ErrCustomFieldOptions   = errors.New("select fields need distinct, non-empty options")
+	ErrDuplicateCustomField = errors.New("a custom field with this name already exists in the workspace")
+	ErrCustomFieldWorkspace = errors.New("custom field and task belong to different workspaces")
+	ErrInvalidFieldValue    = errors.New("value does not match the custom field type or options")
+	ErrInvalidFieldFilter   = errors.New("invalid custom field filter")
+)
+
+func (t CustomFieldType) IsValid() bool {
+	for _, valid := range CustomFieldTypes {
+		if t == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// CustomField is a piece of metadata a workspace tracks on its tasks, like story points or customer.
+type CustomField struct {
+	ID          int             `json:"id"`
+	WorkspaceID int             `json:"workspaceId" gorm:"index"`
+	Name        string          `json:"name"`
+	Type        CustomFieldType `json:"type"`
+	Options     []string        `json:"options,omitempty" gorm:"serializer:json"` // Choices of select and multi-select fields
+	CreatedAt   time.Time       `json:"createdAt"`
+	UpdatedAt   time.Time       `json:"updatedAt"`
+}
+
+func (f *CustomField) Validate() error {
+	f.Name = strings.TrimSpace(f.Name)
+	if f.Name == "" || !f.Type.IsValid() {
+		return ErrInvalidCustomField
+	}
+	if f.Type != FieldSelect && f.Type != FieldMultiSelect {
+		f.Options = nil
+		return nil
+	}
+	if len(f.Options) == 0 {
+		return ErrCustomFieldOptions
+	}
+	seen := map[string]bool{}
+	for _, option := range f.Options {
+		if strings.TrimSpace(option) == "" || seen[option] {
+			return ErrCustomFieldOptions
+		}
+		seen[option] = true
+	}
+	return nil
+}
+
+func (f *CustomField) hasOption(option string) bool {
+	for _, o := range f.Options {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// CustomFieldValue is the value of a custom field on a task. Only the part matching the
+// field type is set; the type is copied from the field so stored values describe themselves.
+type CustomFieldValue struct {
+	TaskID  int             `json:"-" gorm:"primaryKey;autoIncrement:false"`
+	FieldID int             `json:"fieldId" gorm:"primaryKey;autoIncrement:false;index"`
+	Type    CustomFieldType `json:"type"`
+	Text    string          `json:"text,omitempty"`
+	Number  *float64        `json:"number,omitempty"`
+	Date    *time.Time      `json:"date,omitempty"`
+	Options []string        `json:"options,omitempty" gorm:"serializer:json"`
+	UserID  int             `json:"userId,omitempty"`
+	Checked bool            `json:"checked,omitempty"`
+}
+
+// Normalize checks a value against its field and keeps only the part the field type uses.
+// Whether a user value points to an existing user is left to the caller.
+func (f *CustomField) Normalize(value CustomFieldValue) (CustomFieldValue, error) {
+	normalized := CustomFieldValue{FieldID: f.ID, Type: f.Type}
+	switch f.Type {
+	case FieldText:
+		normalized.Text = value.Text
+	case FieldNumber:
+		if value.Number == nil {
+			return normalized, ErrInvalidFieldValue
+		}
+		normalized.Number = value.Number
+	case FieldDate:
+		if value.Date == nil {
+			return normalized, ErrInvalidFieldValue
+		}
+		day := truncateToDay(*value.Date)
+		normalized.Date = &day
+	case FieldSelect:
+		if !f.hasOption(value.Text) {
+			return normalized, ErrInvalidFieldValue
+		}
+		normalized.Text = value.Text
+	case FieldMultiSelect:
+		seen := map[string]bool{}
+		for _, option := range value.Options {
+			if !f.hasOption(option) {
+				return normalized, ErrInvalidFieldValue
+			}
+			if !seen[option] {
+				seen[option] = true
+				normalized.Options = append(normalized.Options, option)
+			}
+		}
+	case FieldUser:
+		if value.UserID == 0 {
+			return normalized, ErrInvalidFieldValue
+		}
+		normalized.UserID = value.UserID
+	case FieldCheckbox:
+		normalized.Checked = value.Checked
+	}
+	return normalized, nil
+}
+
+// CustomFieldFilter keeps the tasks whose value of a field equals Equals and lies between
+// Min and Max, all given as text: numbers, dates as YYYY-MM-DD, user IDs or true/false.
+// For multi-select fields Equals matches tasks having that option among others.
+type CustomFieldFilter struct {
+	FieldID int    `json:"fieldId"`
+	Equals  string `json:"equals,omitempty"`
+	Min     string `json:"min,omitempty"`
+	Max     string `json:"max,omitempty"`
+}
+
+// ParseFilterValue converts a filter bound to the type stored for the field.
+func (f *CustomField) ParseFilterValue(raw string) (interface{}, error) {
+	switch f.Type {
+	case FieldNumber:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, ErrInvalidFieldFilter
+		}
+		return n, nil
+	case FieldDate:
+		day, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return nil, ErrInvalidFieldFilter
+		}
+		return day, nil
+	case FieldUser:
+		id, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, ErrInvalidFieldFilter
+		}
+		return id, nil
+	case FieldCheckbox:
+		checked, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, ErrInvalidFieldFilter
+		}
+		return checked, nil
+	default:
+		return raw, nil
+	}
+}
+
+// ValueColumn is the column of the custom_field_values table holding the values of the field.
+func (f *CustomField) ValueColumn() string {
+	switch f.Type {
+	case FieldNumber:
+		return "number"
+	case FieldDate:
+		return "date"
+	case FieldMultiSelect:
+		return "options"
+	case FieldUser:
+		return "user_id"
+	case FieldCheckbox:
+		return "checked"
+	default:
+		return "text"
+	}
+}
+
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+type CustomFieldRepository interface {
+	Create(field *CustomField) error
+	FindByID(id int) (*CustomField, error)
+	FindByWorkspaceID(workspaceID int) ([]CustomField, error)
+	Update(field *CustomField) error
+	Delete(id int) error // Removes the values of the field too
+	SetValue(taskID int, value CustomFieldValue) error
+	ClearValue(taskID, fieldID int) error
+}
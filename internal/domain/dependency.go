@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+var (
+	ErrSelfDependency  = errors.New("a task cannot be blocked by itself")
+	ErrDependencyCycle = errors.New("dependency would create a cycle")
+)
+
+// TaskDependency records that TaskID cannot be completed before BlockedByID.
+type TaskDependency struct {
+	ID          int       `json:"id"`
+	TaskID      int       `json:"taskId" gorm:"uniqueIndex:idx_task_dependency"`
+	BlockedByID int       `json:"blockedById" gorm:"uniqueIndex:idx_task_dependency"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// BlockedError is returned when a task is completed while some of its blockers are still open.
+type BlockedError struct {
+	BlockerIDs []int
+}
+
+func (e *BlockedError) Error() string {
+	return fmt.Sprintf("task is blocked by open tasks %v", e.BlockerIDs)
+}
+
+type DependencyRepository interface {
+	Create(dependency *TaskDependency) error
+	Delete(taskID, blockedByID int) error
+	FindByTaskIDs(taskIDs []int) ([]TaskDependency, error)
+}
+
+// DependencyNode is the schedule of one task in a dependency graph. Times assume work on
+// open tasks starts now and each task takes its estimate once all its blockers are finished.
+type DependencyNode struct {
+	Task           Task      `json:"task"`
+	EarliestStart  time.Time `json:"earliestStart"`
+	EarliestFinish time.Time `json:"earliestFinish"`
+	SlackMinutes   *int      `json:"slackMinutes,omitempty"` // Time left before the due date, negative when late
+	Critical       bool      `json:"critical"`
+}
+
+type DependencyGraph struct {
+	Nodes        []DependencyNode `json:"nodes"`
+	Edges        []TaskDependency `json:"edges"`
+	CriticalPath []Task           `json:"criticalPath"`
+}
+
+// BuildDependencyGraph schedules the tasks and finds the critical path: the chain of
+// dependencies that finishes last. Ties are broken by the least slack against due dates.
+// Edges pointing outside the given tasks are ignored.
+func BuildDependencyGraph(tasks []Task, dependencies []TaskDependency, now time.Time) *DependencyGraph {
+	index := make(map[int]int, len(tasks))
+	for i, task := range tasks {
+		index[task.ID] = i
+	}
+
+	graph := &DependencyGraph{Nodes: make([]DependencyNode, len(tasks)), Edges: []TaskDependency{}, CriticalPath: []Task{}}
+	blockers := make(map[int][]int)
+	dependents := make(map[int][]int)
+	for _, dep := range dependencies {
+		_, okTask := index[dep.TaskID]
+		_, okBlocker := index[dep.BlockedByID]
+		if !okTask || !okBlocker {
+			continue
+		}
+		graph.Edges = append(graph.Edges, dep)
+		blockers[dep.TaskID] = append(blockers[dep.TaskID], dep.BlockedByID)
+		dependents[dep.BlockedByID] = append(dependents[dep.BlockedByID], dep.TaskID)
+	}
+
+	// Kahn's algorithm, visiting tasks by ID so the result is deterministic
+	pending := make(map[int]int, len(tasks))
+	var ready []int
+	for _, task := range tasks {
+		pending[task.ID] = len(blockers[task.ID])
+		if pending[task.ID] == 0 {
+			ready = append(ready, task.ID)
+		}
+	}
+	sort.Ints(ready)
+
+	finish := make(map[int]time.Time, len(tasks))
+	previous := make(map[int]int, len(tasks))
+	for len(ready) > 0 {
+		id := ready[0]
+		ready = ready[1:]
+		task := tasks[index[id]]
+
+		start := now
+		for _, blockerID := range blockers[id] {
+			if finish[blockerID].After(start) || (finish[blockerID].Equal(start) && previous[id] == 0) {
+				start = finish[blockerID]
+				previous[id] = blockerID
+			}
+		}
+		end := start.Add(task.RemainingDuration())
+		finish[id] = end
+
+		node := DependencyNode{Task: task, EarliestStart: start, EarliestFinish: end}
+		if task.DueAt != nil {
+			slack := int(task.DueAt.Sub(end).Minutes())
+			node.SlackMinutes = &slack
+		}
+		graph.Nodes[index[id]] = node
+
+		next := dependents[id]
+		sort.Ints(next)
+		for _, dependentID := range next {
+			pending[dependentID]--
+			if pending[dependentID] == 0 {
+				ready = append(ready, dependentID)
+			}
+		}
+	}
+
+	// Pick the task that finishes last and walk back through the blockers that delayed it
+	last := 0
+	for _, task := range tasks {
+		end, scheduled := finish[task.ID]
+		if !scheduled {
+			continue
+		}
+		if last == 0 || end.After(finish[last]) || (end.Equal(finish[last]) && lessSlack(graph.Nodes[index[task.ID]], graph.Nodes[index[last]])) {
+			last = task.ID
+		}
+	}
+	for id := last; id != 0; id = previous[id] {
+		graph.Nodes[index[id]].Critical = true
+		graph.CriticalPath = append([]Task{tasks[index[id]]}, graph.CriticalPath...)
+	}
+	return graph
+}
+
+func lessSlack(a, b DependencyNode) bool {
+	if a.SlackMinutes == nil {
+		return false
+	}
+	return b.SlackMinutes == nil || *a.SlackMinutes < *b.SlackMinutes
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+var (
+	ErrInvalidLabel   = errors.New("label name is required and color must be a hex value like #1e90ff")
+	ErrDuplicateLabel = errors.New("a label with this name already exists in the workspace")
+	ErrLabelWorkspace = errors.New("label and task belong to different workspaces")
+	hexColorPattern   = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+)
+
+type Label struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Color       string    `json:"color"`
+	WorkspaceID int       `json:"workspaceId"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+func (l *Label) Validate() error {
+	if l.Name == "" || (l.Color != "" && !hexColorPattern.MatchString(l.Color)) {
+		return ErrInvalidLabel
+	}
+	return nil
+}
+
+type LabelRepository interface {
+	Create(label *Label) error
+	FindByID(id int) (*Label, error)
+	FindByWorkspaceID(workspaceID int) ([]Label, error)
+	Update(label *Label) error
+	Delete(id int) error
+	AddToTask(taskID, labelID int) error
+	RemoveFromTask(taskID, labelID int) error
+}
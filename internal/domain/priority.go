@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// TaskPriority is stored as an integer so that tasks sort by urgency, and travels as a name in JSON.
+type TaskPriority int
+
+const (
+	PriorityNone TaskPriority = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+	PriorityUrgent
+)
+
+var ErrUnknownPriority = errors.New("unknown task priority")
+
+var priorityNames = []string{"none", "low", "medium", "high", "urgent"}
+
+func (p TaskPriority) IsValid() bool {
+	return p >= PriorityNone && p <= PriorityUrgent
+}
+
+func (p TaskPriority) String() string {
+	if !p.IsValid() {
+		return "unknown"
+	}
+	return priorityNames[p]
+}
+
+// ParsePriority converts a priority name such as "high" into a TaskPriority.
+func ParsePriority(name string) (TaskPriority, error) {
+	for i, n := range priorityNames {
+		if strings.EqualFold(name, n) {
+			return TaskPriority(i), nil
+		}
+	}
+	return PriorityNone, ErrUnknownPriority
+}
+
+func (p TaskPriority) MarshalText() ([]byte, error) {
+	if !p.IsValid() {
+		return nil, ErrUnknownPriority
+	}
+	return []byte(p.String()), nil
+}
+
+func (p *TaskPriority) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*p = PriorityNone
+		return nil
+	}
+	priority, err := ParsePriority(string(text))
+	if err != nil {
+		return err
+	}
+	*p = priority
+	return nil
+}
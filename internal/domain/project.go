@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidProject  = errors.New("project name is required and color must be a hex value like #1e90ff")
+	ErrProjectArchived = errors.New("project is archived")
+	ErrProjectScope    = errors.New("a task must be in the same workspace as its project; move it out of the project first")
+)
+
+// Project groups tasks; tasks without a project have ProjectID 0.
+type Project struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Color       string    `json:"color"`
+	Archived    bool      `json:"archived"`
+	OwnerID     int       `json:"ownerId"`
+	WorkspaceID int       `json:"workspaceId,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+func (p *Project) Validate() error {
+	if p.Name == "" || (p.Color != "" && !hexColorPattern.MatchString(p.Color)) {
+		return ErrInvalidProject
+	}
+	return nil
+}
+
+type ProjectFilter struct {
+	OwnerID         int  `json:"ownerId"`
+	WorkspaceID     int  `json:"workspaceId"`
+	IncludeArchived bool `json:"includeArchived"`
+}
+
+type ProjectRepository interface {
+	Create(project *Project) error
+	FindByID(id int) (*Project, error)
+	FindAll(filter ProjectFilter) ([]Project, error)
+	Update(project *Project) error
+	Delete(id int, actor Actor) error
+	MoveTasks(taskIDs []int, projectID int, actor Actor) error
+	// HasTasks reports whether any task is in the project, archived and trashed ones included.
+	HasTasks(id int) (bool, error)
+}
@@ -0,0 +1,425 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidRecurrence = errors.New("invalid recurrence rule")
+	ErrRecurrenceAnchor  = errors.New("a recurring task needs a due or start date")
+	ErrSeriesEnded       = errors.New("the recurring series has no further occurrences")
+	ErrNotRecurring      = errors.New("task is not recurring")
+)
+
+// maxRecurrencePeriods bounds the search for occurrences of rules that rarely or never match,
+// such as the 30th of February.
+const maxRecurrencePeriods = 5000
+
+type Frequency string
+
+const (
+	FreqDaily   Frequency = "DAILY"
+	FreqWeekly  Frequency = "WEEKLY"
+	FreqMonthly Frequency = "MONTHLY"
+	FreqYearly  Frequency = "YEARLY"
+)
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// RecurrenceDay is a BYDAY entry. N selects the nth weekday of the month (negative counts
+// from the end) and is only allowed for monthly and yearly rules; 0 means every such weekday.
+type RecurrenceDay struct {
+	N       int
+	Weekday time.Weekday
+}
+
+// Recurrence is the subset of RFC 5545 RRULE supported for tasks: FREQ, INTERVAL, BYDAY,
+// BYMONTHDAY, COUNT and UNTIL. Weeks start on Monday and yearly rules repeat in the month
+// of the first occurrence.
+type Recurrence struct {
+	Freq       Frequency
+	Interval   int
+	ByDay      []RecurrenceDay
+	ByMonthDay []int
+	Count      int
+	Until      *time.Time
+	untilDate  bool // UNTIL was a date, inclusive in the task's timezone
+}
+
+// ParseRecurrence parses a rule such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10".
+func ParseRecurrence(rule string) (*Recurrence, error) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	r := &Recurrence{Interval: 1}
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRecurrence, part)
+		}
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			r.Freq = Frequency(strings.ToUpper(value))
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+		case "UNTIL":
+			err = r.parseUntil(value)
+		case "BYDAY":
+			r.ByDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseByMonthDay(value)
+		default:
+			return nil, fmt.Errorf("%w: unsupported part %s", ErrInvalidRecurrence, key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: bad %s", ErrInvalidRecurrence, key)
+		}
+	}
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Recurrence) parseUntil(value string) error {
+	if until, err := time.Parse("20060102T150405Z", value); err == nil {
+		r.Until = &until
+		return nil
+	}
+	until, err := time.Parse("20060102", value)
+	if err != nil {
+		return err
+	}
+	r.Until = &until
+	r.untilDate = true
+	return nil
+}
+
+func parseByDay(value string) ([]RecurrenceDay, error) {
+	var days []RecurrenceDay
+	for _, code := range strings.Split(strings.ToUpper(value), ",") {
+		if len(code) < 2 {
+			return nil, ErrInvalidRecurrence
+		}
+		weekday, ok := weekdayCodes[code[len(code)-2:]]
+		if !ok {
+			return nil, ErrInvalidRecurrence
+		}
+		day := RecurrenceDay{Weekday: weekday}
+		if prefix := code[:len(code)-2]; prefix != "" {
+			n, err := strconv.Atoi(prefix)
+			if err != nil || n == 0 || n < -5 || n > 5 {
+				return nil, ErrInvalidRecurrence
+			}
+			day.N = n
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+func parseByMonthDay(value string) ([]int, error) {
+	var days []int
+	for _, part := range strings.Split(value, ",") {
+		day, err := strconv.Atoi(part)
+		if err != nil || day == 0 || day < -31 || day > 31 {
+			return nil, ErrInvalidRecurrence
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+func (r *Recurrence) validate() error {
+	switch r.Freq {
+	case FreqDaily, FreqWeekly, FreqMonthly, FreqYearly:
+	default:
+		return fmt.Errorf("%w: FREQ must be DAILY, WEEKLY, MONTHLY or YEARLY", ErrInvalidRecurrence)
+	}
+	if r.Interval < 1 || r.Count < 0 {
+		return fmt.Errorf("%w: INTERVAL and COUNT must be positive", ErrInvalidRecurrence)
+	}
+	if r.Count > 0 && r.Until != nil {
+		return fmt.Errorf("%w: COUNT and UNTIL cannot be combined", ErrInvalidRecurrence)
+	}
+	for _, day := range r.ByDay {
+		if day.N != 0 && r.Freq != FreqMonthly && r.Freq != FreqYearly {
+			return fmt.Errorf("%w: numbered BYDAY needs a monthly or yearly rule", ErrInvalidRecurrence)
+		}
+	}
+	return nil
+}
+
+// String formats the rule in canonical RRULE form.
+func (r *Recurrence) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			code := strings.ToUpper(day.Weekday.String()[:2])
+			if day.N != 0 {
+				code = strconv.Itoa(day.N) + code
+			}
+			codes[i] = code
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, day := range r.ByMonthDay {
+			days[i] = strconv.Itoa(day)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		if r.untilDate {
+			parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+		}
+	}
+	return strings.Join(parts, ";")
+}
+
+// After returns up to n occurrences strictly after start, keeping the wall clock time of start
+// in its location. COUNT is left to the caller, which knows how many occurrences already happened.
+func (r *Recurrence) After(start time.Time, n int) []time.Time {
+	until := r.Until
+	if until != nil && r.untilDate {
+		endOfDay := time.Date(until.Year(), until.Month(), until.Day(), 23, 59, 59, 0, start.Location())
+		until = &endOfDay
+	}
+
+	var occurrences []time.Time
+	for period := 0; period < maxRecurrencePeriods && len(occurrences) < n; period++ {
+		for _, candidate := range r.candidates(start, period) {
+			if !candidate.After(start) {
+				continue
+			}
+			if until != nil && candidate.After(*until) {
+				return occurrences
+			}
+			occurrences = append(occurrences, candidate)
+			if len(occurrences) == n {
+				break
+			}
+		}
+	}
+	return occurrences
+}
+
+// candidates lists the occurrences of the nth period counted from the one containing start, in order.
+func (r *Recurrence) candidates(start time.Time, period int) []time.Time {
+	step := period * r.Interval
+	y, m, d := start.Date()
+	var dates []time.Time
+	switch r.Freq {
+	case FreqDaily:
+		day := time.Date(y, m, d+step, 0, 0, 0, 0, time.UTC)
+		if r.matchesWeekday(day) && r.matchesMonthDay(day) {
+			dates = append(dates, day)
+		}
+	case FreqWeekly:
+		monday := time.Date(y, m, d-(int(start.Weekday())+6)%7+7*step, 0, 0, 0, 0, time.UTC)
+		weekdays := []time.Weekday{start.Weekday()}
+		if len(r.ByDay) > 0 {
+			weekdays = weekdays[:0]
+			for _, day := range r.ByDay {
+				weekdays = append(weekdays, day.Weekday)
+			}
+		}
+		for _, weekday := range weekdays {
+			day := monday.AddDate(0, 0, (int(weekday)+6)%7)
+			if r.matchesMonthDay(day) {
+				dates = append(dates, day)
+			}
+		}
+	case FreqMonthly:
+		dates = r.monthDays(time.Date(y, m+time.Month(step), 1, 0, 0, 0, 0, time.UTC), d)
+	case FreqYearly:
+		dates = r.monthDays(time.Date(y+step, m, 1, 0, 0, 0, 0, time.UTC), d)
+	}
+
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	occurrences := make([]time.Time, 0, len(dates))
+	for i, date := range dates {
+		if i > 0 && date.Equal(dates[i-1]) {
+			continue
+		}
+		occurrences = append(occurrences, time.Date(date.Year(), date.Month(), date.Day(),
+			start.Hour(), start.Minute(), start.Second(), 0, start.Location()))
+	}
+	return occurrences
+}
+
+// monthDays expands BYMONTHDAY and BYDAY within the month starting at first, defaulting to
+// the day of the month of the first occurrence. Days that do not exist in the month are skipped.
+func (r *Recurrence) monthDays(first time.Time, anchorDay int) []time.Time {
+	last := first.AddDate(0, 1, -1).Day()
+	var dates []time.Time
+	switch {
+	case len(r.ByMonthDay) > 0:
+		for _, day := range r.ByMonthDay {
+			if day < 0 {
+				day = last + 1 + day
+			}
+			date := first.AddDate(0, 0, day-1)
+			if day >= 1 && day <= last && r.matchesWeekday(date) {
+				dates = append(dates, date)
+			}
+		}
+	case len(r.ByDay) > 0:
+		for _, spec := range r.ByDay {
+			var matches []time.Time
+			for day := 0; day < last; day++ {
+				if date := first.AddDate(0, 0, day); date.Weekday() == spec.Weekday {
+					matches = append(matches, date)
+				}
+			}
+			switch {
+			case spec.N == 0:
+				dates = append(dates, matches...)
+			case spec.N > 0 && spec.N <= len(matches):
+				dates = append(dates, matches[spec.N-1])
+			case spec.N < 0 && -spec.N <= len(matches):
+				dates = append(dates, matches[len(matches)+spec.N])
+			}
+		}
+	case anchorDay <= last:
+		dates = append(dates, first.AddDate(0, 0, anchorDay-1))
+	}
+	return dates
+}
+
+func (r *Recurrence) matchesWeekday(date time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, day := range r.ByDay {
+		if day.Weekday == date.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Recurrence) matchesMonthDay(date time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	last := date.AddDate(0, 1, -date.Day()).Day()
+	for _, day := range r.ByMonthDay {
+		if day == date.Day() || last+1+day == date.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+// RecurrenceAnchor is the date a task's occurrences are computed from: its due date, or its
+// start date when it has no due date.
+func (t *Task) RecurrenceAnchor() *time.Time {
+	if t.DueAt != nil {
+		return t.DueAt
+	}
+	return t.StartAt
+}
+
+// ValidateRecurrence checks the task's rule and stores it in canonical form.
+func (t *Task) ValidateRecurrence() error {
+	if t.Recurrence == "" {
+		return nil
+	}
+	rule, err := ParseRecurrence(t.Recurrence)
+	if err != nil {
+		return err
+	}
+	if t.RecurrenceAnchor() == nil {
+		return ErrRecurrenceAnchor
+	}
+	t.Recurrence = rule.String()
+	if t.Occurrence == 0 {
+		t.Occurrence = 1
+	}
+	return nil
+}
+
+// UpcomingOccurrences returns up to n dates after the current occurrence, in the task's timezone.
+func (t *Task) UpcomingOccurrences(n int) ([]time.Time, error) {
+	if t.Recurrence == "" || n <= 0 {
+		return []time.Time{}, nil
+	}
+	rule, err := ParseRecurrence(t.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+	anchor := t.RecurrenceAnchor()
+	if anchor == nil {
+		return nil, ErrRecurrenceAnchor
+	}
+	if rule.Count > 0 {
+		remaining := rule.Count - max(t.Occurrence, 1)
+		n = min(n, max(remaining, 0))
+	}
+	return rule.After(anchor.In(t.Location()), n), nil
+}
+
+// NextOccurrence builds the open task for the occurrence after this one, or returns
+// ErrSeriesEnded when the rule has no further dates.
+func (t *Task) NextOccurrence() (*Task, error) {
+	dates, err := t.UpcomingOccurrences(1)
+	if err != nil {
+		return nil, err
+	}
+	if len(dates) == 0 {
+		return nil, ErrSeriesEnded
+	}
+	shift := dates[0].Sub(*t.RecurrenceAnchor())
+
+	next := &Task{
+		Title:           t.Title,
+		Description:     t.Description,
+		Status:          StatusTodo,
+		Priority:        t.Priority,
+		UserID:          t.UserID,
+		WorkspaceID:     t.WorkspaceID,
+		ProjectID:       t.ProjectID,
+		ParentID:        t.ParentID,
+		Timezone:        t.Timezone,
+		EstimateMinutes: t.EstimateMinutes,
+		Recurrence:      t.Recurrence,
+		SeriesID:        t.SeriesID,
+		Occurrence:      max(t.Occurrence, 1) + 1,
+	}
+	if next.SeriesID == 0 {
+		next.SeriesID = t.ID
+	}
+	if t.DueAt != nil {
+		due := dates[0].UTC()
+		next.DueAt = &due
+	}
+	if t.StartAt != nil {
+		// Keep the same lead time between start and due dates
+		start := t.StartAt.Add(shift).UTC()
+		if t.DueAt == nil {
+			start = dates[0].UTC()
+		}
+		next.StartAt = &start
+	}
+	return next, nil
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used; all sessions started from the same login have been revoked")
+)
+
+// RefreshToken is the server-side record of an opaque refresh token. Every use rotates it:
+// the token is marked used and a successor in the same family is issued. A family is the
+// chain of tokens started by one login, so presenting a used token again, which means it
+// leaked, revokes the whole family.
+type RefreshToken struct {
+	ID        int        `json:"id"`
+	UserID    int        `json:"userId" gorm:"index"`
+	FamilyID  string     `json:"familyId" gorm:"index"`
+	TokenHash string     `json:"-" gorm:"uniqueIndex"` // SHA-256 of the token; the token itself is never stored
+	ExpiresAt time.Time  `json:"expiresAt" gorm:"index"`
+	UsedAt    *time.Time `json:"usedAt,omitempty"`
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// Usable reports whether the token can still be exchanged, ignoring reuse.
+func (t *RefreshToken) Usable(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// TokenPair is what a login or a refresh hands out: a short-lived access token and the
+// refresh token to get the next one with.
+type TokenPair struct {
+	AccessToken  string `json:"token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"` // Seconds until the access token expires
+	RefreshToken string `json:"refresh_token"`
+}
+
+type RefreshTokenRepository interface {
+	Create(token *RefreshToken) error
+	FindByHash(hash string) (*RefreshToken, error)
+	// Rotate marks used as used and stores next in one transaction. It fails with
+	// ErrRefreshTokenReused if used was used or revoked in the meantime.
+	Rotate(used, next *RefreshToken) error
+	RevokeFamily(familyID string) error
+	RevokeUser(userID int) error // Revokes every refresh token of the user
+	// DeleteExpiredBefore removes the tokens that expired before the cutoff and returns how many there were.
+	DeleteExpiredBefore(cutoff time.Time) (int, error)
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// RevokedToken is an entry of the access token revocation list. With a JTI it revokes that
+// one token; with a session ID, every token of that session issued up to RevokedAt; with
+// neither, every token of the user issued up to RevokedAt, which is how "log out
+// everywhere" works. An entry is kept until ExpiresAt, after which the tokens it covers
+// are rejected for being expired anyway.
+type RevokedToken struct {
+	ID        int       `json:"id"`
+	JTI       string    `json:"jti,omitempty" gorm:"index"`
+	SessionID string    `json:"sessionId,omitempty" gorm:"index"`
+	UserID    int       `json:"userId" gorm:"index"`
+	RevokedAt time.Time `json:"revokedAt"`
+	ExpiresAt time.Time `json:"expiresAt" gorm:"index"`
+}
+
+type RevocationRepository interface {
+	Create(entry *RevokedToken) error
+	FindActive(now time.Time) ([]RevokedToken, error) // Entries that have not expired by now
+	// DeleteExpiredBefore removes the entries that expired before the cutoff and returns how many there were.
+	DeleteExpiredBefore(cutoff time.Time) (int, error)
+}
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidSprint  = errors.New("sprint name, start and end dates are required and the end must not be before the start")
+	ErrSprintProject  = errors.New("only tasks of the sprint's project can be added to it")
+	ErrNotInSprint    = errors.New("task is not part of the sprint")
+	ErrSprintTimezone = errors.New("invalid sprint timezone")
+)
+
+// Sprint is a timebox of a project, such as a two-week iteration or a milestone, and the
+// tasks planned for it. Start and end are calendar days, both included, stored as midnight UTC.
+type Sprint struct {
+	ID        int       `json:"id"`
+	ProjectID int       `json:"projectId" gorm:"index"`
+	Name      string    `json:"name"`
+	Goal      string    `json:"goal"`
+	StartDate time.Time `json:"startDate"`
+	EndDate   time.Time `json:"endDate"`
+	Timezone  string    `json:"timezone"` // IANA zone the days of the sprint are counted in, UTC when empty
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (s *Sprint) Validate() error {
+	s.Name = strings.TrimSpace(s.Name)
+	if s.Name == "" || s.StartDate.IsZero() || s.EndDate.IsZero() {
+		return ErrInvalidSprint
+	}
+	if s.Timezone != "" {
+		if _, err := time.LoadLocation(s.Timezone); err != nil {
+			return ErrSprintTimezone
+		}
+	}
+	s.StartDate, s.EndDate = truncateToDay(s.StartDate), truncateToDay(s.EndDate)
+	if s.EndDate.Before(s.StartDate) {
+		return ErrInvalidSprint
+	}
+	return nil
+}
+
+// Location returns the sprint's timezone, falling back to UTC when none is set.
+func (s *Sprint) Location() *time.Location {
+	if s.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// BurndownPoint is the state of a sprint at the end of one of its days. Cancelled tasks
+// count as dropped from the scope; completed work is measured by original estimates.
+type BurndownPoint struct {
+	Date             time.Time `json:"date"`             // The day, as midnight UTC
+	ScopeCount       int       `json:"scopeCount"`       // Tasks in the sprint
+	RemainingCount   int       `json:"remainingCount"`   // Tasks still open
+	CompletedCount   int       `json:"completedCount"`   // Tasks done
+	ScopeMinutes     int       `json:"scopeMinutes"`     // Remaining plus completed minutes
+	RemainingMinutes int       `json:"remainingMinutes"` // Remaining estimates of the open tasks
+	CompletedMinutes int       `json:"completedMinutes"` // Original estimates of the done tasks
+}
+
+// Burndown holds the daily series of a sprint, up to today for a sprint still running.
+// Plotting remaining values gives the burndown chart, completed and scope values the burnup.
+type Burndown struct {
+	Sprint Sprint          `json:"sprint"`
+	Points []BurndownPoint `json:"points"`
+}
+
+// SprintBurndown replays the history of the tasks that are or were in the sprint to compute
+// one point per day of the sprint that has started by now. Each point reflects the end of
+// its day in the sprint's timezone, or now for the current day.
+func SprintBurndown(sprint *Sprint, tasks []Task, activities []Activity, now time.Time) *Burndown {
+	history := map[int][]Activity{}
+	for _, activity := range activities {
+		history[activity.TaskID] = append(history[activity.TaskID], activity)
+	}
+	loc := sprint.Location()
+	burndown := &Burndown{Sprint: *sprint, Points: []BurndownPoint{}}
+	for day := sprint.StartDate; !day.After(sprint.EndDate); day = day.AddDate(0, 0, 1) {
+		start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+		if start.After(now) {
+			break
+		}
+		at := start.AddDate(0, 0, 1)
+		if at.After(now) {
+			at = now
+		}
+		point := BurndownPoint{Date: day}
+		for _, task := range tasks {
+			state, ok := task.Rewind(history[task.ID], at)
+			if !ok || state.SprintID != sprint.ID {
+				continue
+			}
+			switch state.CurrentStatus() {
+			case StatusCancelled:
+				continue
+			case StatusDone:
+				point.CompletedCount++
+				point.CompletedMinutes += state.EstimateMinutes
+			default:
+				point.RemainingCount++
+				point.RemainingMinutes += int(state.RemainingDuration() / time.Minute)
+			}
+			point.ScopeCount++
+		}
+		point.ScopeMinutes = point.RemainingMinutes + point.CompletedMinutes
+		burndown.Points = append(burndown.Points, point)
+	}
+	return burndown
+}
+
+// Rewind returns the task as it was at the given time by undoing the changes its activity
+// log recorded after that time. Only status, estimates, project and sprint are restored,
+// the fields reports look at. It reports false if the task did not exist yet.
+func (t Task) Rewind(activities []Activity, at time.Time) (Task, bool) {
+	if t.CreatedAt.After(at) {
+		return t, false
+	}
+	sorted := append([]Activity(nil), activities...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].CreatedAt.Equal(sorted[j].CreatedAt) {
+			return sorted[i].CreatedAt.After(sorted[j].CreatedAt)
+		}
+		return sorted[i].ID > sorted[j].ID
+	})
+	for _, activity := range sorted {
+		if !activity.CreatedAt.After(at) {
+			break
+		}
+		if activity.Action == ActivityCreated {
+			return t, false
+		}
+		for _, change := range activity.Changes {
+			t.revert(change)
+		}
+	}
+	return t, true
+}
+
+func (t *Task) revert(change FieldChange) {
+	switch change.Field {
+	case "status":
+		t.Status = TaskStatus(change.Old)
+		t.IsCompleted = t.Status == StatusDone
+	case "estimateMinutes":
+		t.EstimateMinutes = parseID(change.Old)
+	case "remainingMinutes":
+		t.RemainingMinutes = nil
+		if change.Old != "" {
+			remaining := parseID(change.Old)
+			t.RemainingMinutes = &remaining
+		}
+	case "projectId":
+		t.ProjectID = parseID(change.Old)
+	case "sprintId":
+		t.SprintID = parseID(change.Old)
+	}
+}
+
+type SprintRepository interface {
+	Create(sprint *Sprint) error
+	FindByID(id int) (*Sprint, error)
+	FindByProjectID(projectID int) ([]Sprint, error) // By start date
+	Update(sprint *Sprint) error
+	Delete(id int, actor Actor) error // Takes its tasks out of the sprint too, on behalf of actor
+	// FindHistory returns the live tasks that are or once were in the sprint, together with
+	// their activity, for replaying how the sprint evolved.
+	FindHistory(sprintID int) ([]Task, []Activity, error)
+}
@@ -1,15 +1,163 @@
 package domain
 
-import "time"
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidSchedule  = errors.New("task start date must not be after its due date")
+	ErrInvalidTimezone  = errors.New("invalid task timezone")
+	ErrTaskCycle        = errors.New("a task cannot be nested under itself or one of its subtasks")
+	ErrParentScope      = errors.New("a subtask must be in the same workspace and project as its parent")
+	ErrNegativeEstimate = errors.New("estimates cannot be negative")
+)
+
+// DeletePolicy decides what happens to the subtasks of a deleted task.
+type DeletePolicy string
+
+const (
+	DeleteReparent DeletePolicy = "reparent" // Subtasks move up to the deleted task's parent
+	DeleteCascade  DeletePolicy = "cascade"  // Subtasks and all their descendants are deleted too
+)
 
 type Task struct {
-	ID          int       `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"` // Adicionando a descrição
-	IsCompleted bool      `json:"isCompleted"`
-	UserID      int       `json:"userId"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID               int                `json:"id"`
+	Title            string             `json:"title"`
+	Description      string             `json:"description"` // Adicionando a descrição
+	Status           TaskStatus         `json:"status"`
+	Priority         TaskPriority       `json:"priority"`
+	IsCompleted      bool               `json:"isCompleted"` // Derived from Status, kept for older clients
+	StatusChangedBy  int                `json:"statusChangedBy,omitempty"`
+	StatusChangedAt  *time.Time         `json:"statusChangedAt,omitempty"`
+	UserID           int                `json:"userId"` // Creator of the task
+	WorkspaceID      int                `json:"workspaceId,omitempty"`
+	ProjectID        int                `json:"projectId,omitempty"`
+	ParentID         int                `json:"parentId,omitempty"`
+	StartAt          *time.Time         `json:"startAt,omitempty"`
+	DueAt            *time.Time         `json:"dueAt,omitempty"`
+	Timezone         string             `json:"timezone"`                   // IANA zone the dates were planned in, e.g. "America/Sao_Paulo"
+	EstimateMinutes  int                `json:"estimateMinutes,omitempty"`  // Original estimate
+	RemainingMinutes *int               `json:"remainingMinutes,omitempty"` // Remaining estimate, unset until the task is re-estimated
+	Recurrence       string             `json:"recurrence,omitempty"`       // RRULE, e.g. "FREQ=WEEKLY;BYDAY=MO"
+	SeriesID         int                `json:"seriesId,omitempty"`         // First task of the recurring series, 0 for the first one itself
+	Occurrence       int                `json:"occurrence,omitempty"`       // Position in the series, starting at 1
+	Labels           []Label            `json:"labels" gorm:"many2many:task_labels"`
+	Assignees        []User             `json:"assignees" gorm:"many2many:task_assignees"` // Users responsible for the work
+	Watchers         []User             `json:"watchers" gorm:"many2many:task_watchers"`   // Users following the task without owning it
+	Checklist        []ChecklistItem    `json:"checklist" gorm:"foreignKey:TaskID"`
+	CustomFields     []CustomFieldValue `json:"customFields" gorm:"foreignKey:TaskID"`
+	CreatedAt        time.Time          `json:"createdAt"`
+	UpdatedAt        time.Time          `json:"updatedAt"`
+	DeletedAt        gorm.DeletedAt     `json:"deletedAt,omitempty" gorm:"index"`  // Set while the task is in the trash
+	ArchivedAt       *time.Time         `json:"archivedAt,omitempty" gorm:"index"` // Set while the task is archived
+	ColumnID         int                `json:"columnId,omitempty" gorm:"index"`   // Custom board column, 0 for the first one
+	Rank             string             `json:"rank,omitempty" gorm:"index"`       // Position within the board column, see RankBetween
+	SprintID         int                `json:"sprintId,omitempty" gorm:"index"`
+}
+
+// CurrentStatus returns the task status, deriving it from IsCompleted for rows created before statuses existed.
+func (t *Task) CurrentStatus() TaskStatus {
+	if t.Status == "" {
+		return StatusFromCompletion(t.IsCompleted)
+	}
+	return t.Status
+}
+
+// IsOpen reports whether work on the task is still pending.
+func (t *Task) IsOpen() bool {
+	status := t.CurrentStatus()
+	return status != StatusDone && status != StatusCancelled
+}
+
+// RemainingDuration is the estimated time left on the task, zero once it is closed.
+// The remaining estimate wins over the original one when it is set.
+func (t *Task) RemainingDuration() time.Duration {
+	if !t.IsOpen() {
+		return 0
+	}
+	if t.RemainingMinutes != nil {
+		return time.Duration(*t.RemainingMinutes) * time.Minute
+	}
+	return time.Duration(t.EstimateMinutes) * time.Minute
+}
+
+// ChecklistProgress counts the checked items of the task's checklist.
+func (t *Task) ChecklistProgress() ChecklistProgress {
+	progress := ChecklistProgress{Total: len(t.Checklist)}
+	for _, item := range t.Checklist {
+		if item.Checked {
+			progress.Done++
+		}
+	}
+	return progress
+}
+
+// IsOverdue reports whether the task has a due date in the past and is still open.
+func (t *Task) IsOverdue(now time.Time) bool {
+	return t.DueAt != nil && !t.IsCompleted && t.DueAt.Before(now)
+}
+
+// Location returns the task's timezone, falling back to UTC when none is set.
+func (t *Task) Location() *time.Location {
+	if t.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(t.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// ValidateSchedule checks the timezone, the ordering of start and due dates and the estimates.
+func (t *Task) ValidateSchedule() error {
+	if t.Timezone != "" {
+		if _, err := time.LoadLocation(t.Timezone); err != nil {
+			return ErrInvalidTimezone
+		}
+	}
+	if t.StartAt != nil && t.DueAt != nil && t.StartAt.After(*t.DueAt) {
+		return ErrInvalidSchedule
+	}
+	if t.EstimateMinutes < 0 || (t.RemainingMinutes != nil && *t.RemainingMinutes < 0) {
+		return ErrNegativeEstimate
+	}
+	return nil
+}
+
+// NormalizeSchedule stores start and due dates in UTC so they compare correctly in the database.
+func (t *Task) NormalizeSchedule() {
+	if t.StartAt != nil {
+		start := t.StartAt.UTC()
+		t.StartAt = &start
+	}
+	if t.DueAt != nil {
+		due := t.DueAt.UTC()
+		t.DueAt = &due
+	}
+}
+
+// MarshalJSON adds the computed fields to the task representation.
+func (t Task) MarshalJSON() ([]byte, error) {
+	type task Task
+	return json.Marshal(struct {
+		task
+		Overdue           bool              `json:"overdue"`
+		ChecklistProgress ChecklistProgress `json:"checklistProgress"`
+	}{
+		task:              task(withStatus(t)),
+		Overdue:           t.IsOverdue(time.Now()),
+		ChecklistProgress: t.ChecklistProgress(),
+	})
+}
+
+func withStatus(t Task) Task {
+	t.Status = t.CurrentStatus()
+	return t
 }
 
 type NewTask struct {
@@ -26,10 +174,26 @@ type UpdateTask struct {
 }
 
 type TaskFilter struct {
-	Search string `json:"search"`
-	Page   int    `json:"page"`
-	Limit  int    `json:"limit"`
-	UserID string `json:"userId"`
+	Search          string              `json:"search"`
+	Page            int                 `json:"page"`
+	Limit           int                 `json:"limit"`
+	UserID          string              `json:"userId"`
+	ProjectID       int                 `json:"projectId"`
+	DueBefore       *time.Time          `json:"dueBefore"`
+	DueAfter        *time.Time          `json:"dueAfter"`
+	Overdue         *bool               `json:"overdue"`
+	Sort            []TaskSort          `json:"sort"`
+	LabelsAny       []int               `json:"labelsAny"` // Tasks having at least one of these labels
+	LabelsAll       []int               `json:"labelsAll"` // Tasks having every one of these labels
+	CreatorID       int                 `json:"creatorId"`
+	AssigneeID      int                 `json:"assigneeId"`
+	WatcherID       int                 `json:"watcherId"`
+	IncludeArchived bool                `json:"includeArchived"` // Archived tasks are left out unless set
+	CustomFields    []CustomFieldFilter `json:"customFields"`    // All of them must match
+	Status          TaskStatus          `json:"status"`
+	ColumnIDs       []int               `json:"columnIds"` // Tasks in any of these custom board columns
+	SprintID        int                 `json:"sprintId"`
+	Access          *TaskAccess         `json:"-"` // Set by TaskService for users who may not see every task
 }
 
 type TaskEdge struct {
@@ -45,11 +209,44 @@ type TaskConnection struct {
 	} `json:"pageInfo"`
 }
 
+// TaskWrite is a task saved by TaskRepository.Apply together with the activity logging it.
+type TaskWrite struct {
+	Task     *Task
+	Create   bool      // Create the task, with its assignees and watchers, instead of updating it
+	Activity *Activity // Nil when there is nothing to log; its TaskID is filled in on saving
+}
+
 type TaskRepository interface {
 	Create(task *Task) error
+	// Apply saves the tasks in order and records their activity, all or nothing.
+	Apply(writes []TaskWrite) error
+	// CreateTree creates a task with its labels, checklist and subtasks, all or nothing.
+	CreateTree(tree *TaskTree) error
 	FindByID(id int) (*Task, error)
 	FindAll(filter TaskFilter) (*TaskConnection, error)
 	Update(task *Task) error
-	Delete(id int) error
+	Delete(id int, policy DeletePolicy) error
 	FindByUserID(userID int) ([]Task, error)
+	FindChildren(parentID int) ([]Task, error)
+	FindDescendants(id int) ([]Task, error)
+	FindBlockers(taskID int) ([]Task, error)
+	AddAssignee(taskID, userID int) error
+	RemoveAssignee(taskID, userID int) error
+	AddWatcher(taskID, userID int) error
+	RemoveWatcher(taskID, userID int) error
+	FindDeleted(filter TaskFilter) (*TaskConnection, error)
+	FindDeletedByID(id int) (*Task, error)
+	// Restore takes a task out of the trash along with the subtasks deleted together with it.
+	Restore(id int) error
+	// Purge permanently removes a trashed task and everything attached to it, returning the
+	// removed attachments so their content can be deleted too.
+	Purge(id int) ([]Attachment, error)
+	PurgeDeletedBefore(cutoff time.Time) ([]Attachment, error)
+	// ArchiveStale archives the live tasks matching the rule as of now and returns their IDs.
+	ArchiveStale(rule ArchiveRule, now time.Time) ([]int, error)
+	// UpdateRank places a task in a board column; Rerank renumbers tasks within their columns.
+	UpdateRank(id, columnID int, rank string) error
+	Rerank(ranks []TaskRank) error
+	// UpdateSprint puts every given task in the sprint, 0 for none, or none of them if any task is missing.
+	UpdateSprint(ids []int, sprintID int) error
 }
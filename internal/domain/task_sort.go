@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+type TaskSortField string
+
+const (
+	SortByPriority    TaskSortField = "priority"
+	SortByDueAt       TaskSortField = "dueAt"
+	SortByCreatedAt   TaskSortField = "createdAt"
+	SortByTitle       TaskSortField = "title"
+	SortByRank        TaskSortField = "rank"        // Manual board order, unranked tasks last
+	SortByCustomField TaskSortField = "customField" // Value of the custom field FieldID, tasks without one last
+)
+
+var ErrInvalidSort = errors.New("invalid task sort")
+
+// TaskSort is one key of a multi-key sort specification.
+type TaskSort struct {
+	Field   TaskSortField `json:"field"`
+	Desc    bool          `json:"desc"`
+	FieldID int           `json:"fieldId,omitempty"` // Custom field to sort by
+}
+
+func (f TaskSortField) IsValid() bool {
+	switch f {
+	case SortByPriority, SortByDueAt, SortByCreatedAt, SortByTitle, SortByRank, SortByCustomField:
+		return true
+	}
+	return false
+}
+
+// ParseTaskSort parses a comma separated sort specification such as "-priority,dueAt,cf:3",
+// where a leading "-" sorts that key in descending order and cf:<id> sorts by a custom field.
+func ParseTaskSort(spec string) ([]TaskSort, error) {
+	var sorts []TaskSort
+	for _, key := range strings.Split(spec, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		sort := TaskSort{}
+		if strings.HasPrefix(key, "-") {
+			sort.Desc = true
+			key = key[1:]
+		} else if strings.HasPrefix(key, "+") {
+			key = key[1:]
+		}
+		if id, ok := strings.CutPrefix(key, "cf:"); ok {
+			fieldID, err := strconv.Atoi(id)
+			if err != nil || fieldID <= 0 {
+				return nil, ErrInvalidSort
+			}
+			sort.Field, sort.FieldID = SortByCustomField, fieldID
+			sorts = append(sorts, sort)
+			continue
+		}
+		sort.Field = TaskSortField(key)
+		if !sort.Field.IsValid() || sort.Field == SortByCustomField {
+			return nil, ErrInvalidSort
+		}
+		sorts = append(sorts, sort)
+	}
+	return sorts, nil
+}
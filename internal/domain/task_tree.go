@@ -0,0 +1,29 @@
+package domain
+
+// Progress returns the completion percentage of a task, rolled up from the leaves of its subtree.
+// Cancelled leaves are ignored; a task without subtasks is either 0 or 100 percent done.
+func Progress(task Task, descendants []Task) float64 {
+	hasChildren := make(map[int]bool, len(descendants))
+	for _, d := range descendants {
+		hasChildren[d.ParentID] = true
+	}
+
+	var done, total int
+	for _, d := range descendants {
+		if hasChildren[d.ID] || d.CurrentStatus() == StatusCancelled {
+			continue
+		}
+		total++
+		if d.CurrentStatus() == StatusDone {
+			done++
+		}
+	}
+
+	if total == 0 {
+		if task.CurrentStatus() == StatusDone {
+			return 100
+		}
+		return 0
+	}
+	return float64(done) * 100 / float64(total)
+}
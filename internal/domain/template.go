@@ -0,0 +1,191 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidTemplate    = errors.New("template name and the title of every task in it are required")
+	ErrMissingPlaceholder = errors.New("no value given for template placeholder")
+	ErrTemplateLabel      = errors.New("template labels must belong to the template's workspace")
+)
+
+// placeholderPattern matches placeholders such as {{customer}} or {{ release }}.
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// TemplateTask describes a task a template creates: the root task or one of its subtasks.
+// Title, description and checklist items may contain {{name}} placeholders.
+type TemplateTask struct {
+	Title            string         `json:"title"`
+	Description      string         `json:"description,omitempty"`
+	Priority         TaskPriority   `json:"priority"`
+	EstimateMinutes  int            `json:"estimateMinutes,omitempty"`
+	DueOffsetMinutes *int           `json:"dueOffsetMinutes,omitempty"` // Due date relative to the time the template is instantiated
+	LabelIDs         []int          `json:"labelIds,omitempty"`
+	Checklist        []string       `json:"checklist,omitempty"`
+	Subtasks         []TemplateTask `json:"subtasks,omitempty"`
+}
+
+// TaskTemplate is a reusable blueprint for a task together with its subtasks.
+type TaskTemplate struct {
+	ID          int          `json:"id"`
+	WorkspaceID int          `json:"workspaceId" gorm:"index"`
+	Name        string       `json:"name"`
+	Task        TemplateTask `json:"task" gorm:"serializer:json"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	UpdatedAt   time.Time    `json:"updatedAt"`
+}
+
+func (t *TaskTemplate) Validate() error {
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" {
+		return ErrInvalidTemplate
+	}
+	return t.Task.validate()
+}
+
+func (t *TemplateTask) validate() error {
+	if strings.TrimSpace(t.Title) == "" {
+		return ErrInvalidTemplate
+	}
+	if !t.Priority.IsValid() {
+		return ErrUnknownPriority
+	}
+	if t.EstimateMinutes < 0 {
+		return ErrNegativeEstimate
+	}
+	for i := range t.Subtasks {
+		if err := t.Subtasks[i].validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LabelIDs lists the labels used anywhere in the template, without repetitions.
+func (t *TaskTemplate) LabelIDs() []int {
+	seen := map[int]bool{}
+	var ids []int
+	t.Task.walk(func(task *TemplateTask) {
+		for _, id := range task.LabelIDs {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	})
+	return ids
+}
+
+// Placeholders lists the names of the placeholders used in the template, sorted.
+func (t *TaskTemplate) Placeholders() []string {
+	seen := map[string]bool{}
+	t.Task.walk(func(task *TemplateTask) {
+		texts := append([]string{task.Title, task.Description}, task.Checklist...)
+		for _, text := range texts {
+			for _, match := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+				seen[match[1]] = true
+			}
+		}
+	})
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (t *TemplateTask) walk(visit func(*TemplateTask)) {
+	visit(t)
+	for i := range t.Subtasks {
+		t.Subtasks[i].walk(visit)
+	}
+}
+
+// TemplateParams are the inputs of one instantiation of a template.
+type TemplateParams struct {
+	Values    map[string]string `json:"values"`              // Placeholder values by name
+	ProjectID int               `json:"projectId,omitempty"` // Project of the created tasks
+	ParentID  int               `json:"parentId,omitempty"`  // Existing task to nest the created tree under
+	UserID    int               `json:"-"`                   // Creator of the tasks
+	Now       time.Time         `json:"-"`                   // Base of the relative due dates
+}
+
+// TaskTree is a task to be created together with its subtasks. Labels are referenced by ID
+// and checklist items are new.
+type TaskTree struct {
+	Task     *Task
+	Subtasks []*TaskTree
+}
+
+// Walk visits the tasks of the tree, parents before their subtasks.
+func (t *TaskTree) Walk(visit func(*Task)) {
+	visit(t.Task)
+	for _, subtask := range t.Subtasks {
+		subtask.Walk(visit)
+	}
+}
+
+// Build turns the template into the tree of tasks to create, substituting placeholders
+// and resolving relative due dates. Every placeholder needs a value.
+func (t *TaskTemplate) Build(params TemplateParams) (*TaskTree, error) {
+	var missing []string
+	for _, name := range t.Placeholders() {
+		if _, ok := params.Values[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrMissingPlaceholder, strings.Join(missing, ", "))
+	}
+	root := t.Task.build(t.WorkspaceID, params)
+	root.Task.ParentID = params.ParentID
+	return root, nil
+}
+
+func (t *TemplateTask) build(workspaceID int, params TemplateParams) *TaskTree {
+	fill := func(text string) string {
+		return placeholderPattern.ReplaceAllStringFunc(text, func(match string) string {
+			return params.Values[placeholderPattern.FindStringSubmatch(match)[1]]
+		})
+	}
+	task := &Task{
+		Title:           fill(t.Title),
+		Description:     fill(t.Description),
+		Status:          StatusTodo,
+		Priority:        t.Priority,
+		UserID:          params.UserID,
+		WorkspaceID:     workspaceID,
+		ProjectID:       params.ProjectID,
+		EstimateMinutes: t.EstimateMinutes,
+	}
+	if t.DueOffsetMinutes != nil {
+		due := params.Now.Add(time.Duration(*t.DueOffsetMinutes) * time.Minute).UTC()
+		task.DueAt = &due
+	}
+	for _, id := range t.LabelIDs {
+		task.Labels = append(task.Labels, Label{ID: id})
+	}
+	for i, text := range t.Checklist {
+		task.Checklist = append(task.Checklist, ChecklistItem{Text: fill(text), Position: i + 1})
+	}
+	tree := &TaskTree{Task: task}
+	for i := range t.Subtasks {
+		tree.Subtasks = append(tree.Subtasks, t.Subtasks[i].build(workspaceID, params))
+	}
+	return tree
+}
+
+type TemplateRepository interface {
+	Create(template *TaskTemplate) error
+	FindByID(id int) (*TaskTemplate, error)
+	FindByWorkspaceID(workspaceID int) ([]TaskTemplate, error)
+	Update(template *TaskTemplate) error
+	Delete(id int) error
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrTimerRunning       = errors.New("a timer is already running for this user")
+	ErrNoRunningTimer     = errors.New("no timer is running for this user")
+	ErrInvalidTimeEntry   = errors.New("a time entry must end after it starts")
+	ErrTimeEntryForbidden = errors.New("only the author can change this time entry")
+)
+
+// TimeEntry is time a user spent on a task, either measured by a timer or logged by hand.
+// A running timer has no EndedAt; each user has at most one.
+type TimeEntry struct {
+	ID        int        `json:"id"`
+	TaskID    int        `json:"taskId" gorm:"index"`
+	UserID    int        `json:"userId" gorm:"index;uniqueIndex:idx_time_entries_running,where:ended_at IS NULL"`
+	StartedAt time.Time  `json:"startedAt" gorm:"index"`
+	EndedAt   *time.Time `json:"endedAt,omitempty"`
+	Seconds   int64      `json:"seconds"` // Length of the entry, filled in once it has ended
+	Note      string     `json:"note"`
+	Manual    bool       `json:"manual"` // Logged by hand rather than measured by a timer
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+func (e *TimeEntry) IsRunning() bool {
+	return e.EndedAt == nil
+}
+
+// Stop ends the entry at the given time and records its length.
+func (e *TimeEntry) Stop(at time.Time) error {
+	at = at.UTC()
+	if at.Before(e.StartedAt) {
+		return ErrInvalidTimeEntry
+	}
+	e.EndedAt = &at
+	e.Seconds = int64(at.Sub(e.StartedAt) / time.Second)
+	return nil
+}
+
+// Elapsed is the length of the entry, counting a running timer up to now.
+func (e *TimeEntry) Elapsed(now time.Time) time.Duration {
+	if e.IsRunning() {
+		return now.Sub(e.StartedAt)
+	}
+	return time.Duration(e.Seconds) * time.Second
+}
+
+// TimeEntryUpdate holds the fields of a time entry to change; nil fields are left as they are.
+type TimeEntryUpdate struct {
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+	EndedAt   *time.Time `json:"endedAt,omitempty"`
+	Note      *string    `json:"note,omitempty"`
+}
+
+// TimeEntryFilter selects time entries; From and To bound the start of the entries, To excluded.
+type TimeEntryFilter struct {
+	TaskID    int        `json:"taskId"`
+	ProjectID int        `json:"projectId"`
+	UserID    int        `json:"userId"`
+	From      *time.Time `json:"from"`
+	To        *time.Time `json:"to"`
+	Page      int        `json:"page"`
+	Limit     int        `json:"limit"`
+}
+
+type TimeEntryEdge struct {
+	Node TimeEntry `json:"node"`
+}
+
+type TimeEntryConnection struct {
+	Edges    []TimeEntryEdge `json:"edges"`
+	PageInfo struct {
+		HasNextPage     bool `json:"hasNextPage"`
+		HasPreviousPage bool `json:"hasPreviousPage"`
+		TotalCount      int  `json:"totalCount"`
+	} `json:"pageInfo"`
+}
+
+// TimeTotal is the tracked time of one task, project or user.
+type TimeTotal struct {
+	ID      int   `json:"id"`
+	Seconds int64 `json:"seconds"`
+}
+
+// TimeReport sums the finished time entries matching a filter, overall and broken down
+// by task, project and user. Running timers are counted once they are stopped.
+type TimeReport struct {
+	Seconds   int64       `json:"seconds"`
+	ByTask    []TimeTotal `json:"byTask"`
+	ByProject []TimeTotal `json:"byProject"` // Tasks outside any project are grouped under ID 0
+	ByUser    []TimeTotal `json:"byUser"`
+}
+
+// TaskTime compares the estimates of a task with the time tracked on it.
+type TaskTime struct {
+	TaskID           int   `json:"taskId"`
+	EstimateMinutes  int   `json:"estimateMinutes"`            // Original estimate
+	RemainingMinutes *int  `json:"remainingMinutes,omitempty"` // Latest remaining estimate, if the task was re-estimated
+	TrackedSeconds   int64 `json:"trackedSeconds"`
+}
+
+type TimeEntryRepository interface {
+	Create(entry *TimeEntry) error // ErrTimerRunning for a second running timer of the user
+	FindByID(id int) (*TimeEntry, error)
+	FindRunning(userID int) (*TimeEntry, error)                   // Nil when the user has no running timer
+	FindAll(filter TimeEntryFilter) (*TimeEntryConnection, error) // Newest first
+	Update(entry *TimeEntry) error
+	Delete(id int) error
+	Report(filter TimeEntryFilter) (*TimeReport, error)
+}
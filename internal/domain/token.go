@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid or expired token")
+	ErrTokenRevoked = errors.New("token has been revoked")
+)
+
+// AccessClaims are the claims of a validated access token.
+type AccessClaims struct {
+	UserID    int       // sub
+	TokenID   string    // jti
+	SessionID string    // sid, the refresh token family the token was issued for
+	Roles     []string  // roles
+	IssuedAt  time.Time // iat
+	ExpiresAt time.Time // exp
+}
@@ -5,17 +5,23 @@ import (
 	"regexp"
 	"task-manager-app/backend/pkg/utils"
 	"time"
+
+	"gorm.io/gorm"
 )
 
+var ErrEmailTaken = errors.New("email already in use")
+
 type User struct {
-	ID           int       `json:"id"`
-	Email        string    `json:"email"`
-	PasswordHash string    `json:"-"`
-	Name         string    `json:"name"`
-	LastName     string    `json:"lastName"`
-	Avatar       string    `json:"avatar"`
-	CreatedAt    time.Time `json:"createdAt"`
-	UpdatedAt    time.Time `json:"updatedAt"`
+	ID           int            `json:"id"`
+	Email        string         `json:"email"`
+	PasswordHash string         `json:"-"`
+	Name         string         `json:"name"`
+	LastName     string         `json:"lastName"`
+	Avatar       string         `json:"avatar"`
+	Superadmin   bool           `json:"superadmin"` // May do anything in every workspace; only set out of band
+	CreatedAt    time.Time      `json:"createdAt"`
+	UpdatedAt    time.Time      `json:"updatedAt"`
+	DeletedAt    gorm.DeletedAt `json:"deletedAt,omitempty" gorm:"index"` // Set while the user is in the trash
 }
 
 type UserLogin struct {
@@ -32,8 +38,10 @@ type UserRegister struct {
 }
 
 type AuthResponse struct {
-	User  *User  `json:"user"`
-	Token string `json:"token"`
+	User         *User  `json:"user"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refreshToken"`
+	ExpiresIn    int    `json:"expiresIn"` // Seconds until Token expires
 }
 
 type UserUpdate struct {
@@ -74,4 +82,9 @@ type UserRepository interface {
 	FindByID(id int) (*User, error)
 	Update(user *User) error
 	Delete(id int) error
+	FindDeleted() ([]User, error)
+	// Restore takes a user out of the trash, failing with ErrEmailTaken if the email was reused meanwhile.
+	Restore(id int) error
+	Purge(id int) error
+	PurgeDeletedBefore(cutoff time.Time) (int, error)
 }
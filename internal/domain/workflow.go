@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+type TaskStatus string
+
+const (
+	StatusTodo       TaskStatus = "todo"
+	StatusInProgress TaskStatus = "in_progress"
+	StatusBlocked    TaskStatus = "blocked"
+	StatusInReview   TaskStatus = "in_review"
+	StatusDone       TaskStatus = "done"
+	StatusCancelled  TaskStatus = "cancelled"
+)
+
+var ErrUnknownStatus = errors.New("unknown task status")
+
+// TaskStatuses lists every status in workflow order.
+var TaskStatuses = []TaskStatus{StatusTodo, StatusInProgress, StatusBlocked, StatusInReview, StatusDone, StatusCancelled}
+
+func (s TaskStatus) IsValid() bool {
+	for _, status := range TaskStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusFromCompletion maps the legacy IsCompleted flag to a status.
+func StatusFromCompletion(completed bool) TaskStatus {
+	if completed {
+		return StatusDone
+	}
+	return StatusTodo
+}
+
+// InvalidTransitionError is returned when a workflow does not allow moving a task between two statuses.
+type InvalidTransitionError struct {
+	From TaskStatus
+	To   TaskStatus
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("transition from %q to %q is not allowed", e.From, e.To)
+}
+
+// Workflow is the state machine a task's status moves through.
+type Workflow struct {
+	transitions map[TaskStatus]map[TaskStatus]bool
+}
+
+// NewWorkflow builds a workflow from a list of allowed transitions.
+func NewWorkflow(transitions []WorkflowTransition) (*Workflow, error) {
+	w := &Workflow{transitions: make(map[TaskStatus]map[TaskStatus]bool)}
+	for _, t := range transitions {
+		if !t.FromStatus.IsValid() || !t.ToStatus.IsValid() {
+			return nil, ErrUnknownStatus
+		}
+		if w.transitions[t.FromStatus] == nil {
+			w.transitions[t.FromStatus] = make(map[TaskStatus]bool)
+		}
+		w.transitions[t.FromStatus][t.ToStatus] = true
+	}
+	return w, nil
+}
+
+// DefaultWorkflow is used by tasks whose workspace has not configured its own transitions.
+func DefaultWorkflow() *Workflow {
+	w, _ := NewWorkflow(DefaultTransitions())
+	return w
+}
+
+func DefaultTransitions() []WorkflowTransition {
+	allowed := map[TaskStatus][]TaskStatus{
+		StatusTodo:       {StatusInProgress, StatusBlocked, StatusDone, StatusCancelled},
+		StatusInProgress: {StatusTodo, StatusBlocked, StatusInReview, StatusDone, StatusCancelled},
+		StatusBlocked:    {StatusTodo, StatusInProgress, StatusCancelled},
+		StatusInReview:   {StatusInProgress, StatusDone, StatusCancelled},
+		StatusDone:       {StatusTodo, StatusInProgress},
+		StatusCancelled:  {StatusTodo},
+	}
+	var transitions []WorkflowTransition
+	for _, from := range TaskStatuses {
+		for _, to := range allowed[from] {
+			transitions = append(transitions, WorkflowTransition{FromStatus: from, ToStatus: to})
+		}
+	}
+	return transitions
+}
+
+// CanTransition reports whether a task may move from one status to another.
+func (w *Workflow) CanTransition(from, to TaskStatus) bool {
+	return from == to || w.transitions[from][to]
+}
+
+// Validate returns an error describing why a transition is rejected, if it is.
+func (w *Workflow) Validate(from, to TaskStatus) error {
+	if !to.IsValid() {
+		return ErrUnknownStatus
+	}
+	if !w.CanTransition(from, to) {
+		return &InvalidTransitionError{From: from, To: to}
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+type Workspace struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	OwnerID   int       `json:"ownerId"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// WorkflowTransition is one allowed status change in a workspace's workflow.
+type WorkflowTransition struct {
+	ID          int        `json:"-"`
+	WorkspaceID int        `json:"-"`
+	FromStatus  TaskStatus `json:"from"`
+	ToStatus    TaskStatus `json:"to"`
+}
+
+type WorkspaceRepository interface {
+	Create(workspace *Workspace) error
+	FindByID(id int) (*Workspace, error)
+	FindByOwnerID(ownerID int) ([]Workspace, error)
+	FindTransitions(workspaceID int) ([]WorkflowTransition, error)
+	ReplaceTransitions(workspaceID int, transitions []WorkflowTransition) error
+	FindMembers(workspaceID int) ([]WorkspaceMember, error)
+	FindMemberships(userID int) ([]WorkspaceMember, error)
+	SaveMember(member *WorkspaceMember) error // Adds the member or changes its role
+	RemoveMember(workspaceID, userID int) error
+}
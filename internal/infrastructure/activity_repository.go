@@ -0,0 +1,69 @@
+package infrastructure
+
+import (
+	"fmt"
+	"task-manager-app/backend/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ActivityRepository struct {
+	db *gorm.DB
+}
+
+func NewActivityRepository(db *gorm.DB) *ActivityRepository {
+	return &ActivityRepository{db: db}
+}
+
+func (r *ActivityRepository) Create(activity *domain.Activity) error {
+	activity.CreatedAt = time.Now()
+	if err := r.db.Create(activity).Error; err != nil {
+		return fmt.Errorf("failed to record activity: %w", err)
+	}
+	return nil
+}
+
+func (r *ActivityRepository) FindByTask(filter domain.ActivityFilter) (*domain.ActivityConnection, error) {
+	var activities []domain.Activity
+	var count int64
+	query := r.db.Model(&domain.Activity{}).Where("task_id = ?", filter.TaskID)
+	if err := query.Count(&count).Error; err != nil {
+		return nil, fmt.Errorf("failed to count activity: %w", err)
+	}
+	query = query.Order("created_at DESC").Order("id DESC")
+	if filter.Page > 0 && filter.Limit > 0 {
+		query = query.Offset((filter.Page - 1) * filter.Limit).Limit(filter.Limit)
+	}
+	if err := query.Find(&activities).Error; err != nil {
+		return nil, fmt.Errorf("failed to find activity: %w", err)
+	}
+
+	connection := &domain.ActivityConnection{Edges: make([]domain.ActivityEdge, len(activities))}
+	for i, activity := range activities {
+		connection.Edges[i] = domain.ActivityEdge{Node: activity}
+	}
+	connection.PageInfo.TotalCount = int(count)
+	connection.PageInfo.HasNextPage = filter.Limit > 0 && filter.Page*filter.Limit < int(count)
+	connection.PageInfo.HasPreviousPage = filter.Page > 1
+	return connection, nil
+}
+
+// recordBulkUpdate logs, inside tx, what a bulk update does to tasks as loaded before it:
+// change applies the update to a copy of each task. Tasks it leaves alone get no entry.
+func recordBulkUpdate(tx *gorm.DB, tasks []domain.Task, actor domain.Actor, change func(task *domain.Task)) error {
+	now := time.Now()
+	for i := range tasks {
+		after := tasks[i]
+		change(&after)
+		changes := domain.DiffTasks(&tasks[i], &after)
+		if len(changes) == 0 {
+			continue
+		}
+		activity := domain.Activity{TaskID: tasks[i].ID, ActorID: actor.UserID, Source: actor.Source, Action: domain.ActivityUpdated, Changes: changes, CreatedAt: now}
+		if err := tx.Create(&activity).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package infrastructure
+
+import (
+	"fmt"
+	"task-manager-app/backend/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AttachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) *AttachmentRepository {
+	return &AttachmentRepository{db: db}
+}
+
+func (r *AttachmentRepository) Create(attachment *domain.Attachment) error {
+	attachment.CreatedAt = time.Now()
+	if err := r.db.Create(attachment).Error; err != nil {
+		return fmt.Errorf("failed to create attachment: %w", err)
+	}
+	return nil
+}
+
+func (r *AttachmentRepository) FindByID(id int) (*domain.Attachment, error) {
+	var attachment domain.Attachment
+	if err := r.db.First(&attachment, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to find attachment: %w", err)
+	}
+	return &attachment, nil
+}
+
+func (r *AttachmentRepository) FindByTaskID(taskID int) ([]domain.Attachment, error) {
+	var attachments []domain.Attachment
+	if err := r.db.Where("task_id = ?", taskID).Order("id").Find(&attachments).Error; err != nil {
+		return nil, fmt.Errorf("failed to find attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+func (r *AttachmentRepository) Delete(id int) error {
+	if err := r.db.Delete(&domain.Attachment{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+	return nil
+}
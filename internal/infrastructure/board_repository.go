@@ -0,0 +1,79 @@
+package infrastructure
+
+import (
+	"fmt"
+	"task-manager-app/backend/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type BoardRepository struct {
+	db *gorm.DB
+}
+
+func NewBoardRepository(db *gorm.DB) *BoardRepository {
+	return &BoardRepository{db: db}
+}
+
+func (r *BoardRepository) FindColumns(projectID int) ([]domain.BoardColumn, error) {
+	var columns []domain.BoardColumn
+	if err := r.db.Where("project_id = ?", projectID).Order("position").Order("id").Find(&columns).Error; err != nil {
+		return nil, fmt.Errorf("failed to find board columns: %w", err)
+	}
+	return columns, nil
+}
+
+func (r *BoardRepository) ReplaceColumns(projectID int, columns []domain.BoardColumn, actor domain.Actor) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var kept []int
+		for _, column := range columns {
+			if column.ID != 0 {
+				kept = append(kept, column.ID)
+			}
+		}
+		removed := tx.Model(&domain.BoardColumn{}).Select("id").Where("project_id = ?", projectID)
+		if len(kept) > 0 {
+			removed = removed.Where("id NOT IN ?", kept)
+		}
+		// Trashed tasks move too, so they show up again when restored
+		var tasks []domain.Task
+		if err := tx.Unscoped().Where("column_id IN (?)", removed).Find(&tasks).Error; err != nil {
+			return err
+		}
+		if err := recordBulkUpdate(tx, tasks, actor, func(task *domain.Task) { task.ColumnID = 0 }); err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&domain.Task{}).Where("column_id IN (?)", removed).Update("column_id", 0).Error; err != nil {
+			return err
+		}
+		deleted := tx.Where("project_id = ?", projectID)
+		if len(kept) > 0 {
+			deleted = deleted.Where("id NOT IN ?", kept)
+		}
+		if err := deleted.Delete(&domain.BoardColumn{}).Error; err != nil {
+			return err
+		}
+		now := time.Now()
+		for i := range columns {
+			columns[i].ProjectID = projectID
+			columns[i].Position = i + 1
+			columns[i].UpdatedAt = now
+			if columns[i].ID == 0 {
+				columns[i].CreatedAt = now
+				if err := tx.Create(&columns[i]).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			if err := tx.Model(&columns[i]).Select("name", "position", "updated_at").Updates(&columns[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to replace board columns: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package infrastructure
+
+import (
+	"fmt"
+	"task-manager-app/backend/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ChecklistRepository struct {
+	db *gorm.DB
+}
+
+func NewChecklistRepository(db *gorm.DB) *ChecklistRepository {
+	return &ChecklistRepository{db: db}
+}
+
+// Create appends the item after the last one of its task's checklist.
+func (r *ChecklistRepository) Create(item *domain.ChecklistItem) error {
+	item.CreatedAt = time.Now()
+	item.UpdatedAt = time.Now()
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var last int
+		if err := tx.Model(&domain.ChecklistItem{}).Where("task_id = ?", item.TaskID).
+			Select("COALESCE(MAX(position), 0)").Scan(&last).Error; err != nil {
+			return err
+		}
+		item.Position = last + 1
+		return tx.Create(item).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create checklist item: %w", err)
+	}
+	return nil
+}
+
+func (r *ChecklistRepository) FindByID(id int) (*domain.ChecklistItem, error) {
+	var item domain.ChecklistItem
+	if err := r.db.First(&item, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to find checklist item: %w", err)
+	}
+	return &item, nil
+}
+
+func (r *ChecklistRepository) FindByTask(taskID int) ([]domain.ChecklistItem, error) {
+	items := []domain.ChecklistItem{}
+	if err := orderedChecklist(r.db.Where("task_id = ?", taskID)).Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("failed to find checklist: %w", err)
+	}
+	return items, nil
+}
+
+func (r *ChecklistRepository) Update(item *domain.ChecklistItem) error {
+	item.UpdatedAt = time.Now()
+	if err := r.db.Save(item).Error; err != nil {
+		return fmt.Errorf("failed to update checklist item: %w", err)
+	}
+	return nil
+}
+
+func (r *ChecklistRepository) Delete(id int) error {
+	if err := r.db.Delete(&domain.ChecklistItem{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete checklist item: %w", err)
+	}
+	return nil
+}
+
+func (r *ChecklistRepository) Reorder(taskID int, itemIDs []int) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for i, id := range itemIDs {
+			if err := tx.Model(&domain.ChecklistItem{}).Where("id = ? AND task_id = ?", id, taskID).
+				Updates(map[string]interface{}{"position": i + 1, "updated_at": time.Now()}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reorder checklist: %w", err)
+	}
+	return nil
+}
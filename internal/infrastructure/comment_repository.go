@@ -0,0 +1,132 @@
+package infrastructure
+
+import (
+	"fmt"
+	"task-manager-app/backend/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type CommentRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) *CommentRepository {
+	return &CommentRepository{db: db}
+}
+
+// Create stores a comment together with its mentions.
+func (r *CommentRepository) Create(comment *domain.Comment) error {
+	comment.CreatedAt = time.Now()
+	comment.UpdatedAt = time.Now()
+	if err := r.db.Create(comment).Error; err != nil {
+		return fmt.Errorf("failed to create comment: %w", err)
+	}
+	return nil
+}
+
+func (r *CommentRepository) FindByID(id int) (*domain.Comment, error) {
+	var comment domain.Comment
+	if err := r.db.Preload("Mentions").First(&comment, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to find comment: %w", err)
+	}
+	return &comment, nil
+}
+
+func (r *CommentRepository) FindByTask(filter domain.CommentFilter) (*domain.CommentConnection, error) {
+	var comments []domain.Comment
+	var count int64
+	query := r.db.Model(&domain.Comment{}).Where("task_id = ? AND parent_id = 0", filter.TaskID)
+	if err := query.Count(&count).Error; err != nil {
+		return nil, fmt.Errorf("failed to count comments: %w", err)
+	}
+	query = query.Order("created_at ASC").Order("id ASC")
+	if filter.Page > 0 && filter.Limit > 0 {
+		query = query.Offset((filter.Page - 1) * filter.Limit).Limit(filter.Limit)
+	}
+	if err := query.Preload("Mentions").Find(&comments).Error; err != nil {
+		return nil, fmt.Errorf("failed to find comments: %w", err)
+	}
+
+	connection := &domain.CommentConnection{Edges: make([]domain.CommentEdge, len(comments))}
+	for i, comment := range comments {
+		connection.Edges[i] = domain.CommentEdge{Node: comment}
+	}
+	connection.PageInfo.TotalCount = int(count)
+	connection.PageInfo.HasNextPage = filter.Limit > 0 && filter.Page*filter.Limit < int(count)
+	connection.PageInfo.HasPreviousPage = filter.Page > 1
+	return connection, nil
+}
+
+func (r *CommentRepository) FindReplies(parentIDs []int) ([]domain.Comment, error) {
+	var replies []domain.Comment
+	if len(parentIDs) == 0 {
+		return replies, nil
+	}
+	if err := r.db.Preload("Mentions").Where("parent_id IN ?", parentIDs).Order("created_at ASC").Order("id ASC").Find(&replies).Error; err != nil {
+		return nil, fmt.Errorf("failed to find replies: %w", err)
+	}
+	return replies, nil
+}
+
+// Update saves the new body, keeps the previous one as a revision and replaces the mentions.
+func (r *CommentRepository) Update(comment *domain.Comment, revision *domain.CommentRevision) error {
+	comment.UpdatedAt = time.Now()
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(revision).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("comment_id = ?", comment.ID).Delete(&domain.CommentMention{}).Error; err != nil {
+			return err
+		}
+		for i := range comment.Mentions {
+			comment.Mentions[i].ID = 0
+			comment.Mentions[i].CommentID = comment.ID
+		}
+		if len(comment.Mentions) > 0 {
+			if err := tx.Create(&comment.Mentions).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Omit("Mentions").Save(comment).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update comment: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a comment with its replies, mentions and revisions.
+func (r *CommentRepository) Delete(id int) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var ids []int
+		if err := tx.Model(&domain.Comment{}).Where("parent_id = ?", id).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		return deleteComments(tx, append(ids, id))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+	return nil
+}
+
+func (r *CommentRepository) FindRevisions(commentID int) ([]domain.CommentRevision, error) {
+	var revisions []domain.CommentRevision
+	if err := r.db.Where("comment_id = ?", commentID).Order("id ASC").Find(&revisions).Error; err != nil {
+		return nil, fmt.Errorf("failed to find comment revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+// deleteComments removes comments and the rows that belong to them.
+func deleteComments(tx *gorm.DB, ids []int) error {
+	if err := tx.Where("comment_id IN ?", ids).Delete(&domain.CommentMention{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("comment_id IN ?", ids).Delete(&domain.CommentRevision{}).Error; err != nil {
+		return err
+	}
+	return tx.Delete(&domain.Comment{}, ids).Error
+}
@@ -0,0 +1,80 @@
+package infrastructure
+
+import (
+	"fmt"
+	"task-manager-app/backend/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CustomFieldRepository struct {
+	db *gorm.DB
+}
+
+func NewCustomFieldRepository(db *gorm.DB) *CustomFieldRepository {
+	return &CustomFieldRepository{db: db}
+}
+
+func (r *CustomFieldRepository) Create(field *domain.CustomField) error {
+	field.CreatedAt = time.Now()
+	field.UpdatedAt = time.Now()
+	if err := r.db.Create(field).Error; err != nil {
+		return fmt.Errorf("failed to create custom field: %w", err)
+	}
+	return nil
+}
+
+func (r *CustomFieldRepository) FindByID(id int) (*domain.CustomField, error) {
+	var field domain.CustomField
+	if err := r.db.First(&field, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to find custom field: %w", err)
+	}
+	return &field, nil
+}
+
+func (r *CustomFieldRepository) FindByWorkspaceID(workspaceID int) ([]domain.CustomField, error) {
+	var fields []domain.CustomField
+	if err := r.db.Where("workspace_id = ?", workspaceID).Order("name").Find(&fields).Error; err != nil {
+		return nil, fmt.Errorf("failed to find custom fields by workspace ID: %w", err)
+	}
+	return fields, nil
+}
+
+func (r *CustomFieldRepository) Update(field *domain.CustomField) error {
+	field.UpdatedAt = time.Now()
+	if err := r.db.Save(field).Error; err != nil {
+		return fmt.Errorf("failed to update custom field: %w", err)
+	}
+	return nil
+}
+
+func (r *CustomFieldRepository) Delete(id int) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("field_id = ?", id).Delete(&domain.CustomFieldValue{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.CustomField{}, id).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete custom field: %w", err)
+	}
+	return nil
+}
+
+// SetValue stores the value of a custom field on a task, replacing the previous one.
+func (r *CustomFieldRepository) SetValue(taskID int, value domain.CustomFieldValue) error {
+	value.TaskID = taskID
+	if err := r.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&value).Error; err != nil {
+		return fmt.Errorf("failed to set custom field value: %w", err)
+	}
+	return nil
+}
+
+func (r *CustomFieldRepository) ClearValue(taskID, fieldID int) error {
+	if err := r.db.Where("task_id = ? AND field_id = ?", taskID, fieldID).Delete(&domain.CustomFieldValue{}).Error; err != nil {
+		return fmt.Errorf("failed to clear custom field value: %w", err)
+	}
+	return nil
+}
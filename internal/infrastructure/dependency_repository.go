@@ -0,0 +1,41 @@
+package infrastructure
+
+import (
+	"fmt"
+	"task-manager-app/backend/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DependencyRepository struct {
+	db *gorm.DB
+}
+
+func NewDependencyRepository(db *gorm.DB) *DependencyRepository {
+	return &DependencyRepository{db: db}
+}
+
+func (r *DependencyRepository) Create(dependency *domain.TaskDependency) error {
+	dependency.CreatedAt = time.Now()
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(dependency).Error; err != nil {
+		return fmt.Errorf("failed to create dependency: %w", err)
+	}
+	return nil
+}
+
+func (r *DependencyRepository) Delete(taskID, blockedByID int) error {
+	if err := r.db.Where("task_id = ? AND blocked_by_id = ?", taskID, blockedByID).Delete(&domain.TaskDependency{}).Error; err != nil {
+		return fmt.Errorf("failed to delete dependency: %w", err)
+	}
+	return nil
+}
+
+func (r *DependencyRepository) FindByTaskIDs(taskIDs []int) ([]domain.TaskDependency, error) {
+	var dependencies []domain.TaskDependency
+	if err := r.db.Where("task_id IN ?", taskIDs).Order("id").Find(&dependencies).Error; err != nil {
+		return nil, fmt.Errorf("failed to find dependencies: %w", err)
+	}
+	return dependencies, nil
+}
@@ -0,0 +1,77 @@
+package infrastructure
+
+import (
+	"fmt"
+	"task-manager-app/backend/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type LabelRepository struct {
+	db *gorm.DB
+}
+
+func NewLabelRepository(db *gorm.DB) *LabelRepository {
+	return &LabelRepository{db: db}
+}
+
+func (r *LabelRepository) Create(label *domain.Label) error {
+	label.CreatedAt = time.Now()
+	label.UpdatedAt = time.Now()
+	if err := r.db.Create(label).Error; err != nil {
+		return fmt.Errorf("failed to create label: %w", err)
+	}
+	return nil
+}
+
+func (r *LabelRepository) FindByID(id int) (*domain.Label, error) {
+	var label domain.Label
+	if err := r.db.First(&label, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to find label: %w", err)
+	}
+	return &label, nil
+}
+
+func (r *LabelRepository) FindByWorkspaceID(workspaceID int) ([]domain.Label, error) {
+	var labels []domain.Label
+	if err := r.db.Where("workspace_id = ?", workspaceID).Order("name").Find(&labels).Error; err != nil {
+		return nil, fmt.Errorf("failed to find labels by workspace ID: %w", err)
+	}
+	return labels, nil
+}
+
+func (r *LabelRepository) Update(label *domain.Label) error {
+	label.UpdatedAt = time.Now()
+	if err := r.db.Save(label).Error; err != nil {
+		return fmt.Errorf("failed to update label: %w", err)
+	}
+	return nil
+}
+
+func (r *LabelRepository) Delete(id int) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM task_labels WHERE label_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Label{}, id).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete label: %w", err)
+	}
+	return nil
+}
+
+func (r *LabelRepository) AddToTask(taskID, labelID int) error {
+	if err := r.db.Exec("INSERT INTO task_labels (task_id, label_id) VALUES (?, ?) ON CONFLICT DO NOTHING", taskID, labelID).Error; err != nil {
+		return fmt.Errorf("failed to add label to task: %w", err)
+	}
+	return nil
+}
+
+func (r *LabelRepository) RemoveFromTask(taskID, labelID int) error {
+	if err := r.db.Exec("DELETE FROM task_labels WHERE task_id = ? AND label_id = ?", taskID, labelID).Error; err != nil {
+		return fmt.Errorf("failed to remove label from task: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package infrastructure
+
+import (
+	"fmt"
+	"task-manager-app/backend/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ProjectRepository struct {
+	db *gorm.DB
+}
+
+func NewProjectRepository(db *gorm.DB) *ProjectRepository {
+	return &ProjectRepository{db: db}
+}
+
+func (r *ProjectRepository) Create(project *domain.Project) error {
+	project.CreatedAt = time.Now()
+	project.UpdatedAt = time.Now()
+	if err := r.db.Create(project).Error; err != nil {
+		return fmt.Errorf("failed to create project: %w", err)
+	}
+	return nil
+}
+
+func (r *ProjectRepository) FindByID(id int) (*domain.Project, error) {
+	var project domain.Project
+	if err := r.db.First(&project, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to find project: %w", err)
+	}
+	return &project, nil
+}
+
+func (r *ProjectRepository) FindAll(filter domain.ProjectFilter) ([]domain.Project, error) {
+	var projects []domain.Project
+	query := r.db.Model(&domain.Project{})
+
+	if filter.OwnerID != 0 {
+		query = query.Where("owner_id = ?", filter.OwnerID)
+	}
+
+	if filter.WorkspaceID != 0 {
+		query = query.Where("workspace_id = ?", filter.WorkspaceID)
+	}
+
+	if !filter.IncludeArchived {
+		query = query.Where("archived = ?", false)
+	}
+
+	if err := query.Order("name").Order("id").Find(&projects).Error; err != nil {
+		return nil, fmt.Errorf("failed to find projects: %w", err)
+	}
+	return projects, nil
+}
+
+func (r *ProjectRepository) Update(project *domain.Project) error {
+	project.UpdatedAt = time.Now()
+	if err := r.db.Save(project).Error; err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the project with its board and sprints and detaches its tasks, leaving them
+// without a project. The detached tasks get an activity entry on behalf of actor.
+func (r *ProjectRepository) Delete(id int, actor domain.Actor) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var tasks []domain.Task
+		if err := tx.Where("project_id = ?", id).Find(&tasks).Error; err != nil {
+			return err
+		}
+		detached := func(task *domain.Task) {
+			task.ProjectID, task.ColumnID, task.Rank, task.SprintID = 0, 0, "", 0
+		}
+		if err := recordBulkUpdate(tx, tasks, actor, detached); err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.Task{}).Where("project_id = ?", id).Updates(map[string]interface{}{"project_id": 0, "column_id": 0, "rank": "", "sprint_id": 0}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("project_id = ?", id).Delete(&domain.Sprint{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("project_id = ?", id).Delete(&domain.BoardColumn{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Project{}, id).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+	return nil
+}
+
+// MoveTasks moves every given task into the project, or none of them if any task is missing,
+// and records the move in the activity of each task that changes project.
+func (r *ProjectRepository) MoveTasks(taskIDs []int, projectID int, actor domain.Actor) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var tasks []domain.Task
+		if err := tx.Where("id IN ?", taskIDs).Find(&tasks).Error; err != nil {
+			return fmt.Errorf("failed to move tasks: %w", err)
+		}
+		if len(tasks) != len(taskIDs) {
+			return fmt.Errorf("failed to move tasks: %w", gorm.ErrRecordNotFound)
+		}
+		moved := func(task *domain.Task) {
+			if task.ProjectID != projectID {
+				task.ProjectID, task.ColumnID, task.Rank, task.SprintID = projectID, 0, "", 0
+			}
+		}
+		if err := recordBulkUpdate(tx, tasks, actor, moved); err != nil {
+			return fmt.Errorf("failed to move tasks: %w", err)
+		}
+		result := tx.Model(&domain.Task{}).Where("id IN ?", taskIDs).Updates(map[string]interface{}{
+			"project_id": projectID,
+			// Board placement and sprints do not carry over to another project
+			"column_id":  gorm.Expr("CASE WHEN project_id = ? THEN column_id ELSE 0 END", projectID),
+			"rank":       gorm.Expr("CASE WHEN project_id = ? THEN rank ELSE '' END", projectID),
+			"sprint_id":  gorm.Expr("CASE WHEN project_id = ? THEN sprint_id ELSE 0 END", projectID),
+			"updated_at": time.Now(),
+		})
+		if result.Error != nil {
+			return fmt.Errorf("failed to move tasks: %w", result.Error)
+		}
+		return nil
+	})
+}
+
+func (r *ProjectRepository) HasTasks(id int) (bool, error) {
+	var count int64
+	if err := r.db.Unscoped().Model(&domain.Task{}).Where("project_id = ?", id).Limit(1).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to count project tasks: %w", err)
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,75 @@
+package infrastructure
+
+import (
+	"fmt"
+	"task-manager-app/backend/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{db: db}
+}
+
+func (r *RefreshTokenRepository) Create(token *domain.RefreshToken) error {
+	token.CreatedAt = time.Now()
+	if err := r.db.Create(token).Error; err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepository) FindByHash(hash string) (*domain.RefreshToken, error) {
+	var token domain.RefreshToken
+	if err := r.db.Where("token_hash = ?", hash).First(&token).Error; err != nil {
+		return nil, fmt.Errorf("failed to find refresh token: %w", err)
+	}
+	return &token, nil
+}
+
+func (r *RefreshTokenRepository) Rotate(used, next *domain.RefreshToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		// Only one of two concurrent refreshes with the same token gets to mark it used
+		result := tx.Model(&domain.RefreshToken{}).Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", used.ID).Update("used_at", now)
+		if result.Error != nil {
+			return fmt.Errorf("failed to rotate refresh token: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrRefreshTokenReused
+		}
+		used.UsedAt = &now
+		next.CreatedAt = now
+		if err := tx.Create(next).Error; err != nil {
+			return fmt.Errorf("failed to rotate refresh token: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *RefreshTokenRepository) RevokeFamily(familyID string) error {
+	if err := r.db.Model(&domain.RefreshToken{}).Where("family_id = ? AND revoked_at IS NULL", familyID).Update("revoked_at", time.Now()).Error; err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeUser(userID int) error {
+	if err := r.db.Model(&domain.RefreshToken{}).Where("user_id = ? AND revoked_at IS NULL", userID).Update("revoked_at", time.Now()).Error; err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepository) DeleteExpiredBefore(cutoff time.Time) (int, error) {
+	result := r.db.Where("expires_at < ?", cutoff).Delete(&domain.RefreshToken{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete expired refresh tokens: %w", result.Error)
+	}
+	return int(result.RowsAffected), nil
+}
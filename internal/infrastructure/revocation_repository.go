@@ -0,0 +1,40 @@
+package infrastructure
+
+import (
+	"fmt"
+	"task-manager-app/backend/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RevocationRepository struct {
+	db *gorm.DB
+}
+
+func NewRevocationRepository(db *gorm.DB) *RevocationRepository {
+	return &RevocationRepository{db: db}
+}
+
+func (r *RevocationRepository) Create(entry *domain.RevokedToken) error {
+	if err := r.db.Create(entry).Error; err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+	return nil
+}
+
+func (r *RevocationRepository) FindActive(now time.Time) ([]domain.RevokedToken, error) {
+	var entries []domain.RevokedToken
+	if err := r.db.Where("expires_at >= ?", now).Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to find revoked tokens: %w", err)
+	}
+	return entries, nil
+}
+
+func (r *RevocationRepository) DeleteExpiredBefore(cutoff time.Time) (int, error) {
+	result := r.db.Where("expires_at < ?", cutoff).Delete(&domain.RevokedToken{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete expired revoked tokens: %w", result.Error)
+	}
+	return int(result.RowsAffected), nil
+}
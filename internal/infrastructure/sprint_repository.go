@@ -0,0 +1,97 @@
+package infrastructure
+
+import (
+	"fmt"
+	"strconv"
+	"task-manager-app/backend/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type SprintRepository struct {
+	db *gorm.DB
+}
+
+func NewSprintRepository(db *gorm.DB) *SprintRepository {
+	return &SprintRepository{db: db}
+}
+
+func (r *SprintRepository) Create(sprint *domain.Sprint) error {
+	sprint.CreatedAt = time.Now()
+	sprint.UpdatedAt = time.Now()
+	if err := r.db.Create(sprint).Error; err != nil {
+		return fmt.Errorf("failed to create sprint: %w", err)
+	}
+	return nil
+}
+
+func (r *SprintRepository) FindByID(id int) (*domain.Sprint, error) {
+	var sprint domain.Sprint
+	if err := r.db.First(&sprint, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to find sprint: %w", err)
+	}
+	return &sprint, nil
+}
+
+func (r *SprintRepository) FindByProjectID(projectID int) ([]domain.Sprint, error) {
+	var sprints []domain.Sprint
+	if err := r.db.Where("project_id = ?", projectID).Order("start_date").Order("id").Find(&sprints).Error; err != nil {
+		return nil, fmt.Errorf("failed to find sprints by project ID: %w", err)
+	}
+	return sprints, nil
+}
+
+func (r *SprintRepository) Update(sprint *domain.Sprint) error {
+	sprint.UpdatedAt = time.Now()
+	if err := r.db.Save(sprint).Error; err != nil {
+		return fmt.Errorf("failed to update sprint: %w", err)
+	}
+	return nil
+}
+
+func (r *SprintRepository) Delete(id int, actor domain.Actor) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Trashed tasks too, so they do not point to a missing sprint when restored
+		var tasks []domain.Task
+		if err := tx.Unscoped().Where("sprint_id = ?", id).Find(&tasks).Error; err != nil {
+			return err
+		}
+		// Burndowns replay the activity log, so the tasks leaving must show up there
+		if err := recordBulkUpdate(tx, tasks, actor, func(task *domain.Task) { task.SprintID = 0 }); err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&domain.Task{}).Where("sprint_id = ?", id).Update("sprint_id", 0).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Sprint{}, id).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete sprint: %w", err)
+	}
+	return nil
+}
+
+func (r *SprintRepository) FindHistory(sprintID int) ([]domain.Task, []domain.Activity, error) {
+	// Changes are stored as JSON such as {"field":"sprintId","old":"","new":"3"}. The second
+	// pattern may also match unrelated entries, which only adds tasks the replay skips.
+	id := strconv.Itoa(sprintID)
+	joined := r.db.Model(&domain.Activity{}).Select("task_id").
+		Where("changes LIKE ? OR changes LIKE ?", `%{"field":"sprintId","old":"`+id+`",%`, `%{"field":"sprintId","old":"%","new":"`+id+`"}%`)
+
+	var tasks []domain.Task
+	if err := r.db.Where("sprint_id = ? OR id IN (?)", sprintID, joined).Order("id").Find(&tasks).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to find sprint tasks: %w", err)
+	}
+	ids := make([]int, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	var activities []domain.Activity
+	if len(ids) > 0 {
+		if err := r.db.Where("task_id IN ?", ids).Order("created_at").Order("id").Find(&activities).Error; err != nil {
+			return nil, nil, fmt.Errorf("failed to find sprint activity: %w", err)
+		}
+	}
+	return tasks, activities, nil
+}
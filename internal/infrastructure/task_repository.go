@@ -1,11 +1,15 @@
 package infrastructure
 
 import (
+	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 	"task-manager-app/backend/internal/domain"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TaskRepository struct {
@@ -19,15 +23,127 @@ func NewTaskRepository(db *gorm.DB) *TaskRepository {
 func (r *TaskRepository) Create(task *domain.Task) error {
 	task.CreatedAt = time.Now()
 	task.UpdatedAt = time.Now()
-	if err := r.db.Create(task).Error; err != nil {
+	if err := r.db.Omit(clause.Associations).Create(task).Error; err != nil {
 		return fmt.Errorf("failed to create task: %w", err)
 	}
 	return nil
 }
 
+func (r *TaskRepository) Apply(writes []domain.TaskWrite) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		for _, write := range writes {
+			task := write.Task
+			task.UpdatedAt = now
+			if write.Create {
+				task.CreatedAt = now
+				if err := tx.Omit(clause.Associations).Create(task).Error; err != nil {
+					return err
+				}
+				// Users deleted in the meantime are left out
+				for table, users := range map[string][]domain.User{"task_assignees": task.Assignees, "task_watchers": task.Watchers} {
+					for _, user := range users {
+						if err := tx.Exec("INSERT INTO "+table+" (task_id, user_id) SELECT ?, id FROM users WHERE id = ? AND deleted_at IS NULL ON CONFLICT DO NOTHING", task.ID, user.ID).Error; err != nil {
+							return err
+						}
+					}
+				}
+			} else if err := tx.Omit(clause.Associations).Save(task).Error; err != nil {
+				return err
+			}
+			if write.Activity != nil {
+				write.Activity.TaskID, write.Activity.CreatedAt = task.ID, now
+				if err := tx.Create(write.Activity).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save tasks: %w", err)
+	}
+	return nil
+}
+
+// UpdateRank places a task in a board column without touching its other fields.
+func (r *TaskRepository) UpdateRank(id, columnID int, rank string) error {
+	if err := r.db.Model(&domain.Task{}).Where("id = ?", id).Updates(map[string]interface{}{"column_id": columnID, "rank": rank}).Error; err != nil {
+		return fmt.Errorf("failed to update task rank: %w", err)
+	}
+	return nil
+}
+
+// Rerank stores new ranks for a set of tasks at once.
+func (r *TaskRepository) Rerank(ranks []domain.TaskRank) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, rank := range ranks {
+			if err := tx.Model(&domain.Task{}).Where("id = ?", rank.ID).Update("rank", rank.Rank).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to rerank tasks: %w", err)
+	}
+	return nil
+}
+
+// UpdateSprint moves every given task into the sprint, or none of them if any task is missing.
+func (r *TaskRepository) UpdateSprint(ids []int, sprintID int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Task{}).Where("id IN ?", ids).Updates(map[string]interface{}{"sprint_id": sprintID, "updated_at": time.Now()})
+		if result.Error != nil {
+			return fmt.Errorf("failed to update task sprint: %w", result.Error)
+		}
+		if int(result.RowsAffected) != len(ids) {
+			return fmt.Errorf("failed to update task sprint: %w", gorm.ErrRecordNotFound)
+		}
+		return nil
+	})
+}
+
+// CreateTree creates a task, its labels and checklist and all its subtasks in one transaction.
+func (r *TaskRepository) CreateTree(tree *domain.TaskTree) error {
+	if err := r.db.Transaction(func(tx *gorm.DB) error { return createTree(tx, tree, time.Now()) }); err != nil {
+		return fmt.Errorf("failed to create task tree: %w", err)
+	}
+	return nil
+}
+
+func createTree(tx *gorm.DB, tree *domain.TaskTree, now time.Time) error {
+	task := tree.Task
+	task.CreatedAt, task.UpdatedAt = now, now
+	if err := tx.Omit(clause.Associations).Create(task).Error; err != nil {
+		return err
+	}
+	// Labels deleted since the tree was planned are skipped
+	for _, label := range task.Labels {
+		if err := tx.Exec("INSERT INTO task_labels (task_id, label_id) SELECT ?, id FROM labels WHERE id = ? ON CONFLICT DO NOTHING", task.ID, label.ID).Error; err != nil {
+			return err
+		}
+	}
+	for i := range task.Checklist {
+		task.Checklist[i].TaskID = task.ID
+	}
+	if len(task.Checklist) > 0 {
+		if err := tx.Create(&task.Checklist).Error; err != nil {
+			return err
+		}
+	}
+	for _, subtask := range tree.Subtasks {
+		subtask.Task.ParentID = task.ID
+		if err := createTree(tx, subtask, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *TaskRepository) FindByID(id int) (*domain.Task, error) {
 	var task domain.Task
-	if err := r.db.First(&task, id).Error; err != nil {
+	if err := r.db.Preload("Labels").Preload("Assignees").Preload("Watchers").Preload("Checklist", orderedChecklist).Preload("CustomFields").First(&task, id).Error; err != nil {
 		return nil, fmt.Errorf("failed to find task: %w", err)
 	}
 	return &task, nil
@@ -42,16 +158,101 @@ func (r *TaskRepository) FindAll(filter domain.TaskFilter) (*domain.TaskConnecti
 		query = query.Where("title LIKE ?", "%"+filter.Search+"%")
 	}
 
+	if filter.ProjectID != 0 {
+		query = query.Where("project_id = ?", filter.ProjectID)
+	}
+
+	if filter.DueBefore != nil {
+		query = query.Where("due_at < ?", filter.DueBefore.UTC())
+	}
+
+	if filter.DueAfter != nil {
+		query = query.Where("due_at > ?", filter.DueAfter.UTC())
+	}
+
+	if filter.Overdue != nil {
+		now := time.Now().UTC()
+		if *filter.Overdue {
+			query = query.Where("due_at IS NOT NULL AND due_at < ? AND is_completed = ?", now, false)
+		} else {
+			query = query.Where("(due_at IS NULL OR due_at >= ? OR is_completed = ?)", now, true)
+		}
+	}
+
+	if len(filter.LabelsAny) > 0 {
+		query = query.Where("id IN (?)", r.db.Table("task_labels").Select("task_id").Where("label_id IN ?", filter.LabelsAny))
+	}
+
+	if len(filter.LabelsAll) > 0 {
+		query = query.Where("id IN (?)", r.db.Table("task_labels").Select("task_id").
+			Where("label_id IN ?", filter.LabelsAll).
+			Group("task_id").
+			Having("COUNT(DISTINCT label_id) = ?", len(filter.LabelsAll)))
+	}
+
+	if filter.CreatorID != 0 {
+		query = query.Where("user_id = ?", filter.CreatorID)
+	}
+
+	if filter.AssigneeID != 0 {
+		query = query.Where("id IN (?)", r.db.Table("task_assignees").Select("task_id").Where("user_id = ?", filter.AssigneeID))
+	}
+
+	if filter.WatcherID != 0 {
+		query = query.Where("id IN (?)", r.db.Table("task_watchers").Select("task_id").Where("user_id = ?", filter.WatcherID))
+	}
+
+	if !filter.IncludeArchived {
+		query = query.Where("archived_at IS NULL")
+	}
+
+	// Rows from before statuses existed only carry the completion flag
+	switch filter.Status {
+	case "":
+	case domain.StatusTodo, domain.StatusDone:
+		query = query.Where("(status = ? OR (status = '' AND is_completed = ?))", filter.Status, filter.Status == domain.StatusDone)
+	default:
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	if len(filter.ColumnIDs) > 0 {
+		query = query.Where("column_id IN ?", filter.ColumnIDs)
+	}
+
+	if filter.SprintID != 0 {
+		query = query.Where("sprint_id = ?", filter.SprintID)
+	}
+
+	if filter.Access != nil {
+		query = query.Where(r.accessScope(filter.Access))
+	}
+
+	for _, cf := range filter.CustomFields {
+		values, err := r.customFieldMatches(cf, filter.Access)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("id IN (?)", values)
+	}
+
 	if err := query.Count(&count).Error; err != nil {
 		return nil, fmt.Errorf("failed to count tasks: %w", err)
 	}
 
+	customColumns, err := r.customSortColumns(filter.Sort, filter.Access)
+	if err != nil {
+		return nil, err
+	}
+	for _, order := range taskOrderClauses(filter.Sort, customColumns) {
+		query = query.Order(order)
+	}
+
 	if filter.Page > 0 && filter.Limit > 0 {
 		offset := (filter.Page - 1) * filter.Limit
 		query = query.Offset(offset).Limit(filter.Limit)
 	}
 
-	if err := query.Find(&tasks).Error; err != nil {
+	if err := query.Preload("Labels").Preload("Assignees").Preload("Watchers").Preload("Checklist", orderedChecklist).Preload("CustomFields").Find(&tasks).Error; err != nil {
 		return nil, fmt.Errorf("failed to find tasks: %w", err)
 	}
 
@@ -71,19 +272,168 @@ func (r *TaskRepository) FindAll(filter domain.TaskFilter) (*domain.TaskConnecti
 
 func (r *TaskRepository) Update(task *domain.Task) error {
 	task.UpdatedAt = time.Now()
-	if err := r.db.Save(task).Error; err != nil {
+	if err := r.db.Omit(clause.Associations).Save(task).Error; err != nil {
 		return fmt.Errorf("failed to update task: %w", err)
 	}
 	return nil
 }
 
-func (r *TaskRepository) Delete(id int) error {
-	if err := r.db.Delete(&domain.Task{}, id).Error; err != nil {
+// Delete moves a task to the trash and applies the policy to its subtasks in the same transaction.
+// Cascaded subtasks share the task's deletion time, which is how Restore and Purge find them again.
+func (r *TaskRepository) Delete(id int, policy domain.DeletePolicy) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var task domain.Task
+		if err := tx.First(&task, id).Error; err != nil {
+			return err
+		}
+
+		ids := []int{id}
+		if policy == domain.DeleteCascade {
+			descendants, err := findDescendants(tx, id)
+			if err != nil {
+				return err
+			}
+			for _, d := range descendants {
+				ids = append(ids, d.ID)
+			}
+		} else if err := tx.Model(&domain.Task{}).Where("parent_id = ?", id).Update("parent_id", task.ParentID).Error; err != nil {
+			return err
+		}
+		return tx.Model(&domain.Task{}).Where("id IN ?", ids).Update("deleted_at", time.Now()).Error
+	})
+	if err != nil {
 		return fmt.Errorf("failed to delete task: %w", err)
 	}
 	return nil
 }
 
+// FindDeleted lists the trash, most recently deleted first.
+func (r *TaskRepository) FindDeleted(filter domain.TaskFilter) (*domain.TaskConnection, error) {
+	var tasks []domain.Task
+	var count int64
+	query := r.db.Unscoped().Model(&domain.Task{}).Where("deleted_at IS NOT NULL")
+	if filter.Search != "" {
+		query = query.Where("title LIKE ?", "%"+filter.Search+"%")
+	}
+	if filter.CreatorID != 0 {
+		query = query.Where("user_id = ?", filter.CreatorID)
+	}
+	if filter.Access != nil {
+		query = query.Where(r.accessScope(filter.Access))
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return nil, fmt.Errorf("failed to count deleted tasks: %w", err)
+	}
+	query = query.Order("deleted_at DESC").Order("id ASC")
+	if filter.Page > 0 && filter.Limit > 0 {
+		query = query.Offset((filter.Page - 1) * filter.Limit).Limit(filter.Limit)
+	}
+	if err := query.Preload("Labels").Find(&tasks).Error; err != nil {
+		return nil, fmt.Errorf("failed to find deleted tasks: %w", err)
+	}
+
+	connection := &domain.TaskConnection{Edges: make([]domain.TaskEdge, len(tasks))}
+	for i, task := range tasks {
+		connection.Edges[i] = domain.TaskEdge{Node: task}
+	}
+	connection.PageInfo.TotalCount = int(count)
+	connection.PageInfo.HasNextPage = filter.Limit > 0 && filter.Page*filter.Limit < int(count)
+	connection.PageInfo.HasPreviousPage = filter.Page > 1
+	return connection, nil
+}
+
+func (r *TaskRepository) FindDeletedByID(id int) (*domain.Task, error) {
+	var task domain.Task
+	if err := r.db.Unscoped().Where("deleted_at IS NOT NULL").Preload("Assignees").Preload("Watchers").First(&task, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to find deleted task: %w", err)
+	}
+	return &task, nil
+}
+
+func (r *TaskRepository) Restore(id int) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		ids, task, err := trashedTree(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&domain.Task{}).Where("id IN ?", ids).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		// A parent that is still in the trash or gone for good leaves the task at the root
+		if task.ParentID != 0 {
+			var parents int64
+			if err := tx.Model(&domain.Task{}).Where("id = ?", task.ParentID).Count(&parents).Error; err != nil {
+				return err
+			}
+			if parents == 0 {
+				return tx.Model(&domain.Task{}).Where("id = ?", id).Update("parent_id", 0).Error
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore task: %w", err)
+	}
+	return nil
+}
+
+func (r *TaskRepository) Purge(id int) ([]domain.Attachment, error) {
+	var attachments []domain.Attachment
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		ids, _, err := trashedTree(tx, id)
+		if err != nil {
+			return err
+		}
+		attachments, err = purgeTasks(tx, ids)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge task: %w", err)
+	}
+	return attachments, nil
+}
+
+func (r *TaskRepository) PurgeDeletedBefore(cutoff time.Time) ([]domain.Attachment, error) {
+	var attachments []domain.Attachment
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var ids []int
+		if err := tx.Unscoped().Model(&domain.Task{}).Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		var err error
+		attachments, err = purgeTasks(tx, ids)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge expired tasks: %w", err)
+	}
+	return attachments, nil
+}
+
+// ArchiveStale archives, in one statement, the tasks whose status is one of the rule's
+// and has not changed since before now minus the rule's delay.
+func (r *TaskRepository) ArchiveStale(rule domain.ArchiveRule, now time.Time) ([]int, error) {
+	var ids []int
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Task{}).
+			Where("archived_at IS NULL AND status IN ? AND COALESCE(status_changed_at, updated_at) < ?", rule.Statuses, now.Add(-rule.After)).
+			Order("id").Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		return tx.Model(&domain.Task{}).Where("id IN ?", ids).Updates(map[string]interface{}{"archived_at": now, "updated_at": now}).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive tasks: %w", err)
+	}
+	return ids, nil
+}
+
 func (r *TaskRepository) FindByUserID(userID int) ([]domain.Task, error) {
 	var tasks []domain.Task
 	if err := r.db.Where("user_id = ?", userID).Find(&tasks).Error; err != nil {
@@ -91,3 +441,276 @@ func (r *TaskRepository) FindByUserID(userID int) ([]domain.Task, error) {
 	}
 	return tasks, nil
 }
+
+func (r *TaskRepository) AddAssignee(taskID, userID int) error {
+	if err := r.addMember("task_assignees", taskID, userID); err != nil {
+		return fmt.Errorf("failed to assign task: %w", err)
+	}
+	return nil
+}
+
+func (r *TaskRepository) RemoveAssignee(taskID, userID int) error {
+	if err := r.db.Exec("DELETE FROM task_assignees WHERE task_id = ? AND user_id = ?", taskID, userID).Error; err != nil {
+		return fmt.Errorf("failed to unassign task: %w", err)
+	}
+	return nil
+}
+
+func (r *TaskRepository) AddWatcher(taskID, userID int) error {
+	if err := r.addMember("task_watchers", taskID, userID); err != nil {
+		return fmt.Errorf("failed to watch task: %w", err)
+	}
+	return nil
+}
+
+func (r *TaskRepository) RemoveWatcher(taskID, userID int) error {
+	if err := r.db.Exec("DELETE FROM task_watchers WHERE task_id = ? AND user_id = ?", taskID, userID).Error; err != nil {
+		return fmt.Errorf("failed to unwatch task: %w", err)
+	}
+	return nil
+}
+
+// addMember links a user to a task through one of the membership join tables, checking the user exists.
+func (r *TaskRepository) addMember(table string, taskID, userID int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&domain.User{}, userID).Error; err != nil {
+			return err
+		}
+		return tx.Exec("INSERT INTO "+table+" (task_id, user_id) VALUES (?, ?) ON CONFLICT DO NOTHING", taskID, userID).Error
+	})
+}
+
+var taskSortColumns = map[domain.TaskSortField]string{
+	domain.SortByPriority:  "priority",
+	domain.SortByDueAt:     "due_at",
+	domain.SortByCreatedAt: "created_at",
+	domain.SortByTitle:     "title",
+	domain.SortByRank:      "rank",
+}
+
+// taskOrderClauses turns a sort specification into ORDER BY clauses. Tasks without a due date
+// or without a value for a sorted custom field always come last, and the ID is appended as a
+// tiebreaker so pagination stays stable. customColumns maps custom field IDs to their value column.
+func taskOrderClauses(sorts []domain.TaskSort, customColumns map[int]string) []string {
+	var clauses []string
+	for _, sort := range sorts {
+		direction := "ASC"
+		if sort.Desc {
+			direction = "DESC"
+		}
+		if sort.Field == domain.SortByCustomField {
+			value := fmt.Sprintf("(SELECT %s FROM custom_field_values WHERE custom_field_values.task_id = tasks.id AND custom_field_values.field_id = %d)",
+				customColumns[sort.FieldID], sort.FieldID)
+			clauses = append(clauses, value+" IS NULL", value+" "+direction)
+			continue
+		}
+		column, ok := taskSortColumns[sort.Field]
+		if !ok {
+			continue
+		}
+		if sort.Field == domain.SortByDueAt {
+			clauses = append(clauses, "due_at IS NULL")
+		}
+		if sort.Field == domain.SortByRank {
+			clauses = append(clauses, "rank = ''")
+		}
+		clauses = append(clauses, column+" "+direction)
+	}
+	return append(clauses, "id ASC")
+}
+
+// visibleCustomField finds a custom field the tasks may be filtered or sorted by: a shared
+// one or one of a workspace the access allows, so fields of other workspaces stay unknown.
+func (r *TaskRepository) visibleCustomField(id int, access *domain.TaskAccess) (*domain.CustomField, bool) {
+	var field domain.CustomField
+	if err := r.db.First(&field, id).Error; err != nil {
+		return nil, false
+	}
+	if access == nil || field.WorkspaceID == 0 || slices.Contains(access.WorkspaceIDs, field.WorkspaceID) {
+		return &field, true
+	}
+	return nil, false
+}
+
+// customFieldMatches selects the IDs of the tasks whose value of a custom field passes the filter.
+func (r *TaskRepository) customFieldMatches(filter domain.CustomFieldFilter, access *domain.TaskAccess) (*gorm.DB, error) {
+	field, ok := r.visibleCustomField(filter.FieldID, access)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown field %d", domain.ErrInvalidFieldFilter, filter.FieldID)
+	}
+	column := field.ValueColumn()
+	query := r.db.Model(&domain.CustomFieldValue{}).Select("task_id").Where("field_id = ?", field.ID)
+	if filter.Equals != "" {
+		if field.Type == domain.FieldMultiSelect {
+			// Options are stored as a JSON array, so the quoted option matches whole elements only
+			option, _ := json.Marshal(filter.Equals)
+			query = query.Where(`options LIKE ? ESCAPE '\'`, "%"+escapeLike(string(option))+"%")
+		} else {
+			value, err := field.ParseFilterValue(filter.Equals)
+			if err != nil {
+				return nil, err
+			}
+			query = query.Where(column+" = ?", value)
+		}
+	}
+	bounds := []struct{ value, operator string }{{filter.Min, ">="}, {filter.Max, "<="}}
+	for _, bound := range bounds {
+		if bound.value == "" {
+			continue
+		}
+		if field.Type != domain.FieldNumber && field.Type != domain.FieldDate && field.Type != domain.FieldText {
+			return nil, domain.ErrInvalidFieldFilter
+		}
+		value, err := field.ParseFilterValue(bound.value)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(column+" "+bound.operator+" ?", value)
+	}
+	return query, nil
+}
+
+// customSortColumns looks up the value column of each custom field the tasks are sorted by.
+func (r *TaskRepository) customSortColumns(sorts []domain.TaskSort, access *domain.TaskAccess) (map[int]string, error) {
+	columns := map[int]string{}
+	for _, sort := range sorts {
+		if sort.Field != domain.SortByCustomField {
+			continue
+		}
+		field, ok := r.visibleCustomField(sort.FieldID, access)
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown custom field %d", domain.ErrInvalidSort, sort.FieldID)
+		}
+		columns[field.ID] = field.ValueColumn()
+	}
+	return columns, nil
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern, for use with ESCAPE '\'.
+var escapeLike = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace
+
+func (r *TaskRepository) FindChildren(parentID int) ([]domain.Task, error) {
+	var tasks []domain.Task
+	if err := r.db.Preload("Labels").Preload("Assignees").Preload("Watchers").Preload("Checklist", orderedChecklist).Preload("CustomFields").Where("parent_id = ?", parentID).Order("id").Find(&tasks).Error; err != nil {
+		return nil, fmt.Errorf("failed to find subtasks: %w", err)
+	}
+	return tasks, nil
+}
+
+func (r *TaskRepository) FindDescendants(id int) ([]domain.Task, error) {
+	tasks, err := findDescendants(r.db, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find descendants: %w", err)
+	}
+	return tasks, nil
+}
+
+func (r *TaskRepository) FindBlockers(taskID int) ([]domain.Task, error) {
+	var tasks []domain.Task
+	blockers := r.db.Model(&domain.TaskDependency{}).Select("blocked_by_id").Where("task_id = ?", taskID)
+	if err := r.db.Where("id IN (?)", blockers).Order("id").Find(&tasks).Error; err != nil {
+		return nil, fmt.Errorf("failed to find blocking tasks: %w", err)
+	}
+	return tasks, nil
+}
+
+// orderedChecklist preloads checklist items in the order the user arranged them.
+func orderedChecklist(db *gorm.DB) *gorm.DB {
+	return db.Order("position").Order("id")
+}
+
+// accessScope matches the tasks a user may see: those of their workspaces and the personal
+// tasks they created, are assigned to or watch.
+func (r *TaskRepository) accessScope(access *domain.TaskAccess) *gorm.DB {
+	scope := r.db.Where("workspace_id = 0 AND (user_id = ? OR id IN (?) OR id IN (?))", access.UserID,
+		r.db.Table("task_assignees").Select("task_id").Where("user_id = ?", access.UserID),
+		r.db.Table("task_watchers").Select("task_id").Where("user_id = ?", access.UserID))
+	if len(access.WorkspaceIDs) > 0 {
+		scope = scope.Or("workspace_id IN ?", access.WorkspaceIDs)
+	}
+	return scope
+}
+
+// trashedTree returns a trashed task and the IDs of it and the subtasks deleted together with it.
+func trashedTree(tx *gorm.DB, id int) ([]int, *domain.Task, error) {
+	var task domain.Task
+	if err := tx.Unscoped().Where("deleted_at IS NOT NULL").First(&task, id).Error; err != nil {
+		return nil, nil, err
+	}
+	descendants, err := findDescendants(tx.Unscoped(), id)
+	if err != nil {
+		return nil, nil, err
+	}
+	ids := []int{id}
+	for _, d := range descendants {
+		if d.DeletedAt.Valid && d.DeletedAt.Time.Equal(task.DeletedAt.Time) {
+			ids = append(ids, d.ID)
+		}
+	}
+	return ids, &task, nil
+}
+
+// purgeTasks permanently removes tasks with their labels, members, dependencies, comments,
+// history and attachments, returning the attachments so their content can be deleted.
+func purgeTasks(tx *gorm.DB, ids []int) ([]domain.Attachment, error) {
+	for _, table := range []string{"task_labels", "task_assignees", "task_watchers"} {
+		if err := tx.Exec("DELETE FROM "+table+" WHERE task_id IN ?", ids).Error; err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Where("task_id IN ? OR blocked_by_id IN ?", ids, ids).Delete(&domain.TaskDependency{}).Error; err != nil {
+		return nil, err
+	}
+	var commentIDs []int
+	if err := tx.Model(&domain.Comment{}).Where("task_id IN ?", ids).Pluck("id", &commentIDs).Error; err != nil {
+		return nil, err
+	}
+	if len(commentIDs) > 0 {
+		if err := deleteComments(tx, commentIDs); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Where("task_id IN ?", ids).Delete(&domain.Activity{}).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("task_id IN ?", ids).Delete(&domain.TimeEntry{}).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("task_id IN ?", ids).Delete(&domain.ChecklistItem{}).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("task_id IN ?", ids).Delete(&domain.CustomFieldValue{}).Error; err != nil {
+		return nil, err
+	}
+	var attachments []domain.Attachment
+	if err := tx.Where("task_id IN ?", ids).Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("task_id IN ?", ids).Delete(&domain.Attachment{}).Error; err != nil {
+		return nil, err
+	}
+	return attachments, tx.Unscoped().Delete(&domain.Task{}, ids).Error
+}
+
+// findDescendants walks the task tree one level at a time, returning every task below id.
+func findDescendants(db *gorm.DB, id int) ([]domain.Task, error) {
+	var descendants []domain.Task
+	seen := map[int]bool{id: true}
+	level := []int{id}
+	for len(level) > 0 {
+		var children []domain.Task
+		if err := db.Where("parent_id IN ?", level).Find(&children).Error; err != nil {
+			return nil, err
+		}
+		level = level[:0]
+		for _, child := range children {
+			if seen[child.ID] {
+				continue
+			}
+			seen[child.ID] = true
+			descendants = append(descendants, child)
+			level = append(level, child.ID)
+		}
+	}
+	return descendants, nil
+}
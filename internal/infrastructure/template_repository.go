@@ -0,0 +1,57 @@
+package infrastructure
+
+import (
+	"fmt"
+	"task-manager-app/backend/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewTemplateRepository(db *gorm.DB) *TemplateRepository {
+	return &TemplateRepository{db: db}
+}
+
+func (r *TemplateRepository) Create(template *domain.TaskTemplate) error {
+	template.CreatedAt = time.Now()
+	template.UpdatedAt = time.Now()
+	if err := r.db.Create(template).Error; err != nil {
+		return fmt.Errorf("failed to create template: %w", err)
+	}
+	return nil
+}
+
+func (r *TemplateRepository) FindByID(id int) (*domain.TaskTemplate, error) {
+	var template domain.TaskTemplate
+	if err := r.db.First(&template, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to find template: %w", err)
+	}
+	return &template, nil
+}
+
+func (r *TemplateRepository) FindByWorkspaceID(workspaceID int) ([]domain.TaskTemplate, error) {
+	var templates []domain.TaskTemplate
+	if err := r.db.Where("workspace_id = ?", workspaceID).Order("name").Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("failed to find templates by workspace ID: %w", err)
+	}
+	return templates, nil
+}
+
+func (r *TemplateRepository) Update(template *domain.TaskTemplate) error {
+	template.UpdatedAt = time.Now()
+	if err := r.db.Save(template).Error; err != nil {
+		return fmt.Errorf("failed to update template: %w", err)
+	}
+	return nil
+}
+
+func (r *TemplateRepository) Delete(id int) error {
+	if err := r.db.Delete(&domain.TaskTemplate{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+	return nil
+}
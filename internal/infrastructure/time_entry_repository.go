@@ -0,0 +1,140 @@
+package infrastructure
+
+import (
+	"errors"
+	"fmt"
+	"task-manager-app/backend/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TimeEntryRepository struct {
+	db *gorm.DB
+}
+
+func NewTimeEntryRepository(db *gorm.DB) *TimeEntryRepository {
+	return &TimeEntryRepository{db: db}
+}
+
+func (r *TimeEntryRepository) Create(entry *domain.TimeEntry) error {
+	entry.CreatedAt = time.Now()
+	entry.UpdatedAt = time.Now()
+	if err := r.db.Create(entry).Error; err != nil {
+		// The unique index on running entries allows one timer per user
+		if entry.IsRunning() && errors.Is(err, gorm.ErrDuplicatedKey) {
+			return domain.ErrTimerRunning
+		}
+		return fmt.Errorf("failed to create time entry: %w", err)
+	}
+	return nil
+}
+
+func (r *TimeEntryRepository) FindByID(id int) (*domain.TimeEntry, error) {
+	var entry domain.TimeEntry
+	if err := r.db.First(&entry, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to find time entry: %w", err)
+	}
+	return &entry, nil
+}
+
+// FindRunning returns the running timer of a user, or nil when there is none.
+func (r *TimeEntryRepository) FindRunning(userID int) (*domain.TimeEntry, error) {
+	var entries []domain.TimeEntry
+	if err := r.db.Where("user_id = ? AND ended_at IS NULL", userID).Limit(1).Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to find running timer: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return &entries[0], nil
+}
+
+func (r *TimeEntryRepository) FindAll(filter domain.TimeEntryFilter) (*domain.TimeEntryConnection, error) {
+	var entries []domain.TimeEntry
+	var count int64
+	query := r.filtered(filter)
+	if err := query.Count(&count).Error; err != nil {
+		return nil, fmt.Errorf("failed to count time entries: %w", err)
+	}
+	query = query.Order("started_at DESC").Order("id DESC")
+	if filter.Page > 0 && filter.Limit > 0 {
+		query = query.Offset((filter.Page - 1) * filter.Limit).Limit(filter.Limit)
+	}
+	if err := query.Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to find time entries: %w", err)
+	}
+
+	connection := &domain.TimeEntryConnection{Edges: make([]domain.TimeEntryEdge, len(entries))}
+	for i, entry := range entries {
+		connection.Edges[i] = domain.TimeEntryEdge{Node: entry}
+	}
+	connection.PageInfo.TotalCount = int(count)
+	connection.PageInfo.HasNextPage = filter.Limit > 0 && filter.Page*filter.Limit < int(count)
+	connection.PageInfo.HasPreviousPage = filter.Page > 1
+	return connection, nil
+}
+
+func (r *TimeEntryRepository) Update(entry *domain.TimeEntry) error {
+	entry.UpdatedAt = time.Now()
+	if err := r.db.Save(entry).Error; err != nil {
+		return fmt.Errorf("failed to update time entry: %w", err)
+	}
+	return nil
+}
+
+func (r *TimeEntryRepository) Delete(id int) error {
+	if err := r.db.Delete(&domain.TimeEntry{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete time entry: %w", err)
+	}
+	return nil
+}
+
+// Report sums the finished entries matching the filter in the database, grouped three ways.
+func (r *TimeEntryRepository) Report(filter domain.TimeEntryFilter) (*domain.TimeReport, error) {
+	report := &domain.TimeReport{ByTask: []domain.TimeTotal{}, ByProject: []domain.TimeTotal{}, ByUser: []domain.TimeTotal{}}
+	finished := func() *gorm.DB {
+		return r.filtered(filter).Where("time_entries.ended_at IS NOT NULL")
+	}
+	if err := finished().Select("COALESCE(SUM(time_entries.seconds), 0)").Scan(&report.Seconds).Error; err != nil {
+		return nil, fmt.Errorf("failed to sum time entries: %w", err)
+	}
+	groups := []struct {
+		column string
+		totals *[]domain.TimeTotal
+	}{
+		{"time_entries.task_id", &report.ByTask},
+		{"tasks.project_id", &report.ByProject},
+		{"time_entries.user_id", &report.ByUser},
+	}
+	for _, group := range groups {
+		err := finished().Joins("JOIN tasks ON tasks.id = time_entries.task_id").
+			Select(group.column + " AS id, SUM(time_entries.seconds) AS seconds").
+			Group(group.column).Order(group.column).
+			Scan(group.totals).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to sum time entries: %w", err)
+		}
+	}
+	return report, nil
+}
+
+func (r *TimeEntryRepository) filtered(filter domain.TimeEntryFilter) *gorm.DB {
+	query := r.db.Model(&domain.TimeEntry{})
+	if filter.TaskID != 0 {
+		query = query.Where("time_entries.task_id = ?", filter.TaskID)
+	}
+	if filter.ProjectID != 0 {
+		query = query.Where("time_entries.task_id IN (?)", r.db.Table("tasks").Select("id").Where("project_id = ?", filter.ProjectID))
+	}
+	if filter.UserID != 0 {
+		query = query.Where("time_entries.user_id = ?", filter.UserID)
+	}
+	if filter.From != nil {
+		query = query.Where("time_entries.started_at >= ?", filter.From.UTC())
+	}
+	if filter.To != nil {
+		query = query.Where("time_entries.started_at < ?", filter.To.UTC())
+	}
+	return query
+}
@@ -2,6 +2,7 @@ package infrastructure
 
 import (
 	"task-manager-app/backend/internal/domain"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -46,6 +47,75 @@ func (r *UserRepository) Update(user *domain.User) error {
 	return r.db.Save(user).Error
 }
 
+// Delete moves a user to the trash; assignments are kept so a restore brings them back.
 func (r *UserRepository) Delete(id int) error {
 	return r.db.Delete(&domain.User{}, id).Error
 }
+
+func (r *UserRepository) FindDeleted() ([]domain.User, error) {
+	var users []domain.User
+	if err := r.db.Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC").Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *UserRepository) Restore(id int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var user domain.User
+		if err := tx.Unscoped().Where("deleted_at IS NOT NULL").First(&user, id).Error; err != nil {
+			return err
+		}
+		var taken int64
+		if err := tx.Model(&domain.User{}).Where("email = ?", user.Email).Count(&taken).Error; err != nil {
+			return err
+		}
+		if taken > 0 {
+			return domain.ErrEmailTaken
+		}
+		return tx.Unscoped().Model(&user).Update("deleted_at", nil).Error
+	})
+}
+
+// Purge permanently removes a user from the trash.
+func (r *UserRepository) Purge(id int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("deleted_at IS NOT NULL").First(&domain.User{}, id).Error; err != nil {
+			return err
+		}
+		return purgeUsers(tx, []int{id})
+	})
+}
+
+// PurgeDeletedBefore permanently removes the users trashed before the cutoff and returns how many there were.
+func (r *UserRepository) PurgeDeletedBefore(cutoff time.Time) (int, error) {
+	var ids []int
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&domain.User{}).Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		return purgeUsers(tx, ids)
+	})
+	return len(ids), err
+}
+
+func purgeUsers(tx *gorm.DB, ids []int) error {
+	for _, table := range []string{"task_assignees", "task_watchers"} {
+		if err := tx.Exec("DELETE FROM "+table+" WHERE user_id IN ?", ids).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Where("user_id IN ?", ids).Delete(&domain.TimeEntry{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("user_id IN ?", ids).Delete(&domain.CustomFieldValue{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("user_id IN ?", ids).Delete(&domain.RefreshToken{}).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Delete(&domain.User{}, ids).Error
+}
@@ -0,0 +1,103 @@
+package infrastructure
+
+import (
+	"fmt"
+	"task-manager-app/backend/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type WorkspaceRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkspaceRepository(db *gorm.DB) *WorkspaceRepository {
+	return &WorkspaceRepository{db: db}
+}
+
+func (r *WorkspaceRepository) Create(workspace *domain.Workspace) error {
+	workspace.CreatedAt = time.Now()
+	workspace.UpdatedAt = time.Now()
+	if err := r.db.Create(workspace).Error; err != nil {
+		return fmt.Errorf("failed to create workspace: %w", err)
+	}
+	return nil
+}
+
+func (r *WorkspaceRepository) FindByID(id int) (*domain.Workspace, error) {
+	var workspace domain.Workspace
+	if err := r.db.First(&workspace, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to find workspace: %w", err)
+	}
+	return &workspace, nil
+}
+
+func (r *WorkspaceRepository) FindByOwnerID(ownerID int) ([]domain.Workspace, error) {
+	var workspaces []domain.Workspace
+	if err := r.db.Where("owner_id = ?", ownerID).Find(&workspaces).Error; err != nil {
+		return nil, fmt.Errorf("failed to find workspaces by owner ID: %w", err)
+	}
+	return workspaces, nil
+}
+
+func (r *WorkspaceRepository) FindTransitions(workspaceID int) ([]domain.WorkflowTransition, error) {
+	var transitions []domain.WorkflowTransition
+	if err := r.db.Where("workspace_id = ?", workspaceID).Find(&transitions).Error; err != nil {
+		return nil, fmt.Errorf("failed to find workflow transitions: %w", err)
+	}
+	return transitions, nil
+}
+
+// ReplaceTransitions swaps the whole workflow of a workspace in a single transaction.
+func (r *WorkspaceRepository) ReplaceTransitions(workspaceID int, transitions []domain.WorkflowTransition) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("workspace_id = ?", workspaceID).Delete(&domain.WorkflowTransition{}).Error; err != nil {
+			return fmt.Errorf("failed to clear workflow transitions: %w", err)
+		}
+		if len(transitions) == 0 {
+			return nil
+		}
+		for i := range transitions {
+			transitions[i].ID = 0
+			transitions[i].WorkspaceID = workspaceID
+		}
+		if err := tx.Create(&transitions).Error; err != nil {
+			return fmt.Errorf("failed to save workflow transitions: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *WorkspaceRepository) FindMembers(workspaceID int) ([]domain.WorkspaceMember, error) {
+	var members []domain.WorkspaceMember
+	if err := r.db.Where("workspace_id = ?", workspaceID).Order("created_at").Order("user_id").Find(&members).Error; err != nil {
+		return nil, fmt.Errorf("failed to find workspace members: %w", err)
+	}
+	return members, nil
+}
+
+func (r *WorkspaceRepository) FindMemberships(userID int) ([]domain.WorkspaceMember, error) {
+	var members []domain.WorkspaceMember
+	if err := r.db.Where("user_id = ?", userID).Order("workspace_id").Find(&members).Error; err != nil {
+		return nil, fmt.Errorf("failed to find workspace memberships: %w", err)
+	}
+	return members, nil
+}
+
+func (r *WorkspaceRepository) SaveMember(member *domain.WorkspaceMember) error {
+	if member.CreatedAt.IsZero() {
+		member.CreatedAt = time.Now()
+	}
+	if err := r.db.Save(member).Error; err != nil {
+		return fmt.Errorf("failed to save workspace member: %w", err)
+	}
+	return nil
+}
+
+func (r *WorkspaceRepository) RemoveMember(workspaceID, userID int) error {
+	if err := r.db.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).Delete(&domain.WorkspaceMember{}).Error; err != nil {
+		return fmt.Errorf("failed to remove workspace member: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package interfaces
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// multipartOverhead leaves room for the multipart boundaries and headers around the file.
+const multipartOverhead = 1 << 20
+
+type AttachmentHandler struct {
+	service *application.AttachmentService
+}
+
+func NewAttachmentHandler(service *application.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{service: service}
+}
+
+// UploadAttachment godoc
+// @Summary Attach a file to a task
+// @Description Upload a file as multipart form data in the "file" field; its type is sniffed from the content
+// @Tags attachments
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Param file formData file true "File"
+// @Success 201 {object} domain.Attachment
+// @Failure 413 {object} gin.H
+// @Failure 415 {object} gin.H
+// @Router /tasks/{id}/attachments [post]
+func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	if max := h.service.MaxSize(); max > 0 {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, max+multipartOverhead)
+	}
+	header, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": domain.ErrAttachmentTooLarge.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A file is required in the \"file\" form field"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.service.Upload(c.Request.Context(), taskID, header.Filename, file, header.Size, restActor(c))
+	if err != nil {
+		c.JSON(attachmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, attachment)
+}
+
+// GetAttachments godoc
+// @Summary Get the attachments of a task
+// @Description Get the attachments of a task
+// @Tags attachments
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Success 200 {array} domain.Attachment
+// @Router /tasks/{id}/attachments [get]
+func (h *AttachmentHandler) GetAttachments(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	attachments, err := h.service.GetAttachments(taskID, restActor(c))
+	if err != nil {
+		c.JSON(attachmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, attachments)
+}
+
+// GetAttachment godoc
+// @Summary Get attachment metadata
+// @Description Get the metadata of an attachment
+// @Tags attachments
+// @Produce  json
+// @Param id path int true "Attachment ID"
+// @Success 200 {object} domain.Attachment
+// @Router /attachments/{id} [get]
+func (h *AttachmentHandler) GetAttachment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid attachment ID"})
+		return
+	}
+	attachment, err := h.service.GetAttachment(id, restActor(c))
+	if err != nil {
+		c.JSON(attachmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, attachment)
+}
+
+// DownloadAttachment godoc
+// @Summary Download an attachment
+// @Description Stream the content of an attachment; supports Range requests
+// @Tags attachments
+// @Produce  octet-stream
+// @Param id path int true "Attachment ID"
+// @Success 200 {file} file
+// @Success 206 {file} file
+// @Router /attachments/{id}/content [get]
+func (h *AttachmentHandler) DownloadAttachment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid attachment ID"})
+		return
+	}
+	attachment, content, err := h.service.Open(c.Request.Context(), id, restActor(c))
+	if err != nil {
+		c.JSON(attachmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	defer content.Close()
+
+	// Always download, never render, so uploaded HTML or scripts cannot run in the app's origin
+	c.Header("Content-Type", attachment.ContentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q; filename*=UTF-8''%s", attachment.Filename, url.PathEscape(attachment.Filename)))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("ETag", strconv.Quote(attachment.Checksum))
+	http.ServeContent(c.Writer, c.Request, attachment.Filename, attachment.CreatedAt, content)
+}
+
+// DeleteAttachment godoc
+// @Summary Delete an attachment
+// @Description Delete an attachment; only its uploader or the task owner may do so
+// @Tags attachments
+// @Param id path int true "Attachment ID"
+// @Success 204
+// @Failure 403 {object} gin.H
+// @Router /attachments/{id} [delete]
+func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid attachment ID"})
+		return
+	}
+	if err := h.service.DeleteAttachment(c.Request.Context(), id, restActor(c)); err != nil {
+		c.JSON(attachmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// attachmentErrorStatus maps attachment service errors to HTTP status codes.
+func attachmentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrEmptyAttachment), errors.Is(err, domain.ErrAttachmentSizeMismatch):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrAttachmentTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, domain.ErrAttachmentType):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, domain.ErrAttachmentForbidden), errors.Is(err, domain.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, domain.ErrBlobNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
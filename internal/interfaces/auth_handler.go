@@ -1,28 +1,23 @@
 package interfaces
 
 import (
+	"errors"
 	"net/http"
 	"task-manager-app/backend/internal/application"
 	"task-manager-app/backend/internal/domain"
+	"task-manager-app/backend/internal/middleware"
 	"task-manager-app/backend/pkg/utils"
-	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 )
 
 type AuthHandler struct {
-	service     *application.UserService
-	jwtSecret   []byte
-	tokenExpiry time.Duration
+	service *application.UserService
+	tokens  *application.TokenService
 }
 
-func NewAuthHandler(service *application.UserService, jwtSecret []byte) *AuthHandler {
-	return &AuthHandler{
-		service:     service,
-		jwtSecret:   jwtSecret,
-		tokenExpiry: 24 * time.Hour,
-	}
+func NewAuthHandler(service *application.UserService, tokens *application.TokenService) *AuthHandler {
+	return &AuthHandler{service: service, tokens: tokens}
 }
 
 func (h *AuthHandler) Register(c *gin.Context) {
@@ -58,45 +53,88 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": utils.TranslateError(err)})
 		return
 	}
-	user, token, err := h.service.Login(req.Email, req.Password)
+	user, tokens, err := h.service.Login(req.Email, req.Password)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"token": token, "user": user})
+	c.JSON(http.StatusOK, gin.H{
+		"token":         tokens.AccessToken,
+		"token_type":    tokens.TokenType,
+		"expires_in":    tokens.ExpiresIn,
+		"refresh_token": tokens.RefreshToken,
+		"user":          user,
+	})
 }
 
+// RefreshToken exchanges a refresh token for a new access token and a new refresh token.
+// Each refresh token works once; reusing one ends every session of its login.
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+	var req struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": utils.TranslateError(err)})
 		return
 	}
+	_, tokens, err := h.service.Refresh(req.RefreshToken)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, domain.ErrInvalidRefreshToken) || errors.Is(err, domain.ErrRefreshTokenReused) {
+			status = http.StatusUnauthorized
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, tokens)
+}
 
-	now := time.Now()
-	claims := jwt.MapClaims{
-		"user_id": userID,
-		"iat":     now.Unix(),
-		"exp":     now.Add(h.tokenExpiry).Unix(),
+// Logout ends the session the request is authenticated with: its access token stops working
+// right away and its refresh token can no longer be used.
+func (h *AuthHandler) Logout(c *gin.Context) {
+	claims, ok := h.authenticate(c)
+	if !ok {
+		return
+	}
+	if err := h.service.Logout(claims); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
+	c.JSON(http.StatusOK, gin.H{"message": "Successfully logged out"})
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(h.jwtSecret)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Token refresh failed"})
+// LogoutEverywhere ends every session of the authenticated user, on all devices.
+func (h *AuthHandler) LogoutEverywhere(c *gin.Context) {
+	claims, ok := h.authenticate(c)
+	if !ok {
 		return
 	}
+	if err := h.service.LogoutEverywhere(claims.UserID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Successfully logged out of all sessions"})
+}
 
-	c.JSON(http.StatusOK, gin.H{
-		"token":      tokenString,
-		"expires_in": h.tokenExpiry.Seconds(),
-		"token_type": "Bearer",
-	})
+// JWKS publishes the public keys access tokens are signed with, so other services can
+// verify them. Verifiers may cache the set for a short while.
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.tokens.JWKS())
 }
 
-func (h *AuthHandler) Logout(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"message":      "Successfully logged out",
-		"instructions": "Please remove the token from your client storage",
-	})
+// authenticate validates the request's access token, writing an error response and returning
+// false when there is none or it is rejected.
+func (h *AuthHandler) authenticate(c *gin.Context) (*domain.AccessClaims, bool) {
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return nil, false
+	}
+	claims, err := middleware.Authenticate(h.tokens, authHeader)
+	if err != nil {
+		c.JSON(middleware.AuthErrorStatus(err), gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return claims, true
 }
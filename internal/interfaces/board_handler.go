@@ -0,0 +1,147 @@
+package interfaces
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+	"task-manager-app/backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BoardHandler struct {
+	service *application.BoardService
+}
+
+func NewBoardHandler(service *application.BoardService) *BoardHandler {
+	return &BoardHandler{service: service}
+}
+
+// GetBoard godoc
+// @Summary Get the board of a project
+// @Description Get the columns of a project's kanban board, by status or by its custom columns, with their tasks in manual order
+// @Tags board
+// @Produce  json
+// @Param id path int true "Project ID"
+// @Param limit query int false "Tasks per column (default 50)"
+// @Success 200 {object} domain.Board
+// @Router /projects/{id}/board [get]
+func (h *BoardHandler) GetBoard(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	limit := 50
+	if v := c.Query("limit"); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return
+		}
+	}
+	board, err := h.service.GetBoard(projectID, limit, restActor(c))
+	if err != nil {
+		c.JSON(boardErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, board)
+}
+
+// GetBoardColumns godoc
+// @Summary Get the custom columns of a project's board
+// @Description Get the custom column set of a project's board; empty when the board groups by status
+// @Tags board
+// @Produce  json
+// @Param id path int true "Project ID"
+// @Success 200 {array} domain.BoardColumn
+// @Router /projects/{id}/board/columns [get]
+func (h *BoardHandler) GetBoardColumns(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	columns, err := h.service.GetColumns(projectID, restActor(c))
+	if err != nil {
+		c.JSON(boardErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, columns)
+}
+
+// SetBoardColumns godoc
+// @Summary Replace the custom columns of a project's board
+// @Description Set the columns in order; list existing columns with their ID to keep their tasks, an empty list groups the board by status again
+// @Tags board
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Project ID"
+// @Param columns body []domain.BoardColumn true "Columns"
+// @Success 200 {array} domain.BoardColumn
+// @Router /projects/{id}/board/columns [put]
+func (h *BoardHandler) SetBoardColumns(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	var columns []domain.BoardColumn
+	if err := c.ShouldBindJSON(&columns); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	columns, err = h.service.SetColumns(projectID, columns, restActor(c))
+	if err != nil {
+		c.JSON(boardErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, columns)
+}
+
+// MoveTask godoc
+// @Summary Move a task on its project's board
+// @Description Move a task to a column (a status, or a custom column ID) between the task right before it and the one right after it; without neighbours it goes to the end
+// @Tags board
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Param move body object true "Target column and neighbours"
+// @Success 200 {object} domain.Task
+// @Router /tasks/{id}/move [post]
+func (h *BoardHandler) MoveTask(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	var req struct {
+		Column string `json:"column" binding:"required"`
+		Before int    `json:"before"`
+		After  int    `json:"after"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": utils.TranslateError(err)})
+		return
+	}
+	task, err := h.service.MoveTask(id, req.Column, req.Before, req.After, restActor(c))
+	if err != nil {
+		c.JSON(boardErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
+
+// boardErrorStatus maps board errors to HTTP status codes, falling back to the task mapping
+// for workflow errors raised by status moves.
+func boardErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidBoardColumns), errors.Is(err, domain.ErrUnknownColumn), errors.Is(err, domain.ErrNoBoard),
+		errors.Is(err, domain.ErrInvalidMove), errors.Is(err, domain.ErrInvalidRank):
+		return http.StatusBadRequest
+	default:
+		return taskErrorStatus(err)
+	}
+}
@@ -0,0 +1,161 @@
+package interfaces
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+	"task-manager-app/backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ChecklistHandler struct {
+	service *application.ChecklistService
+}
+
+func NewChecklistHandler(service *application.ChecklistService) *ChecklistHandler {
+	return &ChecklistHandler{service: service}
+}
+
+// GetChecklist godoc
+// @Summary Get the checklist of a task
+// @Description Get the checklist items of a task in order
+// @Tags checklist
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Success 200 {array} domain.ChecklistItem
+// @Router /tasks/{id}/checklist [get]
+func (h *ChecklistHandler) GetChecklist(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	items, err := h.service.GetChecklist(taskID, restActor(c))
+	if err != nil {
+		c.JSON(checklistErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, items)
+}
+
+// AddChecklistItem godoc
+// @Summary Add a checklist item
+// @Description Append an item to the end of a task's checklist
+// @Tags checklist
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Param item body object true "Item text"
+// @Success 201 {object} domain.ChecklistItem
+// @Router /tasks/{id}/checklist [post]
+func (h *ChecklistHandler) AddChecklistItem(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	var req struct {
+		Text string `json:"text" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": utils.TranslateError(err)})
+		return
+	}
+	item, err := h.service.AddItem(taskID, req.Text, restActor(c))
+	if err != nil {
+		c.JSON(checklistErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, item)
+}
+
+// ReorderChecklist godoc
+// @Summary Reorder a checklist
+// @Description Put the checklist items of a task in a new order; every item must be listed once
+// @Tags checklist
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Param order body object true "Item IDs in their new order"
+// @Success 200 {array} domain.ChecklistItem
+// @Router /tasks/{id}/checklist/order [put]
+func (h *ChecklistHandler) ReorderChecklist(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	var req struct {
+		ItemIDs []int `json:"itemIds" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": utils.TranslateError(err)})
+		return
+	}
+	items, err := h.service.ReorderChecklist(taskID, req.ItemIDs, restActor(c))
+	if err != nil {
+		c.JSON(checklistErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, items)
+}
+
+// ToggleChecklistItem godoc
+// @Summary Check or uncheck a checklist item
+// @Description Check an unchecked item or uncheck a checked one
+// @Tags checklist
+// @Produce  json
+// @Param id path int true "Checklist item ID"
+// @Success 200 {object} domain.ChecklistItem
+// @Router /checklist-items/{id}/toggle [post]
+func (h *ChecklistHandler) ToggleChecklistItem(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid checklist item ID"})
+		return
+	}
+	item, err := h.service.ToggleItem(id, restActor(c))
+	if err != nil {
+		c.JSON(checklistErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, item)
+}
+
+// DeleteChecklistItem godoc
+// @Summary Delete a checklist item
+// @Description Delete a checklist item
+// @Tags checklist
+// @Param id path int true "Checklist item ID"
+// @Success 204
+// @Router /checklist-items/{id} [delete]
+func (h *ChecklistHandler) DeleteChecklistItem(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid checklist item ID"})
+		return
+	}
+	if err := h.service.DeleteItem(id, restActor(c)); err != nil {
+		c.JSON(checklistErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// checklistErrorStatus maps checklist service errors to HTTP status codes.
+func checklistErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrEmptyChecklistItem), errors.Is(err, domain.ErrChecklistOrder):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
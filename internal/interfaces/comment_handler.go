@@ -0,0 +1,203 @@
+package interfaces
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+	"task-manager-app/backend/internal/middleware"
+	"task-manager-app/backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type CommentHandler struct {
+	service *application.CommentService
+}
+
+func NewCommentHandler(service *application.CommentService) *CommentHandler {
+	return &CommentHandler{service: service}
+}
+
+type commentRequest struct {
+	Body     string `json:"body" binding:"required"`
+	ParentID int    `json:"parentId"`
+}
+
+// GetComments godoc
+// @Summary Get the comments of a task
+// @Description Get the top-level comments of a task, oldest first, each with its replies
+// @Tags comments
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Success 200 {object} domain.CommentConnection
+// @Router /tasks/{id}/comments [get]
+func (h *CommentHandler) GetComments(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+	comments, err := h.service.GetComments(domain.CommentFilter{TaskID: taskID, Page: page, Limit: limit}, restActor(c))
+	if err != nil {
+		c.JSON(commentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, comments)
+}
+
+// CreateComment godoc
+// @Summary Comment on a task
+// @Description Comment on a task or reply to one of its comments; @email mentions are recorded
+// @Tags comments
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Param comment body object true "Comment body and optional parentId"
+// @Success 201 {object} domain.Comment
+// @Router /tasks/{id}/comments [post]
+func (h *CommentHandler) CreateComment(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	var req commentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": utils.TranslateError(err)})
+		return
+	}
+	if _, ok := middleware.UserIDFromContext(c.Request.Context()); !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	comment, err := h.service.AddComment(taskID, req.ParentID, req.Body, restActor(c))
+	if err != nil {
+		c.JSON(commentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, comment)
+}
+
+// GetCommentByID godoc
+// @Summary Get a comment by ID
+// @Description Get a comment by ID
+// @Tags comments
+// @Produce  json
+// @Param id path int true "Comment ID"
+// @Success 200 {object} domain.Comment
+// @Router /comments/{id} [get]
+func (h *CommentHandler) GetCommentByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return
+	}
+	comment, err := h.service.GetComment(id, restActor(c))
+	if err != nil {
+		c.JSON(commentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, comment)
+}
+
+// UpdateComment godoc
+// @Summary Edit a comment
+// @Description Edit a comment; only its author or the task owner may do so
+// @Tags comments
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Comment ID"
+// @Param comment body object true "New comment body"
+// @Success 200 {object} domain.Comment
+// @Failure 403 {object} gin.H
+// @Router /comments/{id} [put]
+func (h *CommentHandler) UpdateComment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return
+	}
+	var req commentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": utils.TranslateError(err)})
+		return
+	}
+	comment, err := h.service.UpdateComment(id, req.Body, restActor(c))
+	if err != nil {
+		c.JSON(commentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, comment)
+}
+
+// DeleteComment godoc
+// @Summary Delete a comment
+// @Description Delete a comment and its replies; only its author or the task owner may do so
+// @Tags comments
+// @Param id path int true "Comment ID"
+// @Success 204
+// @Failure 403 {object} gin.H
+// @Router /comments/{id} [delete]
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return
+	}
+	if err := h.service.DeleteComment(id, restActor(c)); err != nil {
+		c.JSON(commentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetCommentHistory godoc
+// @Summary Get the edit history of a comment
+// @Description Get the previous bodies of a comment, oldest first
+// @Tags comments
+// @Produce  json
+// @Param id path int true "Comment ID"
+// @Success 200 {array} domain.CommentRevision
+// @Router /comments/{id}/history [get]
+func (h *CommentHandler) GetCommentHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return
+	}
+	revisions, err := h.service.GetHistory(id, restActor(c))
+	if err != nil {
+		c.JSON(commentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, revisions)
+}
+
+// commentErrorStatus maps comment service errors to HTTP status codes.
+func commentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrEmptyComment), errors.Is(err, domain.ErrCommentParent):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrCommentForbidden), errors.Is(err, domain.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,229 @@
+package interfaces
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type CustomFieldHandler struct {
+	service *application.CustomFieldService
+}
+
+func NewCustomFieldHandler(service *application.CustomFieldService) *CustomFieldHandler {
+	return &CustomFieldHandler{service: service}
+}
+
+// GetCustomFields godoc
+// @Summary Get the custom fields of a workspace
+// @Description Get the custom field definitions of a workspace ordered by name
+// @Tags custom-fields
+// @Produce  json
+// @Param id path int true "Workspace ID"
+// @Success 200 {array} domain.CustomField
+// @Router /workspaces/{id}/custom-fields [get]
+func (h *CustomFieldHandler) GetCustomFields(c *gin.Context) {
+	workspaceID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+	fields, err := h.service.GetFields(workspaceID, restActor(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, fields)
+}
+
+// CreateCustomField godoc
+// @Summary Create a custom field
+// @Description Define a custom field on the tasks of a workspace; select fields need options
+// @Tags custom-fields
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Workspace ID"
+// @Param field body domain.CustomField true "Custom field"
+// @Success 201 {object} domain.CustomField
+// @Router /workspaces/{id}/custom-fields [post]
+func (h *CustomFieldHandler) CreateCustomField(c *gin.Context) {
+	workspaceID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+	var field domain.CustomField
+	if err := c.ShouldBindJSON(&field); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	field.ID = 0
+	field.WorkspaceID = workspaceID
+	if err := h.service.CreateField(&field, restActor(c)); err != nil {
+		c.JSON(customFieldErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, field)
+}
+
+// GetCustomFieldByID godoc
+// @Summary Get a custom field by ID
+// @Description Get a custom field by ID
+// @Tags custom-fields
+// @Produce  json
+// @Param id path int true "Custom field ID"
+// @Success 200 {object} domain.CustomField
+// @Router /custom-fields/{id} [get]
+func (h *CustomFieldHandler) GetCustomFieldByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid custom field ID"})
+		return
+	}
+	field, err := h.service.GetField(id, restActor(c))
+	if errors.Is(err, domain.ErrForbidden) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Custom field not found"})
+		return
+	}
+	c.JSON(http.StatusOK, field)
+}
+
+// UpdateCustomField godoc
+// @Summary Update a custom field
+// @Description Rename a custom field or change its options; the type cannot change
+// @Tags custom-fields
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Custom field ID"
+// @Param field body domain.CustomField true "Custom field"
+// @Success 200 {object} domain.CustomField
+// @Router /custom-fields/{id} [put]
+func (h *CustomFieldHandler) UpdateCustomField(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid custom field ID"})
+		return
+	}
+	var field domain.CustomField
+	if err := c.ShouldBindJSON(&field); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	field.ID = id
+	if err := h.service.UpdateField(&field, restActor(c)); err != nil {
+		c.JSON(customFieldErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, field)
+}
+
+// DeleteCustomField godoc
+// @Summary Delete a custom field
+// @Description Delete a custom field and its values on every task
+// @Tags custom-fields
+// @Param id path int true "Custom field ID"
+// @Success 204
+// @Router /custom-fields/{id} [delete]
+func (h *CustomFieldHandler) DeleteCustomField(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid custom field ID"})
+		return
+	}
+	if err := h.service.DeleteField(id, restActor(c)); err != nil {
+		c.JSON(customFieldErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// SetCustomFieldValue godoc
+// @Summary Set a custom field on a task
+// @Description Set the value of a custom field on a task; only the part matching the field type is kept
+// @Tags custom-fields
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Param fieldId path int true "Custom field ID"
+// @Param value body domain.CustomFieldValue true "Value"
+// @Success 200 {object} domain.Task
+// @Router /tasks/{id}/custom-fields/{fieldId} [put]
+func (h *CustomFieldHandler) SetCustomFieldValue(c *gin.Context) {
+	taskID, fieldID, ok := taskFieldParams(c)
+	if !ok {
+		return
+	}
+	var value domain.CustomFieldValue
+	if err := c.ShouldBindJSON(&value); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	task, err := h.service.SetValue(taskID, fieldID, value, restActor(c))
+	if err != nil {
+		c.JSON(customFieldErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
+
+// ClearCustomFieldValue godoc
+// @Summary Clear a custom field on a task
+// @Description Remove the value of a custom field from a task
+// @Tags custom-fields
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Param fieldId path int true "Custom field ID"
+// @Success 200 {object} domain.Task
+// @Router /tasks/{id}/custom-fields/{fieldId} [delete]
+func (h *CustomFieldHandler) ClearCustomFieldValue(c *gin.Context) {
+	taskID, fieldID, ok := taskFieldParams(c)
+	if !ok {
+		return
+	}
+	task, err := h.service.ClearValue(taskID, fieldID, restActor(c))
+	if err != nil {
+		c.JSON(customFieldErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
+
+func taskFieldParams(c *gin.Context) (int, int, bool) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return 0, 0, false
+	}
+	fieldID, err := strconv.Atoi(c.Param("fieldId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid custom field ID"})
+		return 0, 0, false
+	}
+	return taskID, fieldID, true
+}
+
+// customFieldErrorStatus maps custom field service errors to HTTP status codes.
+func customFieldErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCustomField), errors.Is(err, domain.ErrCustomFieldOptions),
+		errors.Is(err, domain.ErrInvalidFieldValue), errors.Is(err, domain.ErrCustomFieldWorkspace):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrDuplicateCustomField):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,137 @@
+package interfaces
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type DependencyHandler struct {
+	service *application.DependencyService
+}
+
+func NewDependencyHandler(service *application.DependencyService) *DependencyHandler {
+	return &DependencyHandler{service: service}
+}
+
+// GetBlockers godoc
+// @Summary Get the tasks blocking a task
+// @Description Get the tasks a task is blocked by
+// @Tags dependencies
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Success 200 {array} domain.Task
+// @Router /tasks/{id}/dependencies [get]
+func (h *DependencyHandler) GetBlockers(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	blockers, err := h.service.GetBlockers(id, restActor(c))
+	if err != nil {
+		c.JSON(dependencyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, blockers)
+}
+
+// AddDependency godoc
+// @Summary Mark a task as blocked by another
+// @Description Mark a task as blocked by another; edges that would create a cycle are rejected
+// @Tags dependencies
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Param blockerId path int true "Blocking task ID"
+// @Success 200 {object} domain.Task
+// @Failure 409 {object} gin.H
+// @Router /tasks/{id}/dependencies/{blockerId} [post]
+func (h *DependencyHandler) AddDependency(c *gin.Context) {
+	taskID, blockerID, ok := dependencyParams(c)
+	if !ok {
+		return
+	}
+	task, err := h.service.AddDependency(taskID, blockerID, restActor(c))
+	if err != nil {
+		c.JSON(dependencyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
+
+// RemoveDependency godoc
+// @Summary Remove a blocking task
+// @Description Remove a blocking task
+// @Tags dependencies
+// @Param id path int true "Task ID"
+// @Param blockerId path int true "Blocking task ID"
+// @Success 204
+// @Router /tasks/{id}/dependencies/{blockerId} [delete]
+func (h *DependencyHandler) RemoveDependency(c *gin.Context) {
+	taskID, blockerID, ok := dependencyParams(c)
+	if !ok {
+		return
+	}
+	if err := h.service.RemoveDependency(taskID, blockerID, restActor(c)); err != nil {
+		c.JSON(dependencyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetProjectGraph godoc
+// @Summary Get the dependency graph of a project
+// @Description Get the dependencies between the tasks of a project, their schedule from estimates and the critical path
+// @Tags dependencies
+// @Produce  json
+// @Param id path int true "Project ID"
+// @Success 200 {object} domain.DependencyGraph
+// @Router /projects/{id}/dependencies [get]
+func (h *DependencyHandler) GetProjectGraph(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	graph, err := h.service.GetProjectGraph(id, restActor(c))
+	if err != nil {
+		c.JSON(dependencyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, graph)
+}
+
+func dependencyParams(c *gin.Context) (int, int, bool) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return 0, 0, false
+	}
+	blockerID, err := strconv.Atoi(c.Param("blockerId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid blocking task ID"})
+		return 0, 0, false
+	}
+	return taskID, blockerID, true
+}
+
+// dependencyErrorStatus maps dependency service errors to HTTP status codes.
+func dependencyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrSelfDependency):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrDependencyCycle):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
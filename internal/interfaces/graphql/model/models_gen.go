@@ -3,16 +3,118 @@
 package model
 
 import (
+	"fmt"
+	"io"
+	"strconv"
 	"task-manager-app/backend/internal/domain"
+	"time"
 )
 
+type ActivityConnection struct {
+	Edges    []*ActivityEdge `json:"edges"`
+	PageInfo *PageInfo       `json:"pageInfo"`
+}
+
+type ActivityEdge struct {
+	Node *domain.Activity `json:"node"`
+}
+
+type BoardColumnInput struct {
+	ID   *string `json:"id,omitempty"`
+	Name string  `json:"name"`
+}
+
+type CommentConnection struct {
+	Edges    []*CommentEdge `json:"edges"`
+	PageInfo *PageInfo      `json:"pageInfo"`
+}
+
+type CommentEdge struct {
+	Node *domain.Comment `json:"node"`
+}
+
+type CustomFieldFilter struct {
+	FieldID string  `json:"fieldId"`
+	Equals  *string `json:"equals,omitempty"`
+	Min     *string `json:"min,omitempty"`
+	Max     *string `json:"max,omitempty"`
+}
+
+type CustomFieldValueInput struct {
+	Text    *string    `json:"text,omitempty"`
+	Number  *float64   `json:"number,omitempty"`
+	Date    *time.Time `json:"date,omitempty"`
+	Options []string   `json:"options,omitempty"`
+	UserID  *string    `json:"userId,omitempty"`
+	Checked *bool      `json:"checked,omitempty"`
+}
+
+type InstantiateTemplate struct {
+	Values    []*TemplateValue `json:"values,omitempty"`
+	ProjectID *string          `json:"projectId,omitempty"`
+	ParentID  *string          `json:"parentId,omitempty"`
+}
+
+type LogTime struct {
+	TaskID    string    `json:"taskId"`
+	StartedAt time.Time `json:"startedAt"`
+	EndedAt   time.Time `json:"endedAt"`
+	Note      *string   `json:"note,omitempty"`
+}
+
 type Mutation struct {
 }
 
+type NewCustomField struct {
+	WorkspaceID string          `json:"workspaceId"`
+	Name        string          `json:"name"`
+	Type        CustomFieldType `json:"type"`
+	Options     []string        `json:"options,omitempty"`
+}
+
+type NewLabel struct {
+	Name        string  `json:"name"`
+	Color       *string `json:"color,omitempty"`
+	WorkspaceID *string `json:"workspaceId,omitempty"`
+}
+
+type NewProject struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+	Color       *string `json:"color,omitempty"`
+	WorkspaceID *string `json:"workspaceId,omitempty"`
+}
+
+type NewSprint struct {
+	ProjectID string    `json:"projectId"`
+	Name      string    `json:"name"`
+	Goal      *string   `json:"goal,omitempty"`
+	StartDate time.Time `json:"startDate"`
+	EndDate   time.Time `json:"endDate"`
+	Timezone  *string   `json:"timezone,omitempty"`
+}
+
 type NewTask struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	UserID      string `json:"userId"`
+	Title            string        `json:"title"`
+	Description      string        `json:"description"`
+	UserID           string        `json:"userId"`
+	WorkspaceID      *string       `json:"workspaceId,omitempty"`
+	ProjectID        *string       `json:"projectId,omitempty"`
+	ParentID         *string       `json:"parentId,omitempty"`
+	Status           *TaskStatus   `json:"status,omitempty"`
+	Priority         *TaskPriority `json:"priority,omitempty"`
+	StartAt          *time.Time    `json:"startAt,omitempty"`
+	DueAt            *time.Time    `json:"dueAt,omitempty"`
+	Timezone         *string       `json:"timezone,omitempty"`
+	EstimateMinutes  *int          `json:"estimateMinutes,omitempty"`
+	RemainingMinutes *int          `json:"remainingMinutes,omitempty"`
+	Recurrence       *string       `json:"recurrence,omitempty"`
+}
+
+type NewTemplate struct {
+	WorkspaceID *string            `json:"workspaceId,omitempty"`
+	Name        string             `json:"name"`
+	Task        *TemplateTaskInput `json:"task"`
 }
 
 type PageInfo struct {
@@ -34,16 +136,115 @@ type TaskEdge struct {
 }
 
 type TaskFilter struct {
-	Search *string `json:"search,omitempty"`
-	Page   *int    `json:"page,omitempty"`
-	Limit  *int    `json:"limit,omitempty"`
+	Search          *string              `json:"search,omitempty"`
+	ProjectID       *string              `json:"projectId,omitempty"`
+	Page            *int                 `json:"page,omitempty"`
+	Limit           *int                 `json:"limit,omitempty"`
+	DueBefore       *time.Time           `json:"dueBefore,omitempty"`
+	DueAfter        *time.Time           `json:"dueAfter,omitempty"`
+	Overdue         *bool                `json:"overdue,omitempty"`
+	LabelsAny       []string             `json:"labelsAny,omitempty"`
+	LabelsAll       []string             `json:"labelsAll,omitempty"`
+	CreatorID       *string              `json:"creatorId,omitempty"`
+	AssigneeID      *string              `json:"assigneeId,omitempty"`
+	WatcherID       *string              `json:"watcherId,omitempty"`
+	CreatedByMe     *bool                `json:"createdByMe,omitempty"`
+	AssignedToMe    *bool                `json:"assignedToMe,omitempty"`
+	IncludeArchived *bool                `json:"includeArchived,omitempty"`
+	CustomFields    []*CustomFieldFilter `json:"customFields,omitempty"`
+	SprintID        *string              `json:"sprintId,omitempty"`
 }
 
-type UpdateTask struct {
+type TaskOrder struct {
+	Field         TaskOrderField  `json:"field"`
+	Direction     *OrderDirection `json:"direction,omitempty"`
+	CustomFieldID *string         `json:"customFieldId,omitempty"`
+}
+
+type TemplateTaskInput struct {
+	Title            string               `json:"title"`
+	Description      *string              `json:"description,omitempty"`
+	Priority         *TaskPriority        `json:"priority,omitempty"`
+	EstimateMinutes  *int                 `json:"estimateMinutes,omitempty"`
+	DueOffsetMinutes *int                 `json:"dueOffsetMinutes,omitempty"`
+	LabelIds         []string             `json:"labelIds,omitempty"`
+	Checklist        []string             `json:"checklist,omitempty"`
+	Subtasks         []*TemplateTaskInput `json:"subtasks,omitempty"`
+}
+
+type TemplateValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type TimeEntryConnection struct {
+	Edges    []*TimeEntryEdge `json:"edges"`
+	PageInfo *PageInfo        `json:"pageInfo"`
+}
+
+type TimeEntryEdge struct {
+	Node *domain.TimeEntry `json:"node"`
+}
+
+type TimeEntryFilter struct {
+	TaskID    *string    `json:"taskId,omitempty"`
+	ProjectID *string    `json:"projectId,omitempty"`
+	UserID    *string    `json:"userId,omitempty"`
+	From      *time.Time `json:"from,omitempty"`
+	To        *time.Time `json:"to,omitempty"`
+	Page      *int       `json:"page,omitempty"`
+	Limit     *int       `json:"limit,omitempty"`
+}
+
+type UpdateCustomField struct {
+	ID      string   `json:"id"`
+	Name    *string  `json:"name,omitempty"`
+	Options []string `json:"options,omitempty"`
+}
+
+type UpdateProject struct {
 	ID          string  `json:"id"`
-	Title       *string `json:"title,omitempty"`
+	Name        *string `json:"name,omitempty"`
 	Description *string `json:"description,omitempty"`
-	IsCompleted *bool   `json:"isCompleted,omitempty"`
+	Color       *string `json:"color,omitempty"`
+	Archived    *bool   `json:"archived,omitempty"`
+}
+
+type UpdateSprint struct {
+	ID        string     `json:"id"`
+	Name      *string    `json:"name,omitempty"`
+	Goal      *string    `json:"goal,omitempty"`
+	StartDate *time.Time `json:"startDate,omitempty"`
+	EndDate   *time.Time `json:"endDate,omitempty"`
+	Timezone  *string    `json:"timezone,omitempty"`
+}
+
+type UpdateTask struct {
+	ID               string        `json:"id"`
+	Title            *string       `json:"title,omitempty"`
+	Description      *string       `json:"description,omitempty"`
+	IsCompleted      *bool         `json:"isCompleted,omitempty"`
+	Status           *TaskStatus   `json:"status,omitempty"`
+	Priority         *TaskPriority `json:"priority,omitempty"`
+	ParentID         *string       `json:"parentId,omitempty"`
+	StartAt          *time.Time    `json:"startAt,omitempty"`
+	DueAt            *time.Time    `json:"dueAt,omitempty"`
+	Timezone         *string       `json:"timezone,omitempty"`
+	EstimateMinutes  *int          `json:"estimateMinutes,omitempty"`
+	RemainingMinutes *int          `json:"remainingMinutes,omitempty"`
+	Recurrence       *string       `json:"recurrence,omitempty"`
+}
+
+type UpdateTemplate struct {
+	ID   string             `json:"id"`
+	Name *string            `json:"name,omitempty"`
+	Task *TemplateTaskInput `json:"task,omitempty"`
+}
+
+type UpdateTimeEntry struct {
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+	EndedAt   *time.Time `json:"endedAt,omitempty"`
+	Note      *string    `json:"note,omitempty"`
 }
 
 type UserLogin struct {
@@ -58,3 +259,406 @@ type UserRegister struct {
 	LastName string  `json:"lastName"`
 	Avatar   *string `json:"avatar,omitempty"`
 }
+
+type ActivityAction string
+
+const (
+	ActivityActionCreated ActivityAction = "CREATED"
+	ActivityActionUpdated ActivityAction = "UPDATED"
+)
+
+var AllActivityAction = []ActivityAction{
+	ActivityActionCreated,
+	ActivityActionUpdated,
+}
+
+func (e ActivityAction) IsValid() bool {
+	switch e {
+	case ActivityActionCreated, ActivityActionUpdated:
+		return true
+	}
+	return false
+}
+
+func (e ActivityAction) String() string {
+	return string(e)
+}
+
+func (e *ActivityAction) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ActivityAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ActivityAction", str)
+	}
+	return nil
+}
+
+func (e ActivityAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ActivitySource string
+
+const (
+	ActivitySourceRest    ActivitySource = "REST"
+	ActivitySourceGraphql ActivitySource = "GRAPHQL"
+	ActivitySourceSystem  ActivitySource = "SYSTEM"
+)
+
+var AllActivitySource = []ActivitySource{
+	ActivitySourceRest,
+	ActivitySourceGraphql,
+	ActivitySourceSystem,
+}
+
+func (e ActivitySource) IsValid() bool {
+	switch e {
+	case ActivitySourceRest, ActivitySourceGraphql, ActivitySourceSystem:
+		return true
+	}
+	return false
+}
+
+func (e ActivitySource) String() string {
+	return string(e)
+}
+
+func (e *ActivitySource) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ActivitySource(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ActivitySource", str)
+	}
+	return nil
+}
+
+func (e ActivitySource) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type BoardGrouping string
+
+const (
+	BoardGroupingStatus BoardGrouping = "STATUS"
+	BoardGroupingCustom BoardGrouping = "CUSTOM"
+)
+
+var AllBoardGrouping = []BoardGrouping{
+	BoardGroupingStatus,
+	BoardGroupingCustom,
+}
+
+func (e BoardGrouping) IsValid() bool {
+	switch e {
+	case BoardGroupingStatus, BoardGroupingCustom:
+		return true
+	}
+	return false
+}
+
+func (e BoardGrouping) String() string {
+	return string(e)
+}
+
+func (e *BoardGrouping) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = BoardGrouping(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid BoardGrouping", str)
+	}
+	return nil
+}
+
+func (e BoardGrouping) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type CustomFieldType string
+
+const (
+	CustomFieldTypeText        CustomFieldType = "TEXT"
+	CustomFieldTypeNumber      CustomFieldType = "NUMBER"
+	CustomFieldTypeDate        CustomFieldType = "DATE"
+	CustomFieldTypeSelect      CustomFieldType = "SELECT"
+	CustomFieldTypeMultiSelect CustomFieldType = "MULTI_SELECT"
+	CustomFieldTypeUser        CustomFieldType = "USER"
+	CustomFieldTypeCheckbox    CustomFieldType = "CHECKBOX"
+)
+
+var AllCustomFieldType = []CustomFieldType{
+	CustomFieldTypeText,
+	CustomFieldTypeNumber,
+	CustomFieldTypeDate,
+	CustomFieldTypeSelect,
+	CustomFieldTypeMultiSelect,
+	CustomFieldTypeUser,
+	CustomFieldTypeCheckbox,
+}
+
+func (e CustomFieldType) IsValid() bool {
+	switch e {
+	case CustomFieldTypeText, CustomFieldTypeNumber, CustomFieldTypeDate, CustomFieldTypeSelect, CustomFieldTypeMultiSelect, CustomFieldTypeUser, CustomFieldTypeCheckbox:
+		return true
+	}
+	return false
+}
+
+func (e CustomFieldType) String() string {
+	return string(e)
+}
+
+func (e *CustomFieldType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CustomFieldType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CustomFieldType", str)
+	}
+	return nil
+}
+
+func (e CustomFieldType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type OrderDirection string
+
+const (
+	OrderDirectionAsc  OrderDirection = "ASC"
+	OrderDirectionDesc OrderDirection = "DESC"
+)
+
+var AllOrderDirection = []OrderDirection{
+	OrderDirectionAsc,
+	OrderDirectionDesc,
+}
+
+func (e OrderDirection) IsValid() bool {
+	switch e {
+	case OrderDirectionAsc, OrderDirectionDesc:
+		return true
+	}
+	return false
+}
+
+func (e OrderDirection) String() string {
+	return string(e)
+}
+
+func (e *OrderDirection) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrderDirection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrderDirection", str)
+	}
+	return nil
+}
+
+func (e OrderDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type SubtaskPolicy string
+
+const (
+	SubtaskPolicyReparent SubtaskPolicy = "REPARENT"
+	SubtaskPolicyCascade  SubtaskPolicy = "CASCADE"
+)
+
+var AllSubtaskPolicy = []SubtaskPolicy{
+	SubtaskPolicyReparent,
+	SubtaskPolicyCascade,
+}
+
+func (e SubtaskPolicy) IsValid() bool {
+	switch e {
+	case SubtaskPolicyReparent, SubtaskPolicyCascade:
+		return true
+	}
+	return false
+}
+
+func (e SubtaskPolicy) String() string {
+	return string(e)
+}
+
+func (e *SubtaskPolicy) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SubtaskPolicy(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SubtaskPolicy", str)
+	}
+	return nil
+}
+
+func (e SubtaskPolicy) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type TaskOrderField string
+
+const (
+	TaskOrderFieldPriority    TaskOrderField = "PRIORITY"
+	TaskOrderFieldDueAt       TaskOrderField = "DUE_AT"
+	TaskOrderFieldCreatedAt   TaskOrderField = "CREATED_AT"
+	TaskOrderFieldTitle       TaskOrderField = "TITLE"
+	TaskOrderFieldRank        TaskOrderField = "RANK"
+	TaskOrderFieldCustomField TaskOrderField = "CUSTOM_FIELD"
+)
+
+var AllTaskOrderField = []TaskOrderField{
+	TaskOrderFieldPriority,
+	TaskOrderFieldDueAt,
+	TaskOrderFieldCreatedAt,
+	TaskOrderFieldTitle,
+	TaskOrderFieldRank,
+	TaskOrderFieldCustomField,
+}
+
+func (e TaskOrderField) IsValid() bool {
+	switch e {
+	case TaskOrderFieldPriority, TaskOrderFieldDueAt, TaskOrderFieldCreatedAt, TaskOrderFieldTitle, TaskOrderFieldRank, TaskOrderFieldCustomField:
+		return true
+	}
+	return false
+}
+
+func (e TaskOrderField) String() string {
+	return string(e)
+}
+
+func (e *TaskOrderField) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TaskOrderField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TaskOrderField", str)
+	}
+	return nil
+}
+
+func (e TaskOrderField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type TaskPriority string
+
+const (
+	TaskPriorityNone   TaskPriority = "NONE"
+	TaskPriorityLow    TaskPriority = "LOW"
+	TaskPriorityMedium TaskPriority = "MEDIUM"
+	TaskPriorityHigh   TaskPriority = "HIGH"
+	TaskPriorityUrgent TaskPriority = "URGENT"
+)
+
+var AllTaskPriority = []TaskPriority{
+	TaskPriorityNone,
+	TaskPriorityLow,
+	TaskPriorityMedium,
+	TaskPriorityHigh,
+	TaskPriorityUrgent,
+}
+
+func (e TaskPriority) IsValid() bool {
+	switch e {
+	case TaskPriorityNone, TaskPriorityLow, TaskPriorityMedium, TaskPriorityHigh, TaskPriorityUrgent:
+		return true
+	}
+	return false
+}
+
+func (e TaskPriority) String() string {
+	return string(e)
+}
+
+func (e *TaskPriority) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TaskPriority(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TaskPriority", str)
+	}
+	return nil
+}
+
+func (e TaskPriority) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type TaskStatus string
+
+const (
+	TaskStatusTodo       TaskStatus = "TODO"
+	TaskStatusInProgress TaskStatus = "IN_PROGRESS"
+	TaskStatusBlocked    TaskStatus = "BLOCKED"
+	TaskStatusInReview   TaskStatus = "IN_REVIEW"
+	TaskStatusDone       TaskStatus = "DONE"
+	TaskStatusCancelled  TaskStatus = "CANCELLED"
+)
+
+var AllTaskStatus = []TaskStatus{
+	TaskStatusTodo,
+	TaskStatusInProgress,
+	TaskStatusBlocked,
+	TaskStatusInReview,
+	TaskStatusDone,
+	TaskStatusCancelled,
+}
+
+func (e TaskStatus) IsValid() bool {
+	switch e {
+	case TaskStatusTodo, TaskStatusInProgress, TaskStatusBlocked, TaskStatusInReview, TaskStatusDone, TaskStatusCancelled:
+		return true
+	}
+	return false
+}
+
+func (e TaskStatus) String() string {
+	return string(e)
+}
+
+func (e *TaskStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TaskStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TaskStatus", str)
+	}
+	return nil
+}
+
+func (e TaskStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
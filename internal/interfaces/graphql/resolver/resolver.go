@@ -2,38 +2,126 @@ package resolvers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"task-manager-app/backend/internal/application"
 	"task-manager-app/backend/internal/domain"
 	"task-manager-app/backend/internal/interfaces/graphql/generated"
 	"task-manager-app/backend/internal/interfaces/graphql/model"
+	"task-manager-app/backend/internal/middleware"
 	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"gorm.io/gorm"
 )
 
 type Resolver struct {
-	taskService *application.TaskService
-	userService *application.UserService
+	taskService       *application.TaskService
+	userService       *application.UserService
+	labelService      *application.LabelService
+	projectService    *application.ProjectService
+	dependencyService *application.DependencyService
+	commentService    *application.CommentService
+	attachmentService *application.AttachmentService
+	trashService      *application.TrashService
+	timeService       *application.TimeService
+	checklistService  *application.ChecklistService
+	fieldService      *application.CustomFieldService
+	templateService   *application.TemplateService
+	boardService      *application.BoardService
+	sprintService     *application.SprintService
+	tokenService      *application.TokenService
 }
 
-func NewResolver(taskService *application.TaskService, userService *application.UserService) *Resolver {
+func NewResolver(taskService *application.TaskService, userService *application.UserService, labelService *application.LabelService, projectService *application.ProjectService, dependencyService *application.DependencyService, commentService *application.CommentService, attachmentService *application.AttachmentService, trashService *application.TrashService, timeService *application.TimeService, checklistService *application.ChecklistService, fieldService *application.CustomFieldService, templateService *application.TemplateService, boardService *application.BoardService, sprintService *application.SprintService, tokenService *application.TokenService) *Resolver {
 	return &Resolver{
-		taskService: taskService,
-		userService: userService,
+		taskService:       taskService,
+		userService:       userService,
+		labelService:      labelService,
+		projectService:    projectService,
+		dependencyService: dependencyService,
+		commentService:    commentService,
+		attachmentService: attachmentService,
+		trashService:      trashService,
+		timeService:       timeService,
+		checklistService:  checklistService,
+		fieldService:      fieldService,
+		templateService:   templateService,
+		boardService:      boardService,
+		sprintService:     sprintService,
+		tokenService:      tokenService,
+	}
+}
+
+// Authenticate is an operation middleware validating the operation's bearer token, for
+// requests that did not go through the auth middleware.
+func (r *Resolver) Authenticate(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	if _, ok := middleware.ClaimsFromContext(ctx); ok {
+		return next(ctx)
+	}
+	authHeader := graphql.GetOperationContext(ctx).Headers.Get("Authorization")
+	if authHeader == "" {
+		return next(ctx)
+	}
+	claims, err := middleware.Authenticate(r.tokenService, authHeader)
+	if err != nil {
+		return graphql.OneShot(graphql.ErrorResponse(ctx, "%s", err.Error()))
+	}
+	return next(middleware.WithClaims(ctx, claims))
+}
+
+// PresentError is the error presenter of the GraphQL server. Permission errors carry the
+// FORBIDDEN code so clients can tell them apart, as REST clients do by the 403 status.
+func PresentError(ctx context.Context, err error) *gqlerror.Error {
+	presented := graphql.DefaultErrorPresenter(ctx, err)
+	if errors.Is(err, domain.ErrForbidden) || errors.Is(err, domain.ErrCommentForbidden) ||
+		errors.Is(err, domain.ErrAttachmentForbidden) || errors.Is(err, domain.ErrTimeEntryForbidden) {
+		if presented.Extensions == nil {
+			presented.Extensions = map[string]interface{}{}
+		}
+		presented.Extensions["code"] = "FORBIDDEN"
 	}
+	return presented
 }
 
 // Root resolver implementations
-func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
-func (r *Resolver) Query() generated.QueryResolver       { return &queryResolver{r} }
-func (r *Resolver) Task() generated.TaskResolver         { return &taskResolver{r} }
-func (r *Resolver) User() generated.UserResolver         { return &userResolver{r} }
+func (r *Resolver) Activity() generated.ActivityResolver           { return &activityResolver{r} }
+func (r *Resolver) Attachment() generated.AttachmentResolver       { return &attachmentResolver{r} }
+func (r *Resolver) Board() generated.BoardResolver                 { return &boardResolver{r} }
+func (r *Resolver) BoardLane() generated.BoardLaneResolver         { return &boardLaneResolver{r} }
+func (r *Resolver) ChecklistItem() generated.ChecklistItemResolver { return &checklistItemResolver{r} }
+func (r *Resolver) Comment() generated.CommentResolver             { return &commentResolver{r} }
+func (r *Resolver) CustomField() generated.CustomFieldResolver     { return &customFieldResolver{r} }
+func (r *Resolver) CustomFieldValue() generated.CustomFieldValueResolver {
+	return &customFieldValueResolver{r}
+}
+func (r *Resolver) Mutation() generated.MutationResolver         { return &mutationResolver{r} }
+func (r *Resolver) Query() generated.QueryResolver               { return &queryResolver{r} }
+func (r *Resolver) Project() generated.ProjectResolver           { return &projectResolver{r} }
+func (r *Resolver) Task() generated.TaskResolver                 { return &taskResolver{r} }
+func (r *Resolver) TemplateTask() generated.TemplateTaskResolver { return &templateTaskResolver{r} }
+func (r *Resolver) TimeEntry() generated.TimeEntryResolver       { return &timeEntryResolver{r} }
+func (r *Resolver) User() generated.UserResolver                 { return &userResolver{r} }
 
 type (
-	mutationResolver struct{ *Resolver }
-	queryResolver    struct{ *Resolver }
-	taskResolver     struct{ *Resolver }
-	userResolver     struct{ *Resolver }
+	activityResolver         struct{ *Resolver }
+	attachmentResolver       struct{ *Resolver }
+	boardResolver            struct{ *Resolver }
+	boardLaneResolver        struct{ *Resolver }
+	checklistItemResolver    struct{ *Resolver }
+	commentResolver          struct{ *Resolver }
+	customFieldResolver      struct{ *Resolver }
+	customFieldValueResolver struct{ *Resolver }
+	mutationResolver         struct{ *Resolver }
+	queryResolver            struct{ *Resolver }
+	projectResolver          struct{ *Resolver }
+	taskResolver             struct{ *Resolver }
+	templateTaskResolver     struct{ *Resolver }
+	timeEntryResolver        struct{ *Resolver }
+	userResolver             struct{ *Resolver }
 )
 
 // Task mutations
@@ -42,17 +130,45 @@ func (r *mutationResolver) CreateTask(ctx context.Context, input model.NewTask)
 	if err != nil {
 		return nil, fmt.Errorf("invalid user ID: %w", err)
 	}
+	workspaceID, err := parseOptionalID(input.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workspace ID: %w", err)
+	}
+	projectID, err := parseOptionalID(input.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+	parentID, err := parseOptionalID(input.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parent ID: %w", err)
+	}
 
 	task := &domain.Task{
-		Title:       input.Title,
-		Description: input.Description, // Adicionando a descrição
-		UserID:      userID,
-		IsCompleted: false,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		Title:            input.Title,
+		Description:      input.Description, // Adicionando a descrição
+		UserID:           userID,
+		WorkspaceID:      workspaceID,
+		ProjectID:        projectID,
+		ParentID:         parentID,
+		IsCompleted:      false,
+		StartAt:          input.StartAt,
+		DueAt:            input.DueAt,
+		Timezone:         ptrStringValue(input.Timezone),
+		EstimateMinutes:  ptrIntValue(input.EstimateMinutes),
+		RemainingMinutes: input.RemainingMinutes,
+		Recurrence:       ptrStringValue(input.Recurrence),
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	if input.Status != nil {
+		task.Status = toDomainStatus(*input.Status)
+	}
+	if input.Priority != nil {
+		task.Priority = toDomainPriority(*input.Priority)
 	}
 
-	if err := r.taskService.CreateTask(task); err != nil {
+	if err := r.taskService.CreateTask(task, graphqlActor(ctx)); err != nil {
 		return nil, fmt.Errorf("failed to create task: %w", err)
 	}
 
@@ -65,7 +181,7 @@ func (r *mutationResolver) UpdateTask(ctx context.Context, input model.UpdateTas
 		return nil, fmt.Errorf("invalid task ID: %w", err)
 	}
 
-	task, err := r.taskService.GetTaskByID(taskID)
+	task, err := r.taskService.GetTaskByID(taskID, graphqlActor(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get task: %w", err)
 	}
@@ -79,190 +195,1773 @@ func (r *mutationResolver) UpdateTask(ctx context.Context, input model.UpdateTas
 	if input.IsCompleted != nil {
 		task.IsCompleted = *input.IsCompleted
 	}
+	if input.Status != nil {
+		task.Status = toDomainStatus(*input.Status)
+	}
+	if input.Priority != nil {
+		task.Priority = toDomainPriority(*input.Priority)
+	}
+	if input.ParentID != nil {
+		if task.ParentID, err = strconv.Atoi(*input.ParentID); err != nil {
+			return nil, fmt.Errorf("invalid parent ID: %w", err)
+		}
+	}
+	if input.StartAt != nil {
+		task.StartAt = input.StartAt
+	}
+	if input.DueAt != nil {
+		task.DueAt = input.DueAt
+	}
+	if input.Timezone != nil {
+		task.Timezone = *input.Timezone
+	}
+	if input.EstimateMinutes != nil {
+		task.EstimateMinutes = *input.EstimateMinutes
+	}
+	if input.RemainingMinutes != nil {
+		task.RemainingMinutes = input.RemainingMinutes
+	}
+	if input.Recurrence != nil {
+		task.Recurrence = *input.Recurrence
+	}
 	task.UpdatedAt = time.Now()
 
-	if err := r.taskService.UpdateTask(task); err != nil {
+	if err := r.taskService.UpdateTask(task, graphqlActor(ctx)); err != nil {
 		return nil, fmt.Errorf("failed to update task: %w", err)
 	}
 
 	return task, nil
 }
 
-func (r *mutationResolver) DeleteTask(ctx context.Context, id string) (bool, error) {
+func (r *mutationResolver) ChangeTaskStatus(ctx context.Context, id string, status model.TaskStatus, cascade *bool) (*domain.Task, error) {
+	taskID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task ID: %w", err)
+	}
+	return r.taskService.ChangeStatus(taskID, toDomainStatus(status), graphqlActor(ctx), cascade != nil && *cascade)
+}
+
+func (r *mutationResolver) DeleteTask(ctx context.Context, id string, subtasks *model.SubtaskPolicy) (bool, error) {
 	taskID, err := strconv.Atoi(id)
 	if err != nil {
 		return false, err
 	}
-	if err := r.taskService.DeleteTask(taskID); err != nil {
+	policy := domain.DeleteReparent
+	if subtasks != nil && *subtasks == model.SubtaskPolicyCascade {
+		policy = domain.DeleteCascade
+	}
+	if err := r.taskService.DeleteTask(taskID, policy, graphqlActor(ctx)); err != nil {
 		return false, err
 	}
 	return true, nil
 }
 
-// Auth mutations
-func (r *mutationResolver) Login(ctx context.Context, input model.UserLogin) (*domain.AuthResponse, error) {
-	user, token, err := r.userService.Login(input.Email, input.Password)
+func (r *mutationResolver) RestoreTask(ctx context.Context, id string) (*domain.Task, error) {
+	taskID, err := strconv.Atoi(id)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid task ID: %w", err)
 	}
-	return &domain.AuthResponse{User: user, Token: token}, nil
+	return r.trashService.RestoreTask(taskID, graphqlActor(ctx))
 }
 
-func (r *mutationResolver) Register(ctx context.Context, input model.UserRegister) (*domain.User, error) {
-	user := &domain.User{
-		Email:     input.Email,
-		Name:      input.Name,
-		LastName:  input.LastName,
-		Avatar:    ptrStringValue(input.Avatar), // Adicionando o avatar
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+func (r *mutationResolver) ArchiveTask(ctx context.Context, id string) (*domain.Task, error) {
+	taskID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task ID: %w", err)
 	}
+	return r.taskService.ArchiveTask(taskID, graphqlActor(ctx))
+}
 
-	if err := user.HashPassword(input.Password); err != nil {
-		return nil, err
+func (r *mutationResolver) UnarchiveTask(ctx context.Context, id string) (*domain.Task, error) {
+	taskID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task ID: %w", err)
 	}
+	return r.taskService.UnarchiveTask(taskID, graphqlActor(ctx))
+}
 
-	if err := r.userService.Register(user); err != nil {
-		return nil, err
+func (r *mutationResolver) PurgeTask(ctx context.Context, id string) (bool, error) {
+	taskID, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid task ID: %w", err)
 	}
-
-	return user, nil
+	if err := r.trashService.PurgeTask(ctx, taskID, graphqlActor(ctx)); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
-// Query resolvers
-func (r *queryResolver) Tasks(ctx context.Context, filter *model.TaskFilter) (*model.TaskConnection, error) {
-	if filter == nil {
-		filter = &model.TaskFilter{
-			Page:  ptrInt(1),
-			Limit: ptrInt(10),
-		}
+func (r *mutationResolver) RestoreUser(ctx context.Context, id string) (*domain.User, error) {
+	userID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
 	}
+	return r.trashService.RestoreUser(userID, graphqlActor(ctx))
+}
 
-	domainFilter := domain.TaskFilter{
-		Search: ptrStringValue(filter.Search),
-		Page:   ptrIntValue(filter.Page),
-		Limit:  ptrIntValue(filter.Limit),
+func (r *mutationResolver) PurgeUser(ctx context.Context, id string) (bool, error) {
+	userID, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid user ID: %w", err)
+	}
+	if err := r.trashService.PurgeUser(userID, graphqlActor(ctx)); err != nil {
+		return false, err
 	}
+	return true, nil
+}
 
-	tasks, err := r.taskService.GetAllTasks(domainFilter)
+// Project mutations
+func (r *mutationResolver) CreateProject(ctx context.Context, input model.NewProject) (*domain.Project, error) {
+	workspaceID, err := parseOptionalID(input.WorkspaceID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tasks: %w", err)
+		return nil, fmt.Errorf("invalid workspace ID: %w", err)
+	}
+	project := &domain.Project{
+		Name:        input.Name,
+		Description: ptrStringValue(input.Description),
+		Color:       ptrStringValue(input.Color),
+		WorkspaceID: workspaceID,
 	}
+	if err := r.projectService.CreateProject(project, graphqlActor(ctx)); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
 
-	edges := make([]*model.TaskEdge, len(tasks.Edges))
-	for i, edge := range tasks.Edges {
-		edges[i] = &model.TaskEdge{
-			Node: &edge.Node,
-		}
+func (r *mutationResolver) UpdateProject(ctx context.Context, input model.UpdateProject) (*domain.Project, error) {
+	projectID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+	project, err := r.projectService.GetProjectByID(projectID, graphqlActor(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if input.Name != nil {
+		project.Name = *input.Name
+	}
+	if input.Description != nil {
+		project.Description = *input.Description
+	}
+	if input.Color != nil {
+		project.Color = *input.Color
 	}
+	if input.Archived != nil {
+		project.Archived = *input.Archived
+	}
+	if err := r.projectService.UpdateProject(project, graphqlActor(ctx)); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
 
-	return &model.TaskConnection{
-		Edges: edges,
-		PageInfo: &model.PageInfo{
-			HasNextPage:     tasks.PageInfo.HasNextPage,
-			HasPreviousPage: tasks.PageInfo.HasPreviousPage,
-			TotalCount:      tasks.PageInfo.TotalCount,
-		},
-	}, nil
+func (r *mutationResolver) DeleteProject(ctx context.Context, id string) (bool, error) {
+	projectID, err := strconv.Atoi(id)
+	if err != nil {
+		return false, err
+	}
+	if err := r.projectService.DeleteProject(projectID, graphqlActor(ctx)); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
-func (r *queryResolver) Task(ctx context.Context, id string) (*domain.Task, error) {
-	taskID, err := strconv.Atoi(id)
+func (r *mutationResolver) MoveTasksToProject(ctx context.Context, taskIds []string, projectID *string) ([]*domain.Task, error) {
+	ids, err := parseIDs(taskIds)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task ID: %w", err)
+	}
+	targetID, err := parseOptionalID(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+	tasks, err := r.projectService.MoveTasks(ids, targetID, graphqlActor(ctx))
 	if err != nil {
 		return nil, err
 	}
-	return r.taskService.GetTaskByID(taskID)
+	result := make([]*domain.Task, len(tasks))
+	for i := range tasks {
+		result[i] = &tasks[i]
+	}
+	return result, nil
 }
 
-func (r *queryResolver) Me(ctx context.Context) (*domain.User, error) {
-	userID, ok := ctx.Value("user_id").(string)
-	if !ok {
-		return nil, fmt.Errorf("unauthorized")
+// Board mutations
+func (r *mutationResolver) MoveTask(ctx context.Context, id string, column string, before *string, after *string) (*domain.Task, error) {
+	taskID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task ID: %w", err)
 	}
+	beforeID, err := parseOptionalID(before)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task ID: %w", err)
+	}
+	afterID, err := parseOptionalID(after)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task ID: %w", err)
+	}
+	return r.boardService.MoveTask(taskID, column, beforeID, afterID, graphqlActor(ctx))
+}
 
-	id, err := strconv.Atoi(userID)
+func (r *mutationResolver) SetBoardColumns(ctx context.Context, projectID string, columns []*model.BoardColumnInput) ([]*domain.BoardColumn, error) {
+	id, err := strconv.Atoi(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+	input := make([]domain.BoardColumn, len(columns))
+	for i, column := range columns {
+		columnID, err := parseOptionalID(column.ID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid column ID: %w", err)
+		}
+		input[i] = domain.BoardColumn{ID: columnID, Name: column.Name}
+	}
+	saved, err := r.boardService.SetColumns(id, input, graphqlActor(ctx))
 	if err != nil {
 		return nil, err
 	}
-
-	return r.userService.GetUserByID(id)
+	return toBoardColumnPointers(saved), nil
 }
 
-func (r *queryResolver) User(ctx context.Context, id string) (*domain.User, error) {
-	userID, err := strconv.Atoi(id)
+// Sprint mutations
+func (r *mutationResolver) CreateSprint(ctx context.Context, input model.NewSprint) (*domain.Sprint, error) {
+	projectID, err := strconv.Atoi(input.ProjectID)
 	if err != nil {
+		return nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+	sprint := &domain.Sprint{
+		ProjectID: projectID,
+		Name:      input.Name,
+		Goal:      ptrStringValue(input.Goal),
+		StartDate: input.StartDate,
+		EndDate:   input.EndDate,
+		Timezone:  ptrStringValue(input.Timezone),
+	}
+	if err := r.sprintService.CreateSprint(sprint, graphqlActor(ctx)); err != nil {
 		return nil, err
 	}
-	return r.userService.GetUserByID(userID)
+	return sprint, nil
 }
 
-func (r *queryResolver) UserByEmail(ctx context.Context, email string) (*domain.User, error) {
-	return r.userService.GetUserByEmail(email)
+func (r *mutationResolver) UpdateSprint(ctx context.Context, input model.UpdateSprint) (*domain.Sprint, error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sprint ID: %w", err)
+	}
+	sprint, err := r.sprintService.GetSprint(id, graphqlActor(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if input.Name != nil {
+		sprint.Name = *input.Name
+	}
+	if input.Goal != nil {
+		sprint.Goal = *input.Goal
+	}
+	if input.StartDate != nil {
+		sprint.StartDate = *input.StartDate
+	}
+	if input.EndDate != nil {
+		sprint.EndDate = *input.EndDate
+	}
+	if input.Timezone != nil {
+		sprint.Timezone = *input.Timezone
+	}
+	if err := r.sprintService.UpdateSprint(sprint, graphqlActor(ctx)); err != nil {
+		return nil, err
+	}
+	return sprint, nil
 }
 
-func (r *queryResolver) Users(ctx context.Context) ([]*domain.User, error) {
-	return r.userService.GetAllUsers()
+func (r *mutationResolver) DeleteSprint(ctx context.Context, id string) (bool, error) {
+	sprintID, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid sprint ID: %w", err)
+	}
+	if err := r.sprintService.DeleteSprint(sprintID, graphqlActor(ctx)); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
-// Field resolvers
-func (r *taskResolver) ID(ctx context.Context, obj *domain.Task) (string, error) {
-	return strconv.Itoa(obj.ID), nil
+func (r *mutationResolver) AddTasksToSprint(ctx context.Context, sprintID string, taskIds []string) ([]*domain.Task, error) {
+	id, err := strconv.Atoi(sprintID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sprint ID: %w", err)
+	}
+	ids, err := parseIDs(taskIds)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task ID: %w", err)
+	}
+	tasks, err := r.sprintService.AddTasks(id, ids, graphqlActor(ctx))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.Task, len(tasks))
+	for i := range tasks {
+		result[i] = &tasks[i]
+	}
+	return result, nil
 }
 
-func (r *taskResolver) UserID(ctx context.Context, obj *domain.Task) (string, error) {
-	return strconv.Itoa(obj.UserID), nil
+func (r *mutationResolver) RemoveTaskFromSprint(ctx context.Context, sprintID string, taskID string) (*domain.Task, error) {
+	id, err := strconv.Atoi(sprintID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sprint ID: %w", err)
+	}
+	tid, err := strconv.Atoi(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task ID: %w", err)
+	}
+	return r.sprintService.RemoveTask(id, tid, graphqlActor(ctx))
 }
 
-func (r *taskResolver) CreatedAt(ctx context.Context, obj *domain.Task) (string, error) {
-	return obj.CreatedAt.Format(time.RFC3339), nil
+// Label mutations
+func (r *mutationResolver) CreateLabel(ctx context.Context, input model.NewLabel) (*domain.Label, error) {
+	workspaceID, err := parseOptionalID(input.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workspace ID: %w", err)
+	}
+	label := &domain.Label{
+		Name:        input.Name,
+		Color:       ptrStringValue(input.Color),
+		WorkspaceID: workspaceID,
+	}
+	if err := r.labelService.CreateLabel(label, graphqlActor(ctx)); err != nil {
+		return nil, err
+	}
+	return label, nil
 }
 
-func (r *taskResolver) UpdatedAt(ctx context.Context, obj *domain.Task) (string, error) {
-	return obj.UpdatedAt.Format(time.RFC3339), nil
+func (r *mutationResolver) AddLabelToTask(ctx context.Context, taskID string, labelID string) (*domain.Task, error) {
+	ids, err := parseIDs([]string{taskID, labelID})
+	if err != nil {
+		return nil, err
+	}
+	return r.labelService.AddLabelToTask(ids[0], ids[1], graphqlActor(ctx))
 }
 
-func (r *taskResolver) Description(ctx context.Context, obj *domain.Task) (string, error) {
-	return obj.Description, nil
+func (r *mutationResolver) RemoveLabelFromTask(ctx context.Context, taskID string, labelID string) (*domain.Task, error) {
+	ids, err := parseIDs([]string{taskID, labelID})
+	if err != nil {
+		return nil, err
+	}
+	return r.labelService.RemoveLabelFromTask(ids[0], ids[1], graphqlActor(ctx))
 }
 
-func (r *userResolver) ID(ctx context.Context, obj *domain.User) (string, error) {
-	return strconv.Itoa(obj.ID), nil
+func (r *mutationResolver) AssignTask(ctx context.Context, taskID string, userID string) (*domain.Task, error) {
+	tid, uid, err := parseTaskUser(ctx, taskID, &userID)
+	if err != nil {
+		return nil, err
+	}
+	return r.taskService.AssignTask(tid, uid, graphqlActor(ctx))
 }
 
-func (r *userResolver) CreatedAt(ctx context.Context, obj *domain.User) (string, error) {
-	return obj.CreatedAt.Format(time.RFC3339), nil
+func (r *mutationResolver) UnassignTask(ctx context.Context, taskID string, userID string) (*domain.Task, error) {
+	tid, uid, err := parseTaskUser(ctx, taskID, &userID)
+	if err != nil {
+		return nil, err
+	}
+	return r.taskService.UnassignTask(tid, uid, graphqlActor(ctx))
 }
 
-func (r *userResolver) UpdatedAt(ctx context.Context, obj *domain.User) (string, error) {
-	return obj.UpdatedAt.Format(time.RFC3339), nil
+func (r *mutationResolver) WatchTask(ctx context.Context, taskID string, userID *string) (*domain.Task, error) {
+	tid, uid, err := parseTaskUser(ctx, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return r.taskService.WatchTask(tid, uid, graphqlActor(ctx))
 }
 
-func (r *userResolver) Avatar(ctx context.Context, obj *domain.User) (string, error) {
-	if obj.Avatar == "" {
-		return "", nil
+func (r *mutationResolver) UnwatchTask(ctx context.Context, taskID string, userID *string) (*domain.Task, error) {
+	tid, uid, err := parseTaskUser(ctx, taskID, userID)
+	if err != nil {
+		return nil, err
 	}
-	return obj.Avatar, nil
+	return r.taskService.UnwatchTask(tid, uid, graphqlActor(ctx))
 }
 
-// Helper functions
-func ptrString(s string) *string {
-	return &s
+func (r *mutationResolver) SkipOccurrence(ctx context.Context, id string) (*domain.Task, error) {
+	taskID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task ID: %w", err)
+	}
+	return r.taskService.SkipOccurrence(taskID, graphqlActor(ctx))
 }
 
-func ptrInt(i int) *int {
-	return &i
+func (r *mutationResolver) EndRecurringSeries(ctx context.Context, id string) (*domain.Task, error) {
+	taskID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task ID: %w", err)
+	}
+	return r.taskService.EndSeries(taskID, graphqlActor(ctx))
 }
 
-func ptrStringValue(s *string) string {
-	if s == nil {
-		return ""
+// Comment mutations
+func (r *mutationResolver) AddComment(ctx context.Context, taskID string, body string, parentID *string) (*domain.Comment, error) {
+	if _, ok := middleware.UserIDFromContext(ctx); !ok {
+		return nil, fmt.Errorf("unauthorized")
 	}
-	return *s
+	id, err := strconv.Atoi(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task ID: %w", err)
+	}
+	parent, err := parseOptionalID(parentID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parent ID: %w", err)
+	}
+	return r.commentService.AddComment(id, parent, body, graphqlActor(ctx))
 }
 
-func ptrIntValue(i *int) int {
-	if i == nil {
-		return 0
+func (r *mutationResolver) UpdateComment(ctx context.Context, id string, body string) (*domain.Comment, error) {
+	commentID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid comment ID: %w", err)
+	}
+	return r.commentService.UpdateComment(commentID, body, graphqlActor(ctx))
+}
+
+func (r *mutationResolver) DeleteComment(ctx context.Context, id string) (bool, error) {
+	commentID, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid comment ID: %w", err)
+	}
+	if err := r.commentService.DeleteComment(commentID, graphqlActor(ctx)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Attachment mutations
+func (r *mutationResolver) UploadAttachment(ctx context.Context, taskID string, file graphql.Upload) (*domain.Attachment, error) {
+	id, err := strconv.Atoi(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task ID: %w", err)
+	}
+	return r.attachmentService.Upload(ctx, id, file.Filename, file.File, file.Size, graphqlActor(ctx))
+}
+
+func (r *mutationResolver) DeleteAttachment(ctx context.Context, id string) (bool, error) {
+	attachmentID, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid attachment ID: %w", err)
+	}
+	if err := r.attachmentService.DeleteAttachment(ctx, attachmentID, graphqlActor(ctx)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Dependency mutations
+func (r *mutationResolver) AddTaskDependency(ctx context.Context, taskID string, blockedByID string) (*domain.Task, error) {
+	ids, err := parseIDs([]string{taskID, blockedByID})
+	if err != nil {
+		return nil, err
+	}
+	return r.dependencyService.AddDependency(ids[0], ids[1], graphqlActor(ctx))
+}
+
+func (r *mutationResolver) RemoveTaskDependency(ctx context.Context, taskID string, blockedByID string) (*domain.Task, error) {
+	ids, err := parseIDs([]string{taskID, blockedByID})
+	if err != nil {
+		return nil, err
+	}
+	if err := r.dependencyService.RemoveDependency(ids[0], ids[1], graphqlActor(ctx)); err != nil {
+		return nil, err
+	}
+	return r.taskService.GetTaskByID(ids[0], graphqlActor(ctx))
+}
+
+// Custom field mutations
+func (r *mutationResolver) CreateCustomField(ctx context.Context, input model.NewCustomField) (*domain.CustomField, error) {
+	workspaceID, err := strconv.Atoi(input.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workspace ID: %w", err)
+	}
+	field := &domain.CustomField{
+		WorkspaceID: workspaceID,
+		Name:        input.Name,
+		Type:        toDomainFieldType(input.Type),
+		Options:     input.Options,
+	}
+	if err := r.fieldService.CreateField(field, graphqlActor(ctx)); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+func (r *mutationResolver) UpdateCustomField(ctx context.Context, input model.UpdateCustomField) (*domain.CustomField, error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid custom field ID: %w", err)
+	}
+	field, err := r.fieldService.GetField(id, graphqlActor(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if input.Name != nil {
+		field.Name = *input.Name
+	}
+	if input.Options != nil {
+		field.Options = input.Options
+	}
+	if err := r.fieldService.UpdateField(field, graphqlActor(ctx)); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+func (r *mutationResolver) DeleteCustomField(ctx context.Context, id string) (bool, error) {
+	fieldID, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid custom field ID: %w", err)
+	}
+	if err := r.fieldService.DeleteField(fieldID, graphqlActor(ctx)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *mutationResolver) SetCustomFieldValue(ctx context.Context, taskID string, fieldID string, value model.CustomFieldValueInput) (*domain.Task, error) {
+	tID, fID, err := parseTaskField(taskID, fieldID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := parseOptionalID(value.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	return r.fieldService.SetValue(tID, fID, domain.CustomFieldValue{
+		Text:    ptrStringValue(value.Text),
+		Number:  value.Number,
+		Date:    value.Date,
+		Options: value.Options,
+		UserID:  userID,
+		Checked: ptrBoolValue(value.Checked),
+	}, graphqlActor(ctx))
+}
+
+func (r *mutationResolver) ClearCustomFieldValue(ctx context.Context, taskID string, fieldID string) (*domain.Task, error) {
+	tID, fID, err := parseTaskField(taskID, fieldID)
+	if err != nil {
+		return nil, err
+	}
+	return r.fieldService.ClearValue(tID, fID, graphqlActor(ctx))
+}
+
+// Template mutations
+func (r *mutationResolver) CreateTemplate(ctx context.Context, input model.NewTemplate) (*domain.TaskTemplate, error) {
+	workspaceID, err := parseOptionalID(input.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workspace ID: %w", err)
+	}
+	task, err := toDomainTemplateTask(input.Task)
+	if err != nil {
+		return nil, err
+	}
+	template := &domain.TaskTemplate{WorkspaceID: workspaceID, Name: input.Name, Task: task}
+	if err := r.templateService.CreateTemplate(template, graphqlActor(ctx)); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (r *mutationResolver) UpdateTemplate(ctx context.Context, input model.UpdateTemplate) (*domain.TaskTemplate, error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template ID: %w", err)
+	}
+	template, err := r.templateService.GetTemplate(id, graphqlActor(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if input.Name != nil {
+		template.Name = *input.Name
+	}
+	if input.Task != nil {
+		if template.Task, err = toDomainTemplateTask(input.Task); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.templateService.UpdateTemplate(template, graphqlActor(ctx)); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (r *mutationResolver) DeleteTemplate(ctx context.Context, id string) (bool, error) {
+	templateID, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid template ID: %w", err)
+	}
+	if err := r.templateService.DeleteTemplate(templateID, graphqlActor(ctx)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *mutationResolver) InstantiateTemplate(ctx context.Context, id string, input *model.InstantiateTemplate) (*domain.Task, error) {
+	templateID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template ID: %w", err)
+	}
+	if input == nil {
+		input = &model.InstantiateTemplate{}
+	}
+	projectID, err := parseOptionalID(input.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+	parentID, err := parseOptionalID(input.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parent ID: %w", err)
+	}
+	params := domain.TemplateParams{Values: map[string]string{}, ProjectID: projectID, ParentID: parentID}
+	for _, value := range input.Values {
+		params.Values[value.Name] = value.Value
+	}
+	template, err := r.templateService.GetTemplate(templateID, graphqlActor(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return r.taskService.InstantiateTemplate(template, params, graphqlActor(ctx))
+}
+
+// Checklist mutations
+func (r *mutationResolver) AddChecklistItem(ctx context.Context, taskID string, text string) (*domain.ChecklistItem, error) {
+	id, err := strconv.Atoi(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task ID: %w", err)
+	}
+	return r.checklistService.AddItem(id, text, graphqlActor(ctx))
+}
+
+func (r *mutationResolver) ToggleChecklistItem(ctx context.Context, id string) (*domain.ChecklistItem, error) {
+	itemID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checklist item ID: %w", err)
+	}
+	return r.checklistService.ToggleItem(itemID, graphqlActor(ctx))
+}
+
+func (r *mutationResolver) ReorderChecklist(ctx context.Context, taskID string, itemIds []string) ([]*domain.ChecklistItem, error) {
+	id, err := strconv.Atoi(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task ID: %w", err)
+	}
+	ids, err := parseIDs(itemIds)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checklist item ID: %w", err)
+	}
+	items, err := r.checklistService.ReorderChecklist(id, ids, graphqlActor(ctx))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.ChecklistItem, len(items))
+	for i := range items {
+		result[i] = &items[i]
+	}
+	return result, nil
+}
+
+func (r *mutationResolver) DeleteChecklistItem(ctx context.Context, id string) (bool, error) {
+	itemID, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid checklist item ID: %w", err)
+	}
+	if err := r.checklistService.DeleteItem(itemID, graphqlActor(ctx)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Time tracking mutations
+func (r *mutationResolver) StartTimer(ctx context.Context, taskID string, note *string) (*domain.TimeEntry, error) {
+	if _, ok := middleware.UserIDFromContext(ctx); !ok {
+		return nil, fmt.Errorf("unauthorized")
+	}
+	id, err := strconv.Atoi(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task ID: %w", err)
+	}
+	return r.timeService.StartTimer(id, ptrStringValue(note), graphqlActor(ctx))
+}
+
+func (r *mutationResolver) StopTimer(ctx context.Context) (*domain.TimeEntry, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("unauthorized")
+	}
+	return r.timeService.StopTimer(userID)
+}
+
+func (r *mutationResolver) LogTime(ctx context.Context, input model.LogTime) (*domain.TimeEntry, error) {
+	if _, ok := middleware.UserIDFromContext(ctx); !ok {
+		return nil, fmt.Errorf("unauthorized")
+	}
+	taskID, err := strconv.Atoi(input.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task ID: %w", err)
+	}
+	return r.timeService.LogTime(taskID, input.StartedAt, input.EndedAt, ptrStringValue(input.Note), graphqlActor(ctx))
+}
+
+func (r *mutationResolver) UpdateTimeEntry(ctx context.Context, id string, input model.UpdateTimeEntry) (*domain.TimeEntry, error) {
+	entryID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time entry ID: %w", err)
+	}
+	userID, _ := middleware.UserIDFromContext(ctx)
+	return r.timeService.UpdateEntry(entryID, userID, domain.TimeEntryUpdate{
+		StartedAt: input.StartedAt,
+		EndedAt:   input.EndedAt,
+		Note:      input.Note,
+	})
+}
+
+func (r *mutationResolver) DeleteTimeEntry(ctx context.Context, id string) (bool, error) {
+	entryID, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid time entry ID: %w", err)
+	}
+	userID, _ := middleware.UserIDFromContext(ctx)
+	if err := r.timeService.DeleteEntry(entryID, userID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Auth mutations
+func (r *mutationResolver) Login(ctx context.Context, input model.UserLogin) (*domain.AuthResponse, error) {
+	user, tokens, err := r.userService.Login(input.Email, input.Password)
+	if err != nil {
+		return nil, err
+	}
+	return toAuthResponse(user, tokens), nil
+}
+
+func (r *mutationResolver) RefreshToken(ctx context.Context, refreshToken string) (*domain.AuthResponse, error) {
+	user, tokens, err := r.userService.Refresh(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	return toAuthResponse(user, tokens), nil
+}
+
+func (r *mutationResolver) Logout(ctx context.Context) (bool, error) {
+	claims, ok := middleware.ClaimsFromContext(ctx)
+	if !ok {
+		return false, fmt.Errorf("unauthorized")
+	}
+	if err := r.userService.Logout(claims); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *mutationResolver) LogoutEverywhere(ctx context.Context) (bool, error) {
+	claims, ok := middleware.ClaimsFromContext(ctx)
+	if !ok {
+		return false, fmt.Errorf("unauthorized")
+	}
+	if err := r.userService.LogoutEverywhere(claims.UserID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *mutationResolver) Register(ctx context.Context, input model.UserRegister) (*domain.User, error) {
+	user := &domain.User{
+		Email:     input.Email,
+		Name:      input.Name,
+		LastName:  input.LastName,
+		Avatar:    ptrStringValue(input.Avatar), // Adicionando o avatar
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := user.HashPassword(input.Password); err != nil {
+		return nil, err
+	}
+
+	if err := r.userService.Register(user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// Query resolvers
+func (r *queryResolver) Tasks(ctx context.Context, filter *model.TaskFilter, orderBy []*model.TaskOrder) (*model.TaskConnection, error) {
+	domainFilter, err := toDomainFilter(ctx, filter, orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := r.taskService.GetAllTasks(domainFilter, graphqlActor(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	return toModelConnection(tasks), nil
+}
+
+func (r *queryResolver) Task(ctx context.Context, id string) (*domain.Task, error) {
+	taskID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.taskService.GetTaskByID(taskID, graphqlActor(ctx))
+}
+
+func (r *queryResolver) Me(ctx context.Context) (*domain.User, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	return r.userService.GetUserByID(userID, graphqlActor(ctx))
+}
+
+func (r *queryResolver) User(ctx context.Context, id string) (*domain.User, error) {
+	userID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.userService.GetUserByID(userID, graphqlActor(ctx))
+}
+
+func (r *queryResolver) UserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return r.userService.GetUserByEmail(email, graphqlActor(ctx))
+}
+
+func (r *queryResolver) Users(ctx context.Context) ([]*domain.User, error) {
+	return r.userService.GetAllUsers(graphqlActor(ctx))
+}
+
+func (r *queryResolver) Labels(ctx context.Context, workspaceID *string) ([]*domain.Label, error) {
+	id, err := parseOptionalID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := r.labelService.GetLabelsByWorkspaceID(id, graphqlActor(ctx))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.Label, len(labels))
+	for i := range labels {
+		result[i] = &labels[i]
+	}
+	return result, nil
+}
+
+func (r *queryResolver) CustomFields(ctx context.Context, workspaceID string) ([]*domain.CustomField, error) {
+	id, err := strconv.Atoi(workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workspace ID: %w", err)
+	}
+	fields, err := r.fieldService.GetFields(id, graphqlActor(ctx))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.CustomField, len(fields))
+	for i := range fields {
+		result[i] = &fields[i]
+	}
+	return result, nil
+}
+
+func (r *queryResolver) Templates(ctx context.Context, workspaceID *string) ([]*domain.TaskTemplate, error) {
+	id, err := parseOptionalID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	templates, err := r.templateService.GetTemplates(id, graphqlActor(ctx))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.TaskTemplate, len(templates))
+	for i := range templates {
+		result[i] = &templates[i]
+	}
+	return result, nil
+}
+
+func (r *queryResolver) Template(ctx context.Context, id string) (*domain.TaskTemplate, error) {
+	templateID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.templateService.GetTemplate(templateID, graphqlActor(ctx))
+}
+
+func (r *queryResolver) Projects(ctx context.Context, workspaceID *string, includeArchived *bool) ([]*domain.Project, error) {
+	id, err := parseOptionalID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := r.projectService.GetProjects(domain.ProjectFilter{
+		WorkspaceID:     id,
+		IncludeArchived: includeArchived != nil && *includeArchived,
+	}, graphqlActor(ctx))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.Project, len(projects))
+	for i := range projects {
+		result[i] = &projects[i]
+	}
+	return result, nil
+}
+
+func (r *queryResolver) Project(ctx context.Context, id string) (*domain.Project, error) {
+	projectID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.projectService.GetProjectByID(projectID, graphqlActor(ctx))
+}
+
+func (r *queryResolver) TimeEntries(ctx context.Context, filter *model.TimeEntryFilter) (*model.TimeEntryConnection, error) {
+	domainFilter, err := toDomainTimeEntryFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := r.timeService.GetEntries(domainFilter, graphqlActor(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return toModelTimeEntryConnection(entries), nil
+}
+
+func (r *queryResolver) TimeReport(ctx context.Context, filter *model.TimeEntryFilter) (*domain.TimeReport, error) {
+	domainFilter, err := toDomainTimeEntryFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	return r.timeService.GetReport(domainFilter, graphqlActor(ctx))
+}
+
+func (r *queryResolver) RunningTimer(ctx context.Context) (*domain.TimeEntry, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("unauthorized")
+	}
+	return r.timeService.GetRunningTimer(userID)
+}
+
+func (r *queryResolver) Board(ctx context.Context, projectID string, limit *int) (*domain.Board, error) {
+	id, err := strconv.Atoi(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+	return r.boardService.GetBoard(id, ptrIntValue(limit), graphqlActor(ctx))
+}
+
+func (r *queryResolver) BoardColumns(ctx context.Context, projectID string) ([]*domain.BoardColumn, error) {
+	id, err := strconv.Atoi(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+	columns, err := r.boardService.GetColumns(id, graphqlActor(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return toBoardColumnPointers(columns), nil
+}
+
+func (r *queryResolver) Sprints(ctx context.Context, projectID string) ([]*domain.Sprint, error) {
+	id, err := strconv.Atoi(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+	sprints, err := r.sprintService.GetSprints(id, graphqlActor(ctx))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.Sprint, len(sprints))
+	for i := range sprints {
+		result[i] = &sprints[i]
+	}
+	return result, nil
+}
+
+func (r *queryResolver) Sprint(ctx context.Context, id string) (*domain.Sprint, error) {
+	sprintID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sprint ID: %w", err)
+	}
+	return r.sprintService.GetSprint(sprintID, graphqlActor(ctx))
+}
+
+func (r *queryResolver) Burndown(ctx context.Context, sprintID string) (*domain.Burndown, error) {
+	id, err := strconv.Atoi(sprintID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sprint ID: %w", err)
+	}
+	return r.sprintService.GetBurndown(id, graphqlActor(ctx))
+}
+
+// Field resolvers
+func (r *queryResolver) DependencyGraph(ctx context.Context, projectID string) (*domain.DependencyGraph, error) {
+	id, err := strconv.Atoi(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+	return r.dependencyService.GetProjectGraph(id, graphqlActor(ctx))
+}
+
+func (r *queryResolver) DeletedTasks(ctx context.Context, search *string, page *int, limit *int) (*model.TaskConnection, error) {
+	filter := domain.TaskFilter{Search: ptrStringValue(search), Page: 1, Limit: 20}
+	if page != nil {
+		filter.Page = *page
+	}
+	if limit != nil {
+		filter.Limit = *limit
+	}
+	tasks, err := r.trashService.GetDeletedTasks(filter, graphqlActor(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return toModelConnection(tasks), nil
+}
+
+func (r *queryResolver) DeletedUsers(ctx context.Context) ([]*domain.User, error) {
+	users, err := r.trashService.GetDeletedUsers(graphqlActor(ctx))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.User, len(users))
+	for i := range users {
+		result[i] = &users[i]
+	}
+	return result, nil
+}
+
+func (r *taskResolver) ID(ctx context.Context, obj *domain.Task) (string, error) {
+	return strconv.Itoa(obj.ID), nil
+}
+
+func (r *taskResolver) Status(ctx context.Context, obj *domain.Task) (model.TaskStatus, error) {
+	return toModelStatus(obj.CurrentStatus()), nil
+}
+
+func (r *taskResolver) Priority(ctx context.Context, obj *domain.Task) (model.TaskPriority, error) {
+	return model.TaskPriority(strings.ToUpper(obj.Priority.String())), nil
+}
+
+func (r *taskResolver) StatusChangedBy(ctx context.Context, obj *domain.Task) (*string, error) {
+	return optionalID(obj.StatusChangedBy), nil
+}
+
+func (r *taskResolver) UserID(ctx context.Context, obj *domain.Task) (string, error) {
+	return strconv.Itoa(obj.UserID), nil
+}
+
+func (r *taskResolver) CreatorID(ctx context.Context, obj *domain.Task) (string, error) {
+	return strconv.Itoa(obj.UserID), nil
+}
+
+func (r *taskResolver) Creator(ctx context.Context, obj *domain.Task) (*domain.User, error) {
+	if obj.UserID == 0 {
+		return nil, nil
+	}
+	return r.relatedUser(obj.UserID)
+}
+
+func (r *taskResolver) DeletedAt(ctx context.Context, obj *domain.Task) (*time.Time, error) {
+	return deletedAt(obj.DeletedAt), nil
+}
+
+func (r *taskResolver) WorkspaceID(ctx context.Context, obj *domain.Task) (*string, error) {
+	return optionalID(obj.WorkspaceID), nil
+}
+
+func (r *taskResolver) Overdue(ctx context.Context, obj *domain.Task) (bool, error) {
+	return obj.IsOverdue(time.Now()), nil
+}
+
+func (r *taskResolver) CreatedAt(ctx context.Context, obj *domain.Task) (string, error) {
+	return obj.CreatedAt.Format(time.RFC3339), nil
+}
+
+func (r *taskResolver) UpdatedAt(ctx context.Context, obj *domain.Task) (string, error) {
+	return obj.UpdatedAt.Format(time.RFC3339), nil
+}
+
+func (r *taskResolver) Description(ctx context.Context, obj *domain.Task) (string, error) {
+	return obj.Description, nil
+}
+
+func (r *taskResolver) ProjectID(ctx context.Context, obj *domain.Task) (*string, error) {
+	return optionalID(obj.ProjectID), nil
+}
+
+func (r *taskResolver) ParentID(ctx context.Context, obj *domain.Task) (*string, error) {
+	return optionalID(obj.ParentID), nil
+}
+
+func (r *taskResolver) Parent(ctx context.Context, obj *domain.Task) (*domain.Task, error) {
+	if obj.ParentID == 0 {
+		return nil, nil
+	}
+	return r.taskService.GetTaskByID(obj.ParentID, graphqlActor(ctx))
+}
+
+func (r *taskResolver) Children(ctx context.Context, obj *domain.Task) ([]*domain.Task, error) {
+	children, err := r.taskService.GetSubtasks(obj.ID, graphqlActor(ctx))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.Task, len(children))
+	for i := range children {
+		result[i] = &children[i]
+	}
+	return result, nil
+}
+
+func (r *taskResolver) SeriesID(ctx context.Context, obj *domain.Task) (*string, error) {
+	return optionalID(obj.SeriesID), nil
+}
+
+func (r *taskResolver) NextOccurrences(ctx context.Context, obj *domain.Task, count *int) ([]*time.Time, error) {
+	n := 5
+	if count != nil {
+		n = min(*count, 100)
+	}
+	occurrences, err := obj.UpcomingOccurrences(n)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*time.Time, len(occurrences))
+	for i := range occurrences {
+		result[i] = &occurrences[i]
+	}
+	return result, nil
+}
+
+func (r *taskResolver) Comments(ctx context.Context, obj *domain.Task, page *int, limit *int) (*model.CommentConnection, error) {
+	filter := domain.CommentFilter{TaskID: obj.ID, Page: 1, Limit: 20}
+	if page != nil {
+		filter.Page = *page
+	}
+	if limit != nil {
+		filter.Limit = *limit
+	}
+	comments, err := r.commentService.GetComments(filter, graphqlActor(ctx))
+	if err != nil {
+		return nil, err
+	}
+	edges := make([]*model.CommentEdge, len(comments.Edges))
+	for i := range comments.Edges {
+		edges[i] = &model.CommentEdge{Node: &comments.Edges[i].Node}
+	}
+	return &model.CommentConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			HasNextPage:     comments.PageInfo.HasNextPage,
+			HasPreviousPage: comments.PageInfo.HasPreviousPage,
+			TotalCount:      comments.PageInfo.TotalCount,
+		},
+	}, nil
+}
+
+func (r *taskResolver) Attachments(ctx context.Context, obj *domain.Task) ([]*domain.Attachment, error) {
+	attachments, err := r.attachmentService.GetAttachments(obj.ID, graphqlActor(ctx))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.Attachment, len(attachments))
+	for i := range attachments {
+		result[i] = &attachments[i]
+	}
+	return result, nil
+}
+
+func (r *taskResolver) BlockedBy(ctx context.Context, obj *domain.Task) ([]*domain.Task, error) {
+	blockers, err := r.dependencyService.GetBlockers(obj.ID, graphqlActor(ctx))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.Task, len(blockers))
+	for i := range blockers {
+		result[i] = &blockers[i]
+	}
+	return result, nil
+}
+
+func (r *taskResolver) ColumnID(ctx context.Context, obj *domain.Task) (*string, error) {
+	return optionalID(obj.ColumnID), nil
+}
+
+func (r *taskResolver) Rank(ctx context.Context, obj *domain.Task) (*string, error) {
+	if obj.Rank == "" {
+		return nil, nil
+	}
+	return &obj.Rank, nil
+}
+
+func (r *taskResolver) SprintID(ctx context.Context, obj *domain.Task) (*string, error) {
+	return optionalID(obj.SprintID), nil
+}
+
+func (r *taskResolver) Progress(ctx context.Context, obj *domain.Task) (float64, error) {
+	return r.taskService.GetProgress(obj.ID, graphqlActor(ctx))
+}
+
+func (r *taskResolver) History(ctx context.Context, obj *domain.Task, page *int, limit *int) (*model.ActivityConnection, error) {
+	filter := domain.ActivityFilter{TaskID: obj.ID, Page: 1, Limit: 20}
+	if page != nil {
+		filter.Page = *page
+	}
+	if limit != nil {
+		filter.Limit = *limit
+	}
+	history, err := r.taskService.GetHistory(filter, graphqlActor(ctx))
+	if err != nil {
+		return nil, err
+	}
+	edges := make([]*model.ActivityEdge, len(history.Edges))
+	for i := range history.Edges {
+		edges[i] = &model.ActivityEdge{Node: &history.Edges[i].Node}
+	}
+	return &model.ActivityConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			HasNextPage:     history.PageInfo.HasNextPage,
+			HasPreviousPage: history.PageInfo.HasPreviousPage,
+			TotalCount:      history.PageInfo.TotalCount,
+		},
+	}, nil
+}
+
+func (r *taskResolver) TrackedSeconds(ctx context.Context, obj *domain.Task) (int, error) {
+	taskTime, err := r.timeService.GetTaskTime(obj.ID, graphqlActor(ctx))
+	if err != nil {
+		return 0, err
+	}
+	return int(taskTime.TrackedSeconds), nil
+}
+
+func (r *taskResolver) TimeEntries(ctx context.Context, obj *domain.Task, page *int, limit *int) (*model.TimeEntryConnection, error) {
+	filter := domain.TimeEntryFilter{TaskID: obj.ID, Page: 1, Limit: 20}
+	if page != nil {
+		filter.Page = *page
+	}
+	if limit != nil {
+		filter.Limit = *limit
+	}
+	entries, err := r.timeService.GetEntries(filter, graphqlActor(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return toModelTimeEntryConnection(entries), nil
+}
+
+func (r *templateTaskResolver) Priority(ctx context.Context, obj *domain.TemplateTask) (model.TaskPriority, error) {
+	return model.TaskPriority(strings.ToUpper(obj.Priority.String())), nil
+}
+
+func (r *timeEntryResolver) Task(ctx context.Context, obj *domain.TimeEntry) (*domain.Task, error) {
+	return r.taskService.GetTaskByID(obj.TaskID, graphqlActor(ctx))
+}
+
+func (r *timeEntryResolver) User(ctx context.Context, obj *domain.TimeEntry) (*domain.User, error) {
+	return r.relatedUser(obj.UserID)
+}
+
+func (r *timeEntryResolver) Running(ctx context.Context, obj *domain.TimeEntry) (bool, error) {
+	return obj.IsRunning(), nil
+}
+
+func (r *activityResolver) ActorID(ctx context.Context, obj *domain.Activity) (*string, error) {
+	return optionalID(obj.ActorID), nil
+}
+
+func (r *activityResolver) Actor(ctx context.Context, obj *domain.Activity) (*domain.User, error) {
+	if obj.ActorID == 0 {
+		return nil, nil
+	}
+	return r.relatedUser(obj.ActorID)
+}
+
+func (r *activityResolver) Source(ctx context.Context, obj *domain.Activity) (model.ActivitySource, error) {
+	return model.ActivitySource(strings.ToUpper(string(obj.Source))), nil
+}
+
+func (r *activityResolver) Action(ctx context.Context, obj *domain.Activity) (model.ActivityAction, error) {
+	return model.ActivityAction(strings.ToUpper(string(obj.Action))), nil
+}
+
+func (r *attachmentResolver) Uploader(ctx context.Context, obj *domain.Attachment) (*domain.User, error) {
+	if obj.UploaderID == 0 {
+		return nil, nil
+	}
+	return r.relatedUser(obj.UploaderID)
+}
+
+func (r *boardResolver) GroupBy(ctx context.Context, obj *domain.Board) (model.BoardGrouping, error) {
+	return model.BoardGrouping(strings.ToUpper(string(obj.GroupBy))), nil
+}
+
+func (r *boardLaneResolver) Status(ctx context.Context, obj *domain.BoardLane) (*model.TaskStatus, error) {
+	if obj.Status == "" {
+		return nil, nil
+	}
+	status := toModelStatus(obj.Status)
+	return &status, nil
+}
+
+func (r *boardLaneResolver) ColumnID(ctx context.Context, obj *domain.BoardLane) (*string, error) {
+	return optionalID(obj.ColumnID), nil
+}
+
+func (r *boardLaneResolver) Tasks(ctx context.Context, obj *domain.BoardLane) (*model.TaskConnection, error) {
+	return toModelConnection(&obj.Tasks), nil
+}
+
+func (r *checklistItemResolver) CheckedBy(ctx context.Context, obj *domain.ChecklistItem) (*string, error) {
+	return optionalID(obj.CheckedBy), nil
+}
+
+func (r *customFieldResolver) Type(ctx context.Context, obj *domain.CustomField) (model.CustomFieldType, error) {
+	return toModelFieldType(obj.Type), nil
+}
+
+func (r *customFieldValueResolver) Field(ctx context.Context, obj *domain.CustomFieldValue) (*domain.CustomField, error) {
+	return r.fieldService.GetField(obj.FieldID, graphqlActor(ctx))
+}
+
+func (r *customFieldValueResolver) Type(ctx context.Context, obj *domain.CustomFieldValue) (model.CustomFieldType, error) {
+	return toModelFieldType(obj.Type), nil
+}
+
+func (r *customFieldValueResolver) Text(ctx context.Context, obj *domain.CustomFieldValue) (*string, error) {
+	if obj.Type != domain.FieldText && obj.Type != domain.FieldSelect {
+		return nil, nil
+	}
+	return &obj.Text, nil
+}
+
+func (r *customFieldValueResolver) UserID(ctx context.Context, obj *domain.CustomFieldValue) (*string, error) {
+	return optionalID(obj.UserID), nil
+}
+
+func (r *customFieldValueResolver) User(ctx context.Context, obj *domain.CustomFieldValue) (*domain.User, error) {
+	if obj.UserID == 0 {
+		return nil, nil
+	}
+	return r.relatedUser(obj.UserID)
+}
+
+func (r *commentResolver) Author(ctx context.Context, obj *domain.Comment) (*domain.User, error) {
+	return r.relatedUser(obj.AuthorID)
+}
+
+func (r *commentResolver) ParentID(ctx context.Context, obj *domain.Comment) (*string, error) {
+	return optionalID(obj.ParentID), nil
+}
+
+func (r *commentResolver) Replies(ctx context.Context, obj *domain.Comment) ([]*domain.Comment, error) {
+	replies := obj.Replies
+	if replies == nil && obj.ParentID == 0 {
+		var err error
+		if replies, err = r.commentService.GetReplies(obj.ID, graphqlActor(ctx)); err != nil {
+			return nil, err
+		}
+	}
+	result := make([]*domain.Comment, len(replies))
+	for i := range replies {
+		result[i] = &replies[i]
+	}
+	return result, nil
+}
+
+func (r *commentResolver) History(ctx context.Context, obj *domain.Comment) ([]*domain.CommentRevision, error) {
+	revisions, err := r.commentService.GetHistory(obj.ID, graphqlActor(ctx))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.CommentRevision, len(revisions))
+	for i := range revisions {
+		result[i] = &revisions[i]
+	}
+	return result, nil
+}
+
+func (r *projectResolver) WorkspaceID(ctx context.Context, obj *domain.Project) (*string, error) {
+	return optionalID(obj.WorkspaceID), nil
+}
+
+func (r *projectResolver) Tasks(ctx context.Context, obj *domain.Project, filter *model.TaskFilter, orderBy []*model.TaskOrder) (*model.TaskConnection, error) {
+	domainFilter, err := toDomainFilter(ctx, filter, orderBy)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := r.projectService.GetProjectTasks(obj.ID, domainFilter, graphqlActor(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project tasks: %w", err)
+	}
+	return toModelConnection(tasks), nil
+}
+
+func (r *userResolver) ID(ctx context.Context, obj *domain.User) (string, error) {
+	return strconv.Itoa(obj.ID), nil
+}
+
+func (r *userResolver) CreatedAt(ctx context.Context, obj *domain.User) (string, error) {
+	return obj.CreatedAt.Format(time.RFC3339), nil
+}
+
+func (r *userResolver) UpdatedAt(ctx context.Context, obj *domain.User) (string, error) {
+	return obj.UpdatedAt.Format(time.RFC3339), nil
+}
+
+func (r *userResolver) DeletedAt(ctx context.Context, obj *domain.User) (*time.Time, error) {
+	return deletedAt(obj.DeletedAt), nil
+}
+
+func (r *userResolver) Avatar(ctx context.Context, obj *domain.User) (string, error) {
+	if obj.Avatar == "" {
+		return "", nil
+	}
+	return obj.Avatar, nil
+}
+
+// Helper functions
+func toDomainFilter(ctx context.Context, filter *model.TaskFilter, orderBy []*model.TaskOrder) (domain.TaskFilter, error) {
+	if filter == nil {
+		filter = &model.TaskFilter{
+			Page:  ptrInt(1),
+			Limit: ptrInt(10),
+		}
+	}
+
+	projectID, err := parseOptionalID(filter.ProjectID)
+	if err != nil {
+		return domain.TaskFilter{}, fmt.Errorf("invalid project ID: %w", err)
+	}
+	labelsAny, err := parseIDs(filter.LabelsAny)
+	if err != nil {
+		return domain.TaskFilter{}, fmt.Errorf("invalid label ID: %w", err)
+	}
+	labelsAll, err := parseIDs(filter.LabelsAll)
+	if err != nil {
+		return domain.TaskFilter{}, fmt.Errorf("invalid label ID: %w", err)
+	}
+	creatorID, err := parseOptionalID(filter.CreatorID)
+	if err != nil {
+		return domain.TaskFilter{}, fmt.Errorf("invalid creator ID: %w", err)
+	}
+	assigneeID, err := parseOptionalID(filter.AssigneeID)
+	if err != nil {
+		return domain.TaskFilter{}, fmt.Errorf("invalid assignee ID: %w", err)
+	}
+	watcherID, err := parseOptionalID(filter.WatcherID)
+	if err != nil {
+		return domain.TaskFilter{}, fmt.Errorf("invalid watcher ID: %w", err)
+	}
+	sprintID, err := parseOptionalID(filter.SprintID)
+	if err != nil {
+		return domain.TaskFilter{}, fmt.Errorf("invalid sprint ID: %w", err)
+	}
+	customFields := make([]domain.CustomFieldFilter, 0, len(filter.CustomFields))
+	for _, cf := range filter.CustomFields {
+		fieldID, err := strconv.Atoi(cf.FieldID)
+		if err != nil {
+			return domain.TaskFilter{}, fmt.Errorf("invalid custom field ID: %w", err)
+		}
+		customFields = append(customFields, domain.CustomFieldFilter{
+			FieldID: fieldID,
+			Equals:  ptrStringValue(cf.Equals),
+			Min:     ptrStringValue(cf.Min),
+			Max:     ptrStringValue(cf.Max),
+		})
+	}
+	sorts, err := toDomainSort(orderBy)
+	if err != nil {
+		return domain.TaskFilter{}, err
+	}
+	if (filter.CreatedByMe != nil && *filter.CreatedByMe) || (filter.AssignedToMe != nil && *filter.AssignedToMe) {
+		userID, ok := middleware.UserIDFromContext(ctx)
+		if !ok {
+			return domain.TaskFilter{}, fmt.Errorf("unauthorized")
+		}
+		if filter.CreatedByMe != nil && *filter.CreatedByMe {
+			creatorID = userID
+		}
+		if filter.AssignedToMe != nil && *filter.AssignedToMe {
+			assigneeID = userID
+		}
+	}
+
+	return domain.TaskFilter{
+		Search:          ptrStringValue(filter.Search),
+		Page:            ptrIntValue(filter.Page),
+		Limit:           ptrIntValue(filter.Limit),
+		ProjectID:       projectID,
+		DueBefore:       filter.DueBefore,
+		DueAfter:        filter.DueAfter,
+		Overdue:         filter.Overdue,
+		Sort:            sorts,
+		LabelsAny:       labelsAny,
+		LabelsAll:       labelsAll,
+		CreatorID:       creatorID,
+		AssigneeID:      assigneeID,
+		WatcherID:       watcherID,
+		IncludeArchived: ptrBoolValue(filter.IncludeArchived),
+		CustomFields:    customFields,
+		SprintID:        sprintID,
+	}, nil
+}
+
+func toDomainTemplateTask(input *model.TemplateTaskInput) (domain.TemplateTask, error) {
+	labelIDs, err := parseIDs(input.LabelIds)
+	if err != nil {
+		return domain.TemplateTask{}, fmt.Errorf("invalid label ID: %w", err)
+	}
+	task := domain.TemplateTask{
+		Title:            input.Title,
+		Description:      ptrStringValue(input.Description),
+		EstimateMinutes:  ptrIntValue(input.EstimateMinutes),
+		DueOffsetMinutes: input.DueOffsetMinutes,
+		LabelIDs:         labelIDs,
+		Checklist:        input.Checklist,
+	}
+	if input.Priority != nil {
+		task.Priority = toDomainPriority(*input.Priority)
+	}
+	for _, subtask := range input.Subtasks {
+		converted, err := toDomainTemplateTask(subtask)
+		if err != nil {
+			return domain.TemplateTask{}, err
+		}
+		task.Subtasks = append(task.Subtasks, converted)
+	}
+	return task, nil
+}
+
+func toModelConnection(tasks *domain.TaskConnection) *model.TaskConnection {
+	edges := make([]*model.TaskEdge, len(tasks.Edges))
+	for i, edge := range tasks.Edges {
+		edges[i] = &model.TaskEdge{
+			Node: &edge.Node,
+		}
+	}
+
+	return &model.TaskConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			HasNextPage:     tasks.PageInfo.HasNextPage,
+			HasPreviousPage: tasks.PageInfo.HasPreviousPage,
+			TotalCount:      tasks.PageInfo.TotalCount,
+		},
+	}
+}
+
+func toAuthResponse(user *domain.User, tokens *domain.TokenPair) *domain.AuthResponse {
+	return &domain.AuthResponse{User: user, Token: tokens.AccessToken, RefreshToken: tokens.RefreshToken, ExpiresIn: tokens.ExpiresIn}
+}
+
+func toBoardColumnPointers(columns []domain.BoardColumn) []*domain.BoardColumn {
+	result := make([]*domain.BoardColumn, len(columns))
+	for i := range columns {
+		result[i] = &columns[i]
+	}
+	return result
+}
+
+func toDomainTimeEntryFilter(filter *model.TimeEntryFilter) (domain.TimeEntryFilter, error) {
+	if filter == nil {
+		filter = &model.TimeEntryFilter{Page: ptrInt(1), Limit: ptrInt(20)}
+	}
+	taskID, err := parseOptionalID(filter.TaskID)
+	if err != nil {
+		return domain.TimeEntryFilter{}, fmt.Errorf("invalid task ID: %w", err)
+	}
+	projectID, err := parseOptionalID(filter.ProjectID)
+	if err != nil {
+		return domain.TimeEntryFilter{}, fmt.Errorf("invalid project ID: %w", err)
+	}
+	userID, err := parseOptionalID(filter.UserID)
+	if err != nil {
+		return domain.TimeEntryFilter{}, fmt.Errorf("invalid user ID: %w", err)
+	}
+	return domain.TimeEntryFilter{
+		TaskID:    taskID,
+		ProjectID: projectID,
+		UserID:    userID,
+		From:      filter.From,
+		To:        filter.To,
+		Page:      ptrIntValue(filter.Page),
+		Limit:     ptrIntValue(filter.Limit),
+	}, nil
+}
+
+func toModelTimeEntryConnection(entries *domain.TimeEntryConnection) *model.TimeEntryConnection {
+	edges := make([]*model.TimeEntryEdge, len(entries.Edges))
+	for i := range entries.Edges {
+		edges[i] = &model.TimeEntryEdge{Node: &entries.Edges[i].Node}
+	}
+	return &model.TimeEntryConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			HasNextPage:     entries.PageInfo.HasNextPage,
+			HasPreviousPage: entries.PageInfo.HasPreviousPage,
+			TotalCount:      entries.PageInfo.TotalCount,
+		},
+	}
+}
+
+// relatedUser returns a user linked to something the actor can already see, such as the
+// creator of a task or the author of a comment, whether or not they share a workspace.
+func (r *Resolver) relatedUser(id int) (*domain.User, error) {
+	return r.userService.GetUserByID(id, domain.SystemActor())
+}
+
+// graphqlActor is the authenticated user making a change through the GraphQL API.
+func graphqlActor(ctx context.Context) domain.Actor {
+	userID, _ := middleware.UserIDFromContext(ctx)
+	return domain.Actor{UserID: userID, Source: domain.SourceGraphQL}
+}
+
+// parseTaskUser parses the task and user IDs of a membership mutation; a missing user means the caller.
+func parseTaskUser(ctx context.Context, taskID string, userID *string) (int, int, error) {
+	tid, err := strconv.Atoi(taskID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid task ID: %w", err)
+	}
+	if userID == nil {
+		uid, ok := middleware.UserIDFromContext(ctx)
+		if !ok {
+			return 0, 0, fmt.Errorf("unauthorized")
+		}
+		return tid, uid, nil
+	}
+	uid, err := strconv.Atoi(*userID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid user ID: %w", err)
+	}
+	return tid, uid, nil
+}
+
+func parseTaskField(taskID, fieldID string) (int, int, error) {
+	tID, err := strconv.Atoi(taskID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid task ID: %w", err)
+	}
+	fID, err := strconv.Atoi(fieldID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid custom field ID: %w", err)
+	}
+	return tID, fID, nil
+}
+
+func deletedAt(t gorm.DeletedAt) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+func ptrString(s string) *string {
+	return &s
+}
+
+func ptrInt(i int) *int {
+	return &i
+}
+
+func ptrStringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func ptrIntValue(i *int) int {
+	if i == nil {
+		return 0
 	}
 	return *i
 }
+
+// optionalID renders a zero foreign key as null.
+func optionalID(id int) *string {
+	if id == 0 {
+		return nil
+	}
+	return ptrString(strconv.Itoa(id))
+}
+
+func parseOptionalID(id *string) (int, error) {
+	if id == nil {
+		return 0, nil
+	}
+	return strconv.Atoi(*id)
+}
+
+func parseIDs(ids []string) ([]int, error) {
+	result := make([]int, 0, len(ids))
+	for _, id := range ids {
+		n, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, n)
+	}
+	return result, nil
+}
+
+func toModelStatus(status domain.TaskStatus) model.TaskStatus {
+	return model.TaskStatus(strings.ToUpper(string(status)))
+}
+
+func toDomainStatus(status model.TaskStatus) domain.TaskStatus {
+	return domain.TaskStatus(strings.ToLower(string(status)))
+}
+
+func toDomainPriority(priority model.TaskPriority) domain.TaskPriority {
+	p, _ := domain.ParsePriority(string(priority))
+	return p
+}
+
+var taskOrderFields = map[model.TaskOrderField]domain.TaskSortField{
+	model.TaskOrderFieldPriority:    domain.SortByPriority,
+	model.TaskOrderFieldDueAt:       domain.SortByDueAt,
+	model.TaskOrderFieldCreatedAt:   domain.SortByCreatedAt,
+	model.TaskOrderFieldTitle:       domain.SortByTitle,
+	model.TaskOrderFieldRank:        domain.SortByRank,
+	model.TaskOrderFieldCustomField: domain.SortByCustomField,
+}
+
+func toDomainSort(orderBy []*model.TaskOrder) ([]domain.TaskSort, error) {
+	sorts := make([]domain.TaskSort, 0, len(orderBy))
+	for _, order := range orderBy {
+		fieldID, err := parseOptionalID(order.CustomFieldID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid custom field ID: %w", err)
+		}
+		if (order.Field == model.TaskOrderFieldCustomField) != (fieldID != 0) {
+			return nil, fmt.Errorf("%w: customFieldId goes with the CUSTOM_FIELD order field", domain.ErrInvalidSort)
+		}
+		sorts = append(sorts, domain.TaskSort{
+			Field:   taskOrderFields[order.Field],
+			Desc:    order.Direction != nil && *order.Direction == model.OrderDirectionDesc,
+			FieldID: fieldID,
+		})
+	}
+	return sorts, nil
+}
+
+func toModelFieldType(t domain.CustomFieldType) model.CustomFieldType {
+	return model.CustomFieldType(strings.ToUpper(string(t)))
+}
+
+func toDomainFieldType(t model.CustomFieldType) domain.CustomFieldType {
+	return domain.CustomFieldType(strings.ToLower(string(t)))
+}
+
+func ptrBoolValue(b *bool) bool {
+	return b != nil && *b
+}
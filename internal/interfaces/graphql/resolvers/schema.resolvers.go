@@ -10,8 +10,111 @@ import (
 	"task-manager-app/backend/internal/domain"
 	"task-manager-app/backend/internal/interfaces/graphql/generated"
 	"task-manager-app/backend/internal/interfaces/graphql/model"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
 )
 
+// ActorID is the resolver for the actorId field.
+func (r *activityResolver) ActorID(ctx context.Context, obj *domain.Activity) (*string, error) {
+	panic(fmt.Errorf("not implemented: ActorID - actorId"))
+}
+
+// Actor is the resolver for the actor field.
+func (r *activityResolver) Actor(ctx context.Context, obj *domain.Activity) (*domain.User, error) {
+	panic(fmt.Errorf("not implemented: Actor - actor"))
+}
+
+// Source is the resolver for the source field.
+func (r *activityResolver) Source(ctx context.Context, obj *domain.Activity) (model.ActivitySource, error) {
+	panic(fmt.Errorf("not implemented: Source - source"))
+}
+
+// Action is the resolver for the action field.
+func (r *activityResolver) Action(ctx context.Context, obj *domain.Activity) (model.ActivityAction, error) {
+	panic(fmt.Errorf("not implemented: Action - action"))
+}
+
+// Uploader is the resolver for the uploader field.
+func (r *attachmentResolver) Uploader(ctx context.Context, obj *domain.Attachment) (*domain.User, error) {
+	panic(fmt.Errorf("not implemented: Uploader - uploader"))
+}
+
+// GroupBy is the resolver for the groupBy field.
+func (r *boardResolver) GroupBy(ctx context.Context, obj *domain.Board) (model.BoardGrouping, error) {
+	panic(fmt.Errorf("not implemented: GroupBy - groupBy"))
+}
+
+// Status is the resolver for the status field.
+func (r *boardLaneResolver) Status(ctx context.Context, obj *domain.BoardLane) (*model.TaskStatus, error) {
+	panic(fmt.Errorf("not implemented: Status - status"))
+}
+
+// ColumnID is the resolver for the columnId field.
+func (r *boardLaneResolver) ColumnID(ctx context.Context, obj *domain.BoardLane) (*string, error) {
+	panic(fmt.Errorf("not implemented: ColumnID - columnId"))
+}
+
+// Tasks is the resolver for the tasks field.
+func (r *boardLaneResolver) Tasks(ctx context.Context, obj *domain.BoardLane) (*model.TaskConnection, error) {
+	panic(fmt.Errorf("not implemented: Tasks - tasks"))
+}
+
+// CheckedBy is the resolver for the checkedBy field.
+func (r *checklistItemResolver) CheckedBy(ctx context.Context, obj *domain.ChecklistItem) (*string, error) {
+	panic(fmt.Errorf("not implemented: CheckedBy - checkedBy"))
+}
+
+// Author is the resolver for the author field.
+func (r *commentResolver) Author(ctx context.Context, obj *domain.Comment) (*domain.User, error) {
+	panic(fmt.Errorf("not implemented: Author - author"))
+}
+
+// ParentID is the resolver for the parentId field.
+func (r *commentResolver) ParentID(ctx context.Context, obj *domain.Comment) (*string, error) {
+	panic(fmt.Errorf("not implemented: ParentID - parentId"))
+}
+
+// Replies is the resolver for the replies field.
+func (r *commentResolver) Replies(ctx context.Context, obj *domain.Comment) ([]*domain.Comment, error) {
+	panic(fmt.Errorf("not implemented: Replies - replies"))
+}
+
+// History is the resolver for the history field.
+func (r *commentResolver) History(ctx context.Context, obj *domain.Comment) ([]*domain.CommentRevision, error) {
+	panic(fmt.Errorf("not implemented: History - history"))
+}
+
+// Type is the resolver for the type field.
+func (r *customFieldResolver) Type(ctx context.Context, obj *domain.CustomField) (model.CustomFieldType, error) {
+	panic(fmt.Errorf("not implemented: Type - type"))
+}
+
+// Field is the resolver for the field field.
+func (r *customFieldValueResolver) Field(ctx context.Context, obj *domain.CustomFieldValue) (*domain.CustomField, error) {
+	panic(fmt.Errorf("not implemented: Field - field"))
+}
+
+// Type is the resolver for the type field.
+func (r *customFieldValueResolver) Type(ctx context.Context, obj *domain.CustomFieldValue) (model.CustomFieldType, error) {
+	panic(fmt.Errorf("not implemented: Type - type"))
+}
+
+// Text is the resolver for the text field.
+func (r *customFieldValueResolver) Text(ctx context.Context, obj *domain.CustomFieldValue) (*string, error) {
+	panic(fmt.Errorf("not implemented: Text - text"))
+}
+
+// UserID is the resolver for the userId field.
+func (r *customFieldValueResolver) UserID(ctx context.Context, obj *domain.CustomFieldValue) (*string, error) {
+	panic(fmt.Errorf("not implemented: UserID - userId"))
+}
+
+// User is the resolver for the user field.
+func (r *customFieldValueResolver) User(ctx context.Context, obj *domain.CustomFieldValue) (*domain.User, error) {
+	panic(fmt.Errorf("not implemented: User - user"))
+}
+
 // CreateTask is the resolver for the createTask field.
 func (r *mutationResolver) CreateTask(ctx context.Context, input model.NewTask) (*domain.Task, error) {
 	panic(fmt.Errorf("not implemented: CreateTask - createTask"))
@@ -23,10 +126,270 @@ func (r *mutationResolver) UpdateTask(ctx context.Context, input model.UpdateTas
 }
 
 // DeleteTask is the resolver for the deleteTask field.
-func (r *mutationResolver) DeleteTask(ctx context.Context, id string) (bool, error) {
+func (r *mutationResolver) DeleteTask(ctx context.Context, id string, subtasks *model.SubtaskPolicy) (bool, error) {
 	panic(fmt.Errorf("not implemented: DeleteTask - deleteTask"))
 }
 
+// RestoreTask is the resolver for the restoreTask field.
+func (r *mutationResolver) RestoreTask(ctx context.Context, id string) (*domain.Task, error) {
+	panic(fmt.Errorf("not implemented: RestoreTask - restoreTask"))
+}
+
+// ArchiveTask is the resolver for the archiveTask field.
+func (r *mutationResolver) ArchiveTask(ctx context.Context, id string) (*domain.Task, error) {
+	panic(fmt.Errorf("not implemented: ArchiveTask - archiveTask"))
+}
+
+// UnarchiveTask is the resolver for the unarchiveTask field.
+func (r *mutationResolver) UnarchiveTask(ctx context.Context, id string) (*domain.Task, error) {
+	panic(fmt.Errorf("not implemented: UnarchiveTask - unarchiveTask"))
+}
+
+// PurgeTask is the resolver for the purgeTask field.
+func (r *mutationResolver) PurgeTask(ctx context.Context, id string) (bool, error) {
+	panic(fmt.Errorf("not implemented: PurgeTask - purgeTask"))
+}
+
+// RestoreUser is the resolver for the restoreUser field.
+func (r *mutationResolver) RestoreUser(ctx context.Context, id string) (*domain.User, error) {
+	panic(fmt.Errorf("not implemented: RestoreUser - restoreUser"))
+}
+
+// PurgeUser is the resolver for the purgeUser field.
+func (r *mutationResolver) PurgeUser(ctx context.Context, id string) (bool, error) {
+	panic(fmt.Errorf("not implemented: PurgeUser - purgeUser"))
+}
+
+// ChangeTaskStatus is the resolver for the changeTaskStatus field.
+func (r *mutationResolver) ChangeTaskStatus(ctx context.Context, id string, status model.TaskStatus, cascade *bool) (*domain.Task, error) {
+	panic(fmt.Errorf("not implemented: ChangeTaskStatus - changeTaskStatus"))
+}
+
+// CreateProject is the resolver for the createProject field.
+func (r *mutationResolver) CreateProject(ctx context.Context, input model.NewProject) (*domain.Project, error) {
+	panic(fmt.Errorf("not implemented: CreateProject - createProject"))
+}
+
+// UpdateProject is the resolver for the updateProject field.
+func (r *mutationResolver) UpdateProject(ctx context.Context, input model.UpdateProject) (*domain.Project, error) {
+	panic(fmt.Errorf("not implemented: UpdateProject - updateProject"))
+}
+
+// DeleteProject is the resolver for the deleteProject field.
+func (r *mutationResolver) DeleteProject(ctx context.Context, id string) (bool, error) {
+	panic(fmt.Errorf("not implemented: DeleteProject - deleteProject"))
+}
+
+// MoveTasksToProject is the resolver for the moveTasksToProject field.
+func (r *mutationResolver) MoveTasksToProject(ctx context.Context, taskIds []string, projectID *string) ([]*domain.Task, error) {
+	panic(fmt.Errorf("not implemented: MoveTasksToProject - moveTasksToProject"))
+}
+
+// MoveTask is the resolver for the moveTask field.
+func (r *mutationResolver) MoveTask(ctx context.Context, id string, column string, before *string, after *string) (*domain.Task, error) {
+	panic(fmt.Errorf("not implemented: MoveTask - moveTask"))
+}
+
+// SetBoardColumns is the resolver for the setBoardColumns field.
+func (r *mutationResolver) SetBoardColumns(ctx context.Context, projectID string, columns []*model.BoardColumnInput) ([]*domain.BoardColumn, error) {
+	panic(fmt.Errorf("not implemented: SetBoardColumns - setBoardColumns"))
+}
+
+// CreateSprint is the resolver for the createSprint field.
+func (r *mutationResolver) CreateSprint(ctx context.Context, input model.NewSprint) (*domain.Sprint, error) {
+	panic(fmt.Errorf("not implemented: CreateSprint - createSprint"))
+}
+
+// UpdateSprint is the resolver for the updateSprint field.
+func (r *mutationResolver) UpdateSprint(ctx context.Context, input model.UpdateSprint) (*domain.Sprint, error) {
+	panic(fmt.Errorf("not implemented: UpdateSprint - updateSprint"))
+}
+
+// DeleteSprint is the resolver for the deleteSprint field.
+func (r *mutationResolver) DeleteSprint(ctx context.Context, id string) (bool, error) {
+	panic(fmt.Errorf("not implemented: DeleteSprint - deleteSprint"))
+}
+
+// AddTasksToSprint is the resolver for the addTasksToSprint field.
+func (r *mutationResolver) AddTasksToSprint(ctx context.Context, sprintID string, taskIds []string) ([]*domain.Task, error) {
+	panic(fmt.Errorf("not implemented: AddTasksToSprint - addTasksToSprint"))
+}
+
+// RemoveTaskFromSprint is the resolver for the removeTaskFromSprint field.
+func (r *mutationResolver) RemoveTaskFromSprint(ctx context.Context, sprintID string, taskID string) (*domain.Task, error) {
+	panic(fmt.Errorf("not implemented: RemoveTaskFromSprint - removeTaskFromSprint"))
+}
+
+// CreateLabel is the resolver for the createLabel field.
+func (r *mutationResolver) CreateLabel(ctx context.Context, input model.NewLabel) (*domain.Label, error) {
+	panic(fmt.Errorf("not implemented: CreateLabel - createLabel"))
+}
+
+// AddLabelToTask is the resolver for the addLabelToTask field.
+func (r *mutationResolver) AddLabelToTask(ctx context.Context, taskID string, labelID string) (*domain.Task, error) {
+	panic(fmt.Errorf("not implemented: AddLabelToTask - addLabelToTask"))
+}
+
+// RemoveLabelFromTask is the resolver for the removeLabelFromTask field.
+func (r *mutationResolver) RemoveLabelFromTask(ctx context.Context, taskID string, labelID string) (*domain.Task, error) {
+	panic(fmt.Errorf("not implemented: RemoveLabelFromTask - removeLabelFromTask"))
+}
+
+// CreateCustomField is the resolver for the createCustomField field.
+func (r *mutationResolver) CreateCustomField(ctx context.Context, input model.NewCustomField) (*domain.CustomField, error) {
+	panic(fmt.Errorf("not implemented: CreateCustomField - createCustomField"))
+}
+
+// UpdateCustomField is the resolver for the updateCustomField field.
+func (r *mutationResolver) UpdateCustomField(ctx context.Context, input model.UpdateCustomField) (*domain.CustomField, error) {
+	panic(fmt.Errorf("not implemented: UpdateCustomField - updateCustomField"))
+}
+
+// DeleteCustomField is the resolver for the deleteCustomField field.
+func (r *mutationResolver) DeleteCustomField(ctx context.Context, id string) (bool, error) {
+	panic(fmt.Errorf("not implemented: DeleteCustomField - deleteCustomField"))
+}
+
+// SetCustomFieldValue is the resolver for the setCustomFieldValue field.
+func (r *mutationResolver) SetCustomFieldValue(ctx context.Context, taskID string, fieldID string, value model.CustomFieldValueInput) (*domain.Task, error) {
+	panic(fmt.Errorf("not implemented: SetCustomFieldValue - setCustomFieldValue"))
+}
+
+// ClearCustomFieldValue is the resolver for the clearCustomFieldValue field.
+func (r *mutationResolver) ClearCustomFieldValue(ctx context.Context, taskID string, fieldID string) (*domain.Task, error) {
+	panic(fmt.Errorf("not implemented: ClearCustomFieldValue - clearCustomFieldValue"))
+}
+
+// CreateTemplate is the resolver for the createTemplate field.
+func (r *mutationResolver) CreateTemplate(ctx context.Context, input model.NewTemplate) (*domain.TaskTemplate, error) {
+	panic(fmt.Errorf("not implemented: CreateTemplate - createTemplate"))
+}
+
+// UpdateTemplate is the resolver for the updateTemplate field.
+func (r *mutationResolver) UpdateTemplate(ctx context.Context, input model.UpdateTemplate) (*domain.TaskTemplate, error) {
+	panic(fmt.Errorf("not implemented: UpdateTemplate - updateTemplate"))
+}
+
+// DeleteTemplate is the resolver for the deleteTemplate field.
+func (r *mutationResolver) DeleteTemplate(ctx context.Context, id string) (bool, error) {
+	panic(fmt.Errorf("not implemented: DeleteTemplate - deleteTemplate"))
+}
+
+// InstantiateTemplate is the resolver for the instantiateTemplate field.
+func (r *mutationResolver) InstantiateTemplate(ctx context.Context, id string, input *model.InstantiateTemplate) (*domain.Task, error) {
+	panic(fmt.Errorf("not implemented: InstantiateTemplate - instantiateTemplate"))
+}
+
+// AssignTask is the resolver for the assignTask field.
+func (r *mutationResolver) AssignTask(ctx context.Context, taskID string, userID string) (*domain.Task, error) {
+	panic(fmt.Errorf("not implemented: AssignTask - assignTask"))
+}
+
+// UnassignTask is the resolver for the unassignTask field.
+func (r *mutationResolver) UnassignTask(ctx context.Context, taskID string, userID string) (*domain.Task, error) {
+	panic(fmt.Errorf("not implemented: UnassignTask - unassignTask"))
+}
+
+// WatchTask is the resolver for the watchTask field.
+func (r *mutationResolver) WatchTask(ctx context.Context, taskID string, userID *string) (*domain.Task, error) {
+	panic(fmt.Errorf("not implemented: WatchTask - watchTask"))
+}
+
+// UnwatchTask is the resolver for the unwatchTask field.
+func (r *mutationResolver) UnwatchTask(ctx context.Context, taskID string, userID *string) (*domain.Task, error) {
+	panic(fmt.Errorf("not implemented: UnwatchTask - unwatchTask"))
+}
+
+// AddTaskDependency is the resolver for the addTaskDependency field.
+func (r *mutationResolver) AddTaskDependency(ctx context.Context, taskID string, blockedByID string) (*domain.Task, error) {
+	panic(fmt.Errorf("not implemented: AddTaskDependency - addTaskDependency"))
+}
+
+// RemoveTaskDependency is the resolver for the removeTaskDependency field.
+func (r *mutationResolver) RemoveTaskDependency(ctx context.Context, taskID string, blockedByID string) (*domain.Task, error) {
+	panic(fmt.Errorf("not implemented: RemoveTaskDependency - removeTaskDependency"))
+}
+
+// AddComment is the resolver for the addComment field.
+func (r *mutationResolver) AddComment(ctx context.Context, taskID string, body string, parentID *string) (*domain.Comment, error) {
+	panic(fmt.Errorf("not implemented: AddComment - addComment"))
+}
+
+// UpdateComment is the resolver for the updateComment field.
+func (r *mutationResolver) UpdateComment(ctx context.Context, id string, body string) (*domain.Comment, error) {
+	panic(fmt.Errorf("not implemented: UpdateComment - updateComment"))
+}
+
+// DeleteComment is the resolver for the deleteComment field.
+func (r *mutationResolver) DeleteComment(ctx context.Context, id string) (bool, error) {
+	panic(fmt.Errorf("not implemented: DeleteComment - deleteComment"))
+}
+
+// UploadAttachment is the resolver for the uploadAttachment field.
+func (r *mutationResolver) UploadAttachment(ctx context.Context, taskID string, file graphql.Upload) (*domain.Attachment, error) {
+	panic(fmt.Errorf("not implemented: UploadAttachment - uploadAttachment"))
+}
+
+// DeleteAttachment is the resolver for the deleteAttachment field.
+func (r *mutationResolver) DeleteAttachment(ctx context.Context, id string) (bool, error) {
+	panic(fmt.Errorf("not implemented: DeleteAttachment - deleteAttachment"))
+}
+
+// AddChecklistItem is the resolver for the addChecklistItem field.
+func (r *mutationResolver) AddChecklistItem(ctx context.Context, taskID string, text string) (*domain.ChecklistItem, error) {
+	panic(fmt.Errorf("not implemented: AddChecklistItem - addChecklistItem"))
+}
+
+// ToggleChecklistItem is the resolver for the toggleChecklistItem field.
+func (r *mutationResolver) ToggleChecklistItem(ctx context.Context, id string) (*domain.ChecklistItem, error) {
+	panic(fmt.Errorf("not implemented: ToggleChecklistItem - toggleChecklistItem"))
+}
+
+// ReorderChecklist is the resolver for the reorderChecklist field.
+func (r *mutationResolver) ReorderChecklist(ctx context.Context, taskID string, itemIds []string) ([]*domain.ChecklistItem, error) {
+	panic(fmt.Errorf("not implemented: ReorderChecklist - reorderChecklist"))
+}
+
+// DeleteChecklistItem is the resolver for the deleteChecklistItem field.
+func (r *mutationResolver) DeleteChecklistItem(ctx context.Context, id string) (bool, error) {
+	panic(fmt.Errorf("not implemented: DeleteChecklistItem - deleteChecklistItem"))
+}
+
+// StartTimer is the resolver for the startTimer field.
+func (r *mutationResolver) StartTimer(ctx context.Context, taskID string, note *string) (*domain.TimeEntry, error) {
+	panic(fmt.Errorf("not implemented: StartTimer - startTimer"))
+}
+
+// StopTimer is the resolver for the stopTimer field.
+func (r *mutationResolver) StopTimer(ctx context.Context) (*domain.TimeEntry, error) {
+	panic(fmt.Errorf("not implemented: StopTimer - stopTimer"))
+}
+
+// LogTime is the resolver for the logTime field.
+func (r *mutationResolver) LogTime(ctx context.Context, input model.LogTime) (*domain.TimeEntry, error) {
+	panic(fmt.Errorf("not implemented: LogTime - logTime"))
+}
+
+// UpdateTimeEntry is the resolver for the updateTimeEntry field.
+func (r *mutationResolver) UpdateTimeEntry(ctx context.Context, id string, input model.UpdateTimeEntry) (*domain.TimeEntry, error) {
+	panic(fmt.Errorf("not implemented: UpdateTimeEntry - updateTimeEntry"))
+}
+
+// DeleteTimeEntry is the resolver for the deleteTimeEntry field.
+func (r *mutationResolver) DeleteTimeEntry(ctx context.Context, id string) (bool, error) {
+	panic(fmt.Errorf("not implemented: DeleteTimeEntry - deleteTimeEntry"))
+}
+
+// SkipOccurrence is the resolver for the skipOccurrence field.
+func (r *mutationResolver) SkipOccurrence(ctx context.Context, id string) (*domain.Task, error) {
+	panic(fmt.Errorf("not implemented: SkipOccurrence - skipOccurrence"))
+}
+
+// EndRecurringSeries is the resolver for the endRecurringSeries field.
+func (r *mutationResolver) EndRecurringSeries(ctx context.Context, id string) (*domain.Task, error) {
+	panic(fmt.Errorf("not implemented: EndRecurringSeries - endRecurringSeries"))
+}
+
 // Register is the resolver for the register field.
 func (r *mutationResolver) Register(ctx context.Context, input model.UserRegister) (*domain.User, error) {
 	panic(fmt.Errorf("not implemented: Register - register"))
@@ -37,8 +400,33 @@ func (r *mutationResolver) Login(ctx context.Context, input model.UserLogin) (*d
 	panic(fmt.Errorf("not implemented: Login - login"))
 }
 
+// RefreshToken is the resolver for the refreshToken field.
+func (r *mutationResolver) RefreshToken(ctx context.Context, refreshToken string) (*domain.AuthResponse, error) {
+	panic(fmt.Errorf("not implemented: RefreshToken - refreshToken"))
+}
+
+// Logout is the resolver for the logout field.
+func (r *mutationResolver) Logout(ctx context.Context) (bool, error) {
+	panic(fmt.Errorf("not implemented: Logout - logout"))
+}
+
+// LogoutEverywhere is the resolver for the logoutEverywhere field.
+func (r *mutationResolver) LogoutEverywhere(ctx context.Context) (bool, error) {
+	panic(fmt.Errorf("not implemented: LogoutEverywhere - logoutEverywhere"))
+}
+
+// WorkspaceID is the resolver for the workspaceId field.
+func (r *projectResolver) WorkspaceID(ctx context.Context, obj *domain.Project) (*string, error) {
+	panic(fmt.Errorf("not implemented: WorkspaceID - workspaceId"))
+}
+
 // Tasks is the resolver for the tasks field.
-func (r *queryResolver) Tasks(ctx context.Context, filter *model.TaskFilter) (*model.TaskConnection, error) {
+func (r *projectResolver) Tasks(ctx context.Context, obj *domain.Project, filter *model.TaskFilter, orderBy []*model.TaskOrder) (*model.TaskConnection, error) {
+	panic(fmt.Errorf("not implemented: Tasks - tasks"))
+}
+
+// Tasks is the resolver for the tasks field.
+func (r *queryResolver) Tasks(ctx context.Context, filter *model.TaskFilter, orderBy []*model.TaskOrder) (*model.TaskConnection, error) {
 	panic(fmt.Errorf("not implemented: Tasks - tasks"))
 }
 
@@ -67,16 +455,201 @@ func (r *queryResolver) Users(ctx context.Context) ([]*domain.User, error) {
 	panic(fmt.Errorf("not implemented: Users - users"))
 }
 
+// Labels is the resolver for the labels field.
+func (r *queryResolver) Labels(ctx context.Context, workspaceID *string) ([]*domain.Label, error) {
+	panic(fmt.Errorf("not implemented: Labels - labels"))
+}
+
+// CustomFields is the resolver for the customFields field.
+func (r *queryResolver) CustomFields(ctx context.Context, workspaceID string) ([]*domain.CustomField, error) {
+	panic(fmt.Errorf("not implemented: CustomFields - customFields"))
+}
+
+// Templates is the resolver for the templates field.
+func (r *queryResolver) Templates(ctx context.Context, workspaceID *string) ([]*domain.TaskTemplate, error) {
+	panic(fmt.Errorf("not implemented: Templates - templates"))
+}
+
+// Template is the resolver for the template field.
+func (r *queryResolver) Template(ctx context.Context, id string) (*domain.TaskTemplate, error) {
+	panic(fmt.Errorf("not implemented: Template - template"))
+}
+
+// Projects is the resolver for the projects field.
+func (r *queryResolver) Projects(ctx context.Context, workspaceID *string, includeArchived *bool) ([]*domain.Project, error) {
+	panic(fmt.Errorf("not implemented: Projects - projects"))
+}
+
+// Project is the resolver for the project field.
+func (r *queryResolver) Project(ctx context.Context, id string) (*domain.Project, error) {
+	panic(fmt.Errorf("not implemented: Project - project"))
+}
+
+// DependencyGraph is the resolver for the dependencyGraph field.
+func (r *queryResolver) DependencyGraph(ctx context.Context, projectID string) (*domain.DependencyGraph, error) {
+	panic(fmt.Errorf("not implemented: DependencyGraph - dependencyGraph"))
+}
+
+// Board is the resolver for the board field.
+func (r *queryResolver) Board(ctx context.Context, projectID string, limit *int) (*domain.Board, error) {
+	panic(fmt.Errorf("not implemented: Board - board"))
+}
+
+// BoardColumns is the resolver for the boardColumns field.
+func (r *queryResolver) BoardColumns(ctx context.Context, projectID string) ([]*domain.BoardColumn, error) {
+	panic(fmt.Errorf("not implemented: BoardColumns - boardColumns"))
+}
+
+// Sprints is the resolver for the sprints field.
+func (r *queryResolver) Sprints(ctx context.Context, projectID string) ([]*domain.Sprint, error) {
+	panic(fmt.Errorf("not implemented: Sprints - sprints"))
+}
+
+// Sprint is the resolver for the sprint field.
+func (r *queryResolver) Sprint(ctx context.Context, id string) (*domain.Sprint, error) {
+	panic(fmt.Errorf("not implemented: Sprint - sprint"))
+}
+
+// Burndown is the resolver for the burndown field.
+func (r *queryResolver) Burndown(ctx context.Context, sprintID string) (*domain.Burndown, error) {
+	panic(fmt.Errorf("not implemented: Burndown - burndown"))
+}
+
+// DeletedTasks is the resolver for the deletedTasks field.
+func (r *queryResolver) DeletedTasks(ctx context.Context, search *string, page *int, limit *int) (*model.TaskConnection, error) {
+	panic(fmt.Errorf("not implemented: DeletedTasks - deletedTasks"))
+}
+
+// DeletedUsers is the resolver for the deletedUsers field.
+func (r *queryResolver) DeletedUsers(ctx context.Context) ([]*domain.User, error) {
+	panic(fmt.Errorf("not implemented: DeletedUsers - deletedUsers"))
+}
+
+// TimeEntries is the resolver for the timeEntries field.
+func (r *queryResolver) TimeEntries(ctx context.Context, filter *model.TimeEntryFilter) (*model.TimeEntryConnection, error) {
+	panic(fmt.Errorf("not implemented: TimeEntries - timeEntries"))
+}
+
+// TimeReport is the resolver for the timeReport field.
+func (r *queryResolver) TimeReport(ctx context.Context, filter *model.TimeEntryFilter) (*domain.TimeReport, error) {
+	panic(fmt.Errorf("not implemented: TimeReport - timeReport"))
+}
+
+// RunningTimer is the resolver for the runningTimer field.
+func (r *queryResolver) RunningTimer(ctx context.Context) (*domain.TimeEntry, error) {
+	panic(fmt.Errorf("not implemented: RunningTimer - runningTimer"))
+}
+
 // ID is the resolver for the id field.
 func (r *taskResolver) ID(ctx context.Context, obj *domain.Task) (string, error) {
 	panic(fmt.Errorf("not implemented: ID - id"))
 }
 
+// Status is the resolver for the status field.
+func (r *taskResolver) Status(ctx context.Context, obj *domain.Task) (model.TaskStatus, error) {
+	panic(fmt.Errorf("not implemented: Status - status"))
+}
+
+// Priority is the resolver for the priority field.
+func (r *taskResolver) Priority(ctx context.Context, obj *domain.Task) (model.TaskPriority, error) {
+	panic(fmt.Errorf("not implemented: Priority - priority"))
+}
+
+// StatusChangedBy is the resolver for the statusChangedBy field.
+func (r *taskResolver) StatusChangedBy(ctx context.Context, obj *domain.Task) (*string, error) {
+	panic(fmt.Errorf("not implemented: StatusChangedBy - statusChangedBy"))
+}
+
 // UserID is the resolver for the userId field.
 func (r *taskResolver) UserID(ctx context.Context, obj *domain.Task) (string, error) {
 	panic(fmt.Errorf("not implemented: UserID - userId"))
 }
 
+// CreatorID is the resolver for the creatorId field.
+func (r *taskResolver) CreatorID(ctx context.Context, obj *domain.Task) (string, error) {
+	panic(fmt.Errorf("not implemented: CreatorID - creatorId"))
+}
+
+// Creator is the resolver for the creator field.
+func (r *taskResolver) Creator(ctx context.Context, obj *domain.Task) (*domain.User, error) {
+	panic(fmt.Errorf("not implemented: Creator - creator"))
+}
+
+// WorkspaceID is the resolver for the workspaceId field.
+func (r *taskResolver) WorkspaceID(ctx context.Context, obj *domain.Task) (*string, error) {
+	panic(fmt.Errorf("not implemented: WorkspaceID - workspaceId"))
+}
+
+// ProjectID is the resolver for the projectId field.
+func (r *taskResolver) ProjectID(ctx context.Context, obj *domain.Task) (*string, error) {
+	panic(fmt.Errorf("not implemented: ProjectID - projectId"))
+}
+
+// ParentID is the resolver for the parentId field.
+func (r *taskResolver) ParentID(ctx context.Context, obj *domain.Task) (*string, error) {
+	panic(fmt.Errorf("not implemented: ParentID - parentId"))
+}
+
+// Parent is the resolver for the parent field.
+func (r *taskResolver) Parent(ctx context.Context, obj *domain.Task) (*domain.Task, error) {
+	panic(fmt.Errorf("not implemented: Parent - parent"))
+}
+
+// Children is the resolver for the children field.
+func (r *taskResolver) Children(ctx context.Context, obj *domain.Task) ([]*domain.Task, error) {
+	panic(fmt.Errorf("not implemented: Children - children"))
+}
+
+// Progress is the resolver for the progress field.
+func (r *taskResolver) Progress(ctx context.Context, obj *domain.Task) (float64, error) {
+	panic(fmt.Errorf("not implemented: Progress - progress"))
+}
+
+// TrackedSeconds is the resolver for the trackedSeconds field.
+func (r *taskResolver) TrackedSeconds(ctx context.Context, obj *domain.Task) (int, error) {
+	panic(fmt.Errorf("not implemented: TrackedSeconds - trackedSeconds"))
+}
+
+// TimeEntries is the resolver for the timeEntries field.
+func (r *taskResolver) TimeEntries(ctx context.Context, obj *domain.Task, page *int, limit *int) (*model.TimeEntryConnection, error) {
+	panic(fmt.Errorf("not implemented: TimeEntries - timeEntries"))
+}
+
+// SeriesID is the resolver for the seriesId field.
+func (r *taskResolver) SeriesID(ctx context.Context, obj *domain.Task) (*string, error) {
+	panic(fmt.Errorf("not implemented: SeriesID - seriesId"))
+}
+
+// NextOccurrences is the resolver for the nextOccurrences field.
+func (r *taskResolver) NextOccurrences(ctx context.Context, obj *domain.Task, count *int) ([]*time.Time, error) {
+	panic(fmt.Errorf("not implemented: NextOccurrences - nextOccurrences"))
+}
+
+// Overdue is the resolver for the overdue field.
+func (r *taskResolver) Overdue(ctx context.Context, obj *domain.Task) (bool, error) {
+	panic(fmt.Errorf("not implemented: Overdue - overdue"))
+}
+
+// BlockedBy is the resolver for the blockedBy field.
+func (r *taskResolver) BlockedBy(ctx context.Context, obj *domain.Task) ([]*domain.Task, error) {
+	panic(fmt.Errorf("not implemented: BlockedBy - blockedBy"))
+}
+
+// Comments is the resolver for the comments field.
+func (r *taskResolver) Comments(ctx context.Context, obj *domain.Task, page *int, limit *int) (*model.CommentConnection, error) {
+	panic(fmt.Errorf("not implemented: Comments - comments"))
+}
+
+// Attachments is the resolver for the attachments field.
+func (r *taskResolver) Attachments(ctx context.Context, obj *domain.Task) ([]*domain.Attachment, error) {
+	panic(fmt.Errorf("not implemented: Attachments - attachments"))
+}
+
+// History is the resolver for the history field.
+func (r *taskResolver) History(ctx context.Context, obj *domain.Task, page *int, limit *int) (*model.ActivityConnection, error) {
+	panic(fmt.Errorf("not implemented: History - history"))
+}
+
 // CreatedAt is the resolver for the createdAt field.
 func (r *taskResolver) CreatedAt(ctx context.Context, obj *domain.Task) (string, error) {
 	panic(fmt.Errorf("not implemented: CreatedAt - createdAt"))
@@ -87,6 +660,46 @@ func (r *taskResolver) UpdatedAt(ctx context.Context, obj *domain.Task) (string,
 	panic(fmt.Errorf("not implemented: UpdatedAt - updatedAt"))
 }
 
+// DeletedAt is the resolver for the deletedAt field.
+func (r *taskResolver) DeletedAt(ctx context.Context, obj *domain.Task) (*time.Time, error) {
+	panic(fmt.Errorf("not implemented: DeletedAt - deletedAt"))
+}
+
+// ColumnID is the resolver for the columnId field.
+func (r *taskResolver) ColumnID(ctx context.Context, obj *domain.Task) (*string, error) {
+	panic(fmt.Errorf("not implemented: ColumnID - columnId"))
+}
+
+// Rank is the resolver for the rank field.
+func (r *taskResolver) Rank(ctx context.Context, obj *domain.Task) (*string, error) {
+	panic(fmt.Errorf("not implemented: Rank - rank"))
+}
+
+// SprintID is the resolver for the sprintId field.
+func (r *taskResolver) SprintID(ctx context.Context, obj *domain.Task) (*string, error) {
+	panic(fmt.Errorf("not implemented: SprintID - sprintId"))
+}
+
+// Priority is the resolver for the priority field.
+func (r *templateTaskResolver) Priority(ctx context.Context, obj *domain.TemplateTask) (model.TaskPriority, error) {
+	panic(fmt.Errorf("not implemented: Priority - priority"))
+}
+
+// Task is the resolver for the task field.
+func (r *timeEntryResolver) Task(ctx context.Context, obj *domain.TimeEntry) (*domain.Task, error) {
+	panic(fmt.Errorf("not implemented: Task - task"))
+}
+
+// User is the resolver for the user field.
+func (r *timeEntryResolver) User(ctx context.Context, obj *domain.TimeEntry) (*domain.User, error) {
+	panic(fmt.Errorf("not implemented: User - user"))
+}
+
+// Running is the resolver for the running field.
+func (r *timeEntryResolver) Running(ctx context.Context, obj *domain.TimeEntry) (bool, error) {
+	panic(fmt.Errorf("not implemented: Running - running"))
+}
+
 // CreatedAt is the resolver for the createdAt field.
 func (r *userResolver) CreatedAt(ctx context.Context, obj *domain.User) (string, error) {
 	panic(fmt.Errorf("not implemented: CreatedAt - createdAt"))
@@ -97,19 +710,70 @@ func (r *userResolver) UpdatedAt(ctx context.Context, obj *domain.User) (string,
 	panic(fmt.Errorf("not implemented: UpdatedAt - updatedAt"))
 }
 
+// DeletedAt is the resolver for the deletedAt field.
+func (r *userResolver) DeletedAt(ctx context.Context, obj *domain.User) (*time.Time, error) {
+	panic(fmt.Errorf("not implemented: DeletedAt - deletedAt"))
+}
+
+// Activity returns generated.ActivityResolver implementation.
+func (r *Resolver) Activity() generated.ActivityResolver { return &activityResolver{r} }
+
+// Attachment returns generated.AttachmentResolver implementation.
+func (r *Resolver) Attachment() generated.AttachmentResolver { return &attachmentResolver{r} }
+
+// Board returns generated.BoardResolver implementation.
+func (r *Resolver) Board() generated.BoardResolver { return &boardResolver{r} }
+
+// BoardLane returns generated.BoardLaneResolver implementation.
+func (r *Resolver) BoardLane() generated.BoardLaneResolver { return &boardLaneResolver{r} }
+
+// ChecklistItem returns generated.ChecklistItemResolver implementation.
+func (r *Resolver) ChecklistItem() generated.ChecklistItemResolver { return &checklistItemResolver{r} }
+
+// Comment returns generated.CommentResolver implementation.
+func (r *Resolver) Comment() generated.CommentResolver { return &commentResolver{r} }
+
+// CustomField returns generated.CustomFieldResolver implementation.
+func (r *Resolver) CustomField() generated.CustomFieldResolver { return &customFieldResolver{r} }
+
+// CustomFieldValue returns generated.CustomFieldValueResolver implementation.
+func (r *Resolver) CustomFieldValue() generated.CustomFieldValueResolver {
+	return &customFieldValueResolver{r}
+}
+
 // Mutation returns generated.MutationResolver implementation.
 func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
 
+// Project returns generated.ProjectResolver implementation.
+func (r *Resolver) Project() generated.ProjectResolver { return &projectResolver{r} }
+
 // Query returns generated.QueryResolver implementation.
 func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
 
 // Task returns generated.TaskResolver implementation.
 func (r *Resolver) Task() generated.TaskResolver { return &taskResolver{r} }
 
+// TemplateTask returns generated.TemplateTaskResolver implementation.
+func (r *Resolver) TemplateTask() generated.TemplateTaskResolver { return &templateTaskResolver{r} }
+
+// TimeEntry returns generated.TimeEntryResolver implementation.
+func (r *Resolver) TimeEntry() generated.TimeEntryResolver { return &timeEntryResolver{r} }
+
 // User returns generated.UserResolver implementation.
 func (r *Resolver) User() generated.UserResolver { return &userResolver{r} }
 
+type activityResolver struct{ *Resolver }
+type attachmentResolver struct{ *Resolver }
+type boardResolver struct{ *Resolver }
+type boardLaneResolver struct{ *Resolver }
+type checklistItemResolver struct{ *Resolver }
+type commentResolver struct{ *Resolver }
+type customFieldResolver struct{ *Resolver }
+type customFieldValueResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
+type projectResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
 type taskResolver struct{ *Resolver }
+type templateTaskResolver struct{ *Resolver }
+type timeEntryResolver struct{ *Resolver }
 type userResolver struct{ *Resolver }
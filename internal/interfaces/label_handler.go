@@ -0,0 +1,217 @@
+package interfaces
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type LabelHandler struct {
+	service *application.LabelService
+}
+
+func NewLabelHandler(service *application.LabelService) *LabelHandler {
+	return &LabelHandler{service: service}
+}
+
+// GetLabels godoc
+// @Summary Get the labels of a workspace
+// @Description Get the labels of a workspace
+// @Tags labels
+// @Produce  json
+// @Param workspaceId query int false "Workspace ID"
+// @Success 200 {array} domain.Label
+// @Router /labels [get]
+func (h *LabelHandler) GetLabels(c *gin.Context) {
+	workspaceID := 0
+	if v := c.Query("workspaceId"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+			return
+		}
+		workspaceID = id
+	}
+	labels, err := h.service.GetLabelsByWorkspaceID(workspaceID, restActor(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, labels)
+}
+
+// CreateLabel godoc
+// @Summary Create a new label
+// @Description Create a new label
+// @Tags labels
+// @Accept  json
+// @Produce  json
+// @Param label body domain.Label true "Label"
+// @Success 201 {object} domain.Label
+// @Router /labels [post]
+func (h *LabelHandler) CreateLabel(c *gin.Context) {
+	var label domain.Label
+	if err := c.ShouldBindJSON(&label); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.service.CreateLabel(&label, restActor(c)); err != nil {
+		c.JSON(labelErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, label)
+}
+
+// GetLabelByID godoc
+// @Summary Get a label by ID
+// @Description Get a label by ID
+// @Tags labels
+// @Produce  json
+// @Param id path int true "Label ID"
+// @Success 200 {object} domain.Label
+// @Router /labels/{id} [get]
+func (h *LabelHandler) GetLabelByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid label ID"})
+		return
+	}
+	label, err := h.service.GetLabelByID(id, restActor(c))
+	if errors.Is(err, domain.ErrForbidden) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Label not found"})
+		return
+	}
+	c.JSON(http.StatusOK, label)
+}
+
+// UpdateLabel godoc
+// @Summary Update a label by ID
+// @Description Rename or recolor a label
+// @Tags labels
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Label ID"
+// @Param label body domain.Label true "Label"
+// @Success 200 {object} domain.Label
+// @Router /labels/{id} [put]
+func (h *LabelHandler) UpdateLabel(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid label ID"})
+		return
+	}
+	var label domain.Label
+	if err := c.ShouldBindJSON(&label); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	label.ID = id
+	if err := h.service.UpdateLabel(&label, restActor(c)); err != nil {
+		c.JSON(labelErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, label)
+}
+
+// DeleteLabel godoc
+// @Summary Delete a label by ID
+// @Description Delete a label and detach it from every task
+// @Tags labels
+// @Param id path int true "Label ID"
+// @Success 204
+// @Router /labels/{id} [delete]
+func (h *LabelHandler) DeleteLabel(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid label ID"})
+		return
+	}
+	if err := h.service.DeleteLabel(id, restActor(c)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// AddLabelToTask godoc
+// @Summary Add a label to a task
+// @Description Add a label to a task
+// @Tags labels
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Param labelId path int true "Label ID"
+// @Success 200 {object} domain.Task
+// @Router /tasks/{id}/labels/{labelId} [post]
+func (h *LabelHandler) AddLabelToTask(c *gin.Context) {
+	taskID, labelID, ok := taskLabelParams(c)
+	if !ok {
+		return
+	}
+	task, err := h.service.AddLabelToTask(taskID, labelID, restActor(c))
+	if err != nil {
+		c.JSON(labelErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
+
+// RemoveLabelFromTask godoc
+// @Summary Remove a label from a task
+// @Description Remove a label from a task
+// @Tags labels
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Param labelId path int true "Label ID"
+// @Success 200 {object} domain.Task
+// @Router /tasks/{id}/labels/{labelId} [delete]
+func (h *LabelHandler) RemoveLabelFromTask(c *gin.Context) {
+	taskID, labelID, ok := taskLabelParams(c)
+	if !ok {
+		return
+	}
+	task, err := h.service.RemoveLabelFromTask(taskID, labelID, restActor(c))
+	if err != nil {
+		c.JSON(labelErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
+
+func taskLabelParams(c *gin.Context) (int, int, bool) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return 0, 0, false
+	}
+	labelID, err := strconv.Atoi(c.Param("labelId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid label ID"})
+		return 0, 0, false
+	}
+	return taskID, labelID, true
+}
+
+// labelErrorStatus maps label service errors to HTTP status codes.
+func labelErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidLabel), errors.Is(err, domain.ErrLabelWorkspace):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrDuplicateLabel):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,227 @@
+package interfaces
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ProjectHandler struct {
+	service *application.ProjectService
+}
+
+func NewProjectHandler(service *application.ProjectService) *ProjectHandler {
+	return &ProjectHandler{service: service}
+}
+
+// GetProjects godoc
+// @Summary Get all projects
+// @Description Get all projects, hiding archived ones unless includeArchived is set
+// @Tags projects
+// @Produce  json
+// @Param workspaceId query int false "Workspace ID"
+// @Param includeArchived query bool false "Include archived projects"
+// @Success 200 {array} domain.Project
+// @Router /projects [get]
+func (h *ProjectHandler) GetProjects(c *gin.Context) {
+	var filter domain.ProjectFilter
+	if v := c.Query("workspaceId"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+			return
+		}
+		filter.WorkspaceID = id
+	}
+	if v := c.Query("includeArchived"); v != "" {
+		includeArchived, err := strconv.ParseBool(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid includeArchived flag"})
+			return
+		}
+		filter.IncludeArchived = includeArchived
+	}
+	projects, err := h.service.GetProjects(filter, restActor(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, projects)
+}
+
+// CreateProject godoc
+// @Summary Create a new project
+// @Description Create a new project owned by the authenticated user
+// @Tags projects
+// @Accept  json
+// @Produce  json
+// @Param project body domain.Project true "Project"
+// @Success 201 {object} domain.Project
+// @Router /projects [post]
+func (h *ProjectHandler) CreateProject(c *gin.Context) {
+	var project domain.Project
+	if err := c.ShouldBindJSON(&project); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.service.CreateProject(&project, restActor(c)); err != nil {
+		c.JSON(projectErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, project)
+}
+
+// GetProjectByID godoc
+// @Summary Get a project by ID
+// @Description Get a project by ID
+// @Tags projects
+// @Produce  json
+// @Param id path int true "Project ID"
+// @Success 200 {object} domain.Project
+// @Router /projects/{id} [get]
+func (h *ProjectHandler) GetProjectByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	project, err := h.service.GetProjectByID(id, restActor(c))
+	if errors.Is(err, domain.ErrForbidden) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return
+	}
+	c.JSON(http.StatusOK, project)
+}
+
+// UpdateProject godoc
+// @Summary Update a project by ID
+// @Description Update a project by ID, including archiving it
+// @Tags projects
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Project ID"
+// @Param project body domain.Project true "Project"
+// @Success 200 {object} domain.Project
+// @Router /projects/{id} [put]
+func (h *ProjectHandler) UpdateProject(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	var project domain.Project
+	if err := c.ShouldBindJSON(&project); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	project.ID = id
+	if err := h.service.UpdateProject(&project, restActor(c)); err != nil {
+		c.JSON(projectErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, project)
+}
+
+// DeleteProject godoc
+// @Summary Delete a project by ID
+// @Description Delete a project; its tasks are kept without a project
+// @Tags projects
+// @Param id path int true "Project ID"
+// @Success 204
+// @Router /projects/{id} [delete]
+func (h *ProjectHandler) DeleteProject(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	if err := h.service.DeleteProject(id, restActor(c)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetProjectTasks godoc
+// @Summary Get the tasks of a project
+// @Description Get the tasks of a project, accepting the same filters as GET /tasks
+// @Tags projects
+// @Produce  json
+// @Param id path int true "Project ID"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Success 200 {object} domain.TaskConnection
+// @Router /projects/{id}/tasks [get]
+func (h *ProjectHandler) GetProjectTasks(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	filter, ok := taskFilterFromQuery(c)
+	if !ok {
+		return
+	}
+	tasks, err := h.service.GetProjectTasks(id, filter, restActor(c))
+	if err != nil {
+		c.JSON(projectErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, tasks)
+}
+
+// MoveTasks godoc
+// @Summary Move tasks into a project
+// @Description Move tasks into a project; either every task is moved or none is
+// @Tags projects
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Project ID"
+// @Param tasks body object true "Task IDs"
+// @Success 200 {array} domain.Task
+// @Router /projects/{id}/tasks [post]
+func (h *ProjectHandler) MoveTasks(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	var req struct {
+		TaskIDs []int `json:"taskIds" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	tasks, err := h.service.MoveTasks(req.TaskIDs, id, restActor(c))
+	if err != nil {
+		c.JSON(projectErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, tasks)
+}
+
+// projectErrorStatus maps project service errors to HTTP status codes.
+func projectErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidProject):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrProjectArchived), errors.Is(err, domain.ErrProjectScope):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
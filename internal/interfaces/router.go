@@ -1,8 +1,13 @@
 package interfaces
 
 import (
+	"log"
+	"os"
+	"path/filepath"
 	"task-manager-app/backend/internal/application"
 	"task-manager-app/backend/internal/infrastructure"
+	"task-manager-app/backend/internal/middleware"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -12,9 +17,51 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 	router := gin.Default()
 
 	// Initialize handlers
-	taskHandler := NewTaskHandler(application.NewTaskService(infrastructure.NewTaskRepository(db)))
-	userHandler := NewUserHandler(application.NewUserService(infrastructure.NewUserRepository(db)))
-	authHandler := NewAuthHandler(application.NewUserService(infrastructure.NewUserRepository(db)), []byte("your_jwt_secret"))
+	taskRepo := infrastructure.NewTaskRepository(db)
+	workspaceRepo := infrastructure.NewWorkspaceRepository(db)
+	accessPolicy := application.NewAccessPolicy(infrastructure.NewUserRepository(db), workspaceRepo)
+	taskService := application.NewTaskService(taskRepo, workspaceRepo, infrastructure.NewActivityRepository(db), accessPolicy)
+	taskHandler := NewTaskHandler(taskService)
+	workspaceHandler := NewWorkspaceHandler(application.NewWorkspaceService(workspaceRepo, accessPolicy))
+	labelRepo := infrastructure.NewLabelRepository(db)
+	labelHandler := NewLabelHandler(application.NewLabelService(labelRepo, taskRepo, accessPolicy))
+	templateHandler := NewTemplateHandler(application.NewTemplateService(infrastructure.NewTemplateRepository(db), labelRepo, accessPolicy), taskService)
+	projectRepo := infrastructure.NewProjectRepository(db)
+	projectHandler := NewProjectHandler(application.NewProjectService(projectRepo, taskRepo, accessPolicy))
+	boardHandler := NewBoardHandler(application.NewBoardService(infrastructure.NewBoardRepository(db), projectRepo, taskRepo, taskService, accessPolicy))
+	sprintHandler := NewSprintHandler(application.NewSprintService(infrastructure.NewSprintRepository(db), projectRepo, taskService, accessPolicy))
+	dependencyHandler := NewDependencyHandler(application.NewDependencyService(infrastructure.NewDependencyRepository(db), taskRepo, projectRepo, accessPolicy))
+	commentHandler := NewCommentHandler(application.NewCommentService(infrastructure.NewCommentRepository(db), taskRepo, infrastructure.NewUserRepository(db), accessPolicy))
+	blobs, err := infrastructure.NewLocalBlobStore(filepath.Join(os.TempDir(), "task-manager-attachments"))
+	if err != nil {
+		log.Fatalf("Failed to initialize attachment storage: %v", err)
+	}
+	attachmentHandler := NewAttachmentHandler(application.NewAttachmentService(infrastructure.NewAttachmentRepository(db), taskRepo, blobs,
+		application.AttachmentLimits{MaxSize: 10 << 20}, accessPolicy))
+	trashHandler := NewTrashHandler(application.NewTrashService(taskRepo, infrastructure.NewUserRepository(db), blobs, 0, accessPolicy))
+	timeHandler := NewTimeHandler(application.NewTimeService(infrastructure.NewTimeEntryRepository(db), taskRepo, projectRepo, accessPolicy))
+	checklistHandler := NewChecklistHandler(application.NewChecklistService(infrastructure.NewChecklistRepository(db), taskRepo, accessPolicy))
+	customFieldHandler := NewCustomFieldHandler(application.NewCustomFieldService(infrastructure.NewCustomFieldRepository(db), taskRepo, infrastructure.NewUserRepository(db), accessPolicy))
+	keys, err := application.NewKeySet([]application.SigningKey{application.NewHMACKey("hs256", []byte(os.Getenv("JWT_SECRET")))}, 15*time.Minute)
+	if err != nil {
+		log.Fatalf("Failed to load signing keys: %v", err)
+	}
+	tokenService := application.NewTokenService(application.TokenConfig{
+		Keys:     keys,
+		Issuer:   "task-manager",
+		Audience: "task-manager-api",
+		Expiry:   15 * time.Minute,
+	}, application.NewRevocationService(infrastructure.NewRevocationRepository(db), time.Minute))
+	userService := application.NewUserService(infrastructure.NewUserRepository(db), infrastructure.NewRefreshTokenRepository(db), tokenService, 7*24*time.Hour, accessPolicy)
+	userHandler := NewUserHandler(userService)
+	authHandler := NewAuthHandler(userService, tokenService)
+
+	// Public routes
+	router.POST("/users", userHandler.Register)
+	router.POST("/login", authHandler.Login)
+
+	// Every route registered from here on requires a valid access token
+	router.Use(middleware.AuthMiddleware(tokenService))
 
 	// Task routes
 	router.POST("/tasks", taskHandler.CreateTask)
@@ -22,10 +69,125 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 	router.GET("/tasks/:id", taskHandler.GetTaskByID) // Adicionando rota GET /tasks/:id
 	router.PUT("/tasks/:id", taskHandler.UpdateTask)
 	router.DELETE("/tasks/:id", taskHandler.DeleteTask)
+	router.POST("/tasks/:id/archive", taskHandler.ArchiveTask)
+	router.DELETE("/tasks/:id/archive", taskHandler.UnarchiveTask)
+	router.PUT("/tasks/:id/status", taskHandler.ChangeTaskStatus)
+	router.GET("/tasks/:id/subtasks", taskHandler.GetSubtasks)
+	router.GET("/tasks/:id/history", taskHandler.GetTaskHistory)
+	router.GET("/tasks/:id/occurrences", taskHandler.GetOccurrences)
+	router.POST("/tasks/:id/recurrence/skip", taskHandler.SkipOccurrence)
+	router.DELETE("/tasks/:id/recurrence", taskHandler.EndSeries)
+	router.GET("/tasks/:id/comments", commentHandler.GetComments)
+	router.POST("/tasks/:id/comments", commentHandler.CreateComment)
+	router.GET("/tasks/:id/time", timeHandler.GetTaskTime)
+	router.GET("/tasks/:id/time-entries", timeHandler.GetTaskTimeEntries)
+	router.POST("/tasks/:id/time-entries", timeHandler.LogTime)
+	router.POST("/tasks/:id/timer", timeHandler.StartTimer)
+	router.GET("/tasks/:id/checklist", checklistHandler.GetChecklist)
+	router.POST("/tasks/:id/checklist", checklistHandler.AddChecklistItem)
+	router.PUT("/tasks/:id/checklist/order", checklistHandler.ReorderChecklist)
+	router.GET("/tasks/:id/attachments", attachmentHandler.GetAttachments)
+	router.POST("/tasks/:id/attachments", attachmentHandler.UploadAttachment)
+	router.POST("/tasks/:id/assignees/:userId", taskHandler.AssignTask)
+	router.DELETE("/tasks/:id/assignees/:userId", taskHandler.UnassignTask)
+	router.POST("/tasks/:id/watchers/:userId", taskHandler.WatchTask)
+	router.DELETE("/tasks/:id/watchers/:userId", taskHandler.UnwatchTask)
+	router.POST("/tasks/:id/labels/:labelId", labelHandler.AddLabelToTask)
+	router.DELETE("/tasks/:id/labels/:labelId", labelHandler.RemoveLabelFromTask)
+	router.PUT("/tasks/:id/custom-fields/:fieldId", customFieldHandler.SetCustomFieldValue)
+	router.DELETE("/tasks/:id/custom-fields/:fieldId", customFieldHandler.ClearCustomFieldValue)
+	router.POST("/tasks/:id/move", boardHandler.MoveTask)
+	router.GET("/tasks/:id/dependencies", dependencyHandler.GetBlockers)
+	router.POST("/tasks/:id/dependencies/:blockerId", dependencyHandler.AddDependency)
+	router.DELETE("/tasks/:id/dependencies/:blockerId", dependencyHandler.RemoveDependency)
+
+	// Comment routes
+	router.GET("/comments/:id", commentHandler.GetCommentByID)
+	router.PUT("/comments/:id", commentHandler.UpdateComment)
+	router.DELETE("/comments/:id", commentHandler.DeleteComment)
+	router.GET("/comments/:id/history", commentHandler.GetCommentHistory)
+
+	// Checklist routes
+	router.POST("/checklist-items/:id/toggle", checklistHandler.ToggleChecklistItem)
+	router.DELETE("/checklist-items/:id", checklistHandler.DeleteChecklistItem)
+
+	// Attachment routes
+	router.GET("/attachments/:id", attachmentHandler.GetAttachment)
+	router.GET("/attachments/:id/content", attachmentHandler.DownloadAttachment)
+	router.DELETE("/attachments/:id", attachmentHandler.DeleteAttachment)
+
+	// Time tracking routes
+	router.GET("/timer", timeHandler.GetRunningTimer)
+	router.POST("/timer/stop", timeHandler.StopTimer)
+	router.GET("/time-entries", timeHandler.GetTimeEntries)
+	router.GET("/time-entries/report", timeHandler.GetTimeReport)
+	router.GET("/time-entries/:id", timeHandler.GetTimeEntry)
+	router.PUT("/time-entries/:id", timeHandler.UpdateTimeEntry)
+	router.DELETE("/time-entries/:id", timeHandler.DeleteTimeEntry)
+
+	// Trash routes
+	router.GET("/trash/tasks", trashHandler.GetDeletedTasks)
+	router.POST("/trash/tasks/:id/restore", trashHandler.RestoreTask)
+	router.DELETE("/trash/tasks/:id", trashHandler.PurgeTask)
+	router.GET("/trash/users", trashHandler.GetDeletedUsers)
+	router.POST("/trash/users/:id/restore", trashHandler.RestoreUser)
+	router.DELETE("/trash/users/:id", trashHandler.PurgeUser)
+
+	// Project routes
+	router.GET("/projects", projectHandler.GetProjects)
+	router.POST("/projects", projectHandler.CreateProject)
+	router.GET("/projects/:id", projectHandler.GetProjectByID)
+	router.PUT("/projects/:id", projectHandler.UpdateProject)
+	router.DELETE("/projects/:id", projectHandler.DeleteProject)
+	router.GET("/projects/:id/tasks", projectHandler.GetProjectTasks)
+	router.POST("/projects/:id/tasks", projectHandler.MoveTasks)
+	router.GET("/projects/:id/dependencies", dependencyHandler.GetProjectGraph)
+	router.GET("/projects/:id/board", boardHandler.GetBoard)
+	router.GET("/projects/:id/board/columns", boardHandler.GetBoardColumns)
+	router.PUT("/projects/:id/board/columns", boardHandler.SetBoardColumns)
+	router.GET("/projects/:id/sprints", sprintHandler.GetSprints)
+	router.POST("/projects/:id/sprints", sprintHandler.CreateSprint)
+
+	// Sprint routes
+	router.GET("/sprints/:id", sprintHandler.GetSprintByID)
+	router.PUT("/sprints/:id", sprintHandler.UpdateSprint)
+	router.DELETE("/sprints/:id", sprintHandler.DeleteSprint)
+	router.POST("/sprints/:id/tasks", sprintHandler.AddSprintTasks)
+	router.DELETE("/sprints/:id/tasks/:taskId", sprintHandler.RemoveSprintTask)
+	router.GET("/sprints/:id/burndown", sprintHandler.GetBurndown)
+
+	// Label routes
+	router.GET("/labels", labelHandler.GetLabels)
+	router.POST("/labels", labelHandler.CreateLabel)
+	router.GET("/labels/:id", labelHandler.GetLabelByID)
+	router.PUT("/labels/:id", labelHandler.UpdateLabel)
+	router.DELETE("/labels/:id", labelHandler.DeleteLabel)
+
+	// Template routes
+	router.GET("/templates", templateHandler.GetTemplates)
+	router.POST("/templates", templateHandler.CreateTemplate)
+	router.GET("/templates/:id", templateHandler.GetTemplateByID)
+	router.PUT("/templates/:id", templateHandler.UpdateTemplate)
+	router.DELETE("/templates/:id", templateHandler.DeleteTemplate)
+	router.POST("/templates/:id/instantiate", templateHandler.InstantiateTemplate)
+
+	// Workspace routes
+	router.POST("/workspaces", workspaceHandler.CreateWorkspace)
+	router.GET("/workspaces/:id", workspaceHandler.GetWorkspaceByID)
+	router.GET("/workspaces/:id/workflow", workspaceHandler.GetWorkflow)
+	router.PUT("/workspaces/:id/workflow", workspaceHandler.UpdateWorkflow)
+	router.GET("/workspaces/:id/members", workspaceHandler.GetMembers)
+	router.PUT("/workspaces/:id/members/:userId", workspaceHandler.SetMember)
+	router.DELETE("/workspaces/:id/members/:userId", workspaceHandler.RemoveMember)
+
+	// Custom field routes
+	router.GET("/workspaces/:id/custom-fields", customFieldHandler.GetCustomFields)
+	router.POST("/workspaces/:id/custom-fields", customFieldHandler.CreateCustomField)
+	router.GET("/custom-fields/:id", customFieldHandler.GetCustomFieldByID)
+	router.PUT("/custom-fields/:id", customFieldHandler.UpdateCustomField)
+	router.DELETE("/custom-fields/:id", customFieldHandler.DeleteCustomField)
 
 	// User routes
-	router.POST("/users", userHandler.Register)
-	router.POST("/login", authHandler.Login)
 	router.GET("/users", userHandler.GetUsers)
 	router.GET("/users/:id", userHandler.GetUserByID)
 	router.PUT("/users/:id", userHandler.UpdateUser)
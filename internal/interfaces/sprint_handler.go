@@ -0,0 +1,244 @@
+package interfaces
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type SprintHandler struct {
+	service *application.SprintService
+}
+
+func NewSprintHandler(service *application.SprintService) *SprintHandler {
+	return &SprintHandler{service: service}
+}
+
+// GetSprints godoc
+// @Summary Get the sprints of a project
+// @Description Get the sprints and milestones of a project ordered by start date
+// @Tags sprints
+// @Produce  json
+// @Param id path int true "Project ID"
+// @Success 200 {array} domain.Sprint
+// @Router /projects/{id}/sprints [get]
+func (h *SprintHandler) GetSprints(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	sprints, err := h.service.GetSprints(projectID, restActor(c))
+	if err != nil {
+		c.JSON(sprintErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, sprints)
+}
+
+// CreateSprint godoc
+// @Summary Create a sprint
+// @Description Create a sprint or milestone in a project; start and end dates are both included
+// @Tags sprints
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Project ID"
+// @Param sprint body domain.Sprint true "Sprint"
+// @Success 201 {object} domain.Sprint
+// @Router /projects/{id}/sprints [post]
+func (h *SprintHandler) CreateSprint(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	var sprint domain.Sprint
+	if err := c.ShouldBindJSON(&sprint); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	sprint.ID = 0
+	sprint.ProjectID = projectID
+	if err := h.service.CreateSprint(&sprint, restActor(c)); err != nil {
+		c.JSON(sprintErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, sprint)
+}
+
+// GetSprintByID godoc
+// @Summary Get a sprint by ID
+// @Description Get a sprint by ID; list its tasks with GET /tasks?sprintId=
+// @Tags sprints
+// @Produce  json
+// @Param id path int true "Sprint ID"
+// @Success 200 {object} domain.Sprint
+// @Router /sprints/{id} [get]
+func (h *SprintHandler) GetSprintByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sprint ID"})
+		return
+	}
+	sprint, err := h.service.GetSprint(id, restActor(c))
+	if errors.Is(err, domain.ErrForbidden) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Sprint not found"})
+		return
+	}
+	c.JSON(http.StatusOK, sprint)
+}
+
+// UpdateSprint godoc
+// @Summary Update a sprint
+// @Description Change the name, goal, dates and timezone of a sprint
+// @Tags sprints
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Sprint ID"
+// @Param sprint body domain.Sprint true "Sprint"
+// @Success 200 {object} domain.Sprint
+// @Router /sprints/{id} [put]
+func (h *SprintHandler) UpdateSprint(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sprint ID"})
+		return
+	}
+	var sprint domain.Sprint
+	if err := c.ShouldBindJSON(&sprint); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	sprint.ID = id
+	if err := h.service.UpdateSprint(&sprint, restActor(c)); err != nil {
+		c.JSON(sprintErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, sprint)
+}
+
+// DeleteSprint godoc
+// @Summary Delete a sprint
+// @Description Delete a sprint; its tasks stay in the project without a sprint
+// @Tags sprints
+// @Param id path int true "Sprint ID"
+// @Success 204
+// @Router /sprints/{id} [delete]
+func (h *SprintHandler) DeleteSprint(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sprint ID"})
+		return
+	}
+	if err := h.service.DeleteSprint(id, restActor(c)); err != nil {
+		c.JSON(sprintErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// AddSprintTasks godoc
+// @Summary Add tasks to a sprint
+// @Description Plan tasks of the sprint's project into the sprint, taking them out of any other sprint; either every task is added or none is
+// @Tags sprints
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Sprint ID"
+// @Param tasks body object true "Task IDs"
+// @Success 200 {array} domain.Task
+// @Router /sprints/{id}/tasks [post]
+func (h *SprintHandler) AddSprintTasks(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sprint ID"})
+		return
+	}
+	var req struct {
+		TaskIDs []int `json:"taskIds" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	tasks, err := h.service.AddTasks(id, req.TaskIDs, restActor(c))
+	if err != nil {
+		c.JSON(sprintErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, tasks)
+}
+
+// RemoveSprintTask godoc
+// @Summary Remove a task from a sprint
+// @Description Take a task out of the sprint; it stays in the project
+// @Tags sprints
+// @Produce  json
+// @Param id path int true "Sprint ID"
+// @Param taskId path int true "Task ID"
+// @Success 200 {object} domain.Task
+// @Router /sprints/{id}/tasks/{taskId} [delete]
+func (h *SprintHandler) RemoveSprintTask(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sprint ID"})
+		return
+	}
+	taskID, err := strconv.Atoi(c.Param("taskId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	task, err := h.service.RemoveTask(id, taskID, restActor(c))
+	if err != nil {
+		c.JSON(sprintErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
+
+// GetBurndown godoc
+// @Summary Get the burndown of a sprint
+// @Description Get one point per day of the sprint so far with the scope, remaining and completed task counts and estimates, computed from the task history
+// @Tags sprints
+// @Produce  json
+// @Param id path int true "Sprint ID"
+// @Success 200 {object} domain.Burndown
+// @Router /sprints/{id}/burndown [get]
+func (h *SprintHandler) GetBurndown(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sprint ID"})
+		return
+	}
+	burndown, err := h.service.GetBurndown(id, restActor(c))
+	if err != nil {
+		c.JSON(sprintErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, burndown)
+}
+
+// sprintErrorStatus maps sprint service errors to HTTP status codes.
+func sprintErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidSprint), errors.Is(err, domain.ErrSprintTimezone), errors.Is(err, domain.ErrSprintProject):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrProjectArchived):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, domain.ErrNotInSprint):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -1,12 +1,20 @@
 package interfaces
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
 	"task-manager-app/backend/internal/application"
 	"task-manager-app/backend/internal/domain"
+	"task-manager-app/backend/internal/middleware"
+	"task-manager-app/backend/pkg/utils"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type TaskHandler struct {
@@ -23,17 +31,36 @@ func NewTaskHandler(service *application.TaskService) *TaskHandler {
 // @Tags tasks
 // @Accept  json
 // @Produce  json
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Param projectId query int false "Project ID"
+// @Param sprintId query int false "Sprint ID"
+// @Param search query string false "Search in title"
+// @Param dueBefore query string false "Due before (RFC3339)"
+// @Param dueAfter query string false "Due after (RFC3339)"
+// @Param overdue query bool false "Only overdue (true) or not overdue (false) tasks"
+// @Param includeArchived query bool false "Also return archived tasks"
+// @Param labelsAny query string false "Comma separated label IDs, matches tasks with any of them"
+// @Param labelsAll query string false "Comma separated label IDs, matches tasks with all of them"
+// @Param creatorId query int false "Only tasks created by this user"
+// @Param assigneeId query int false "Only tasks assigned to this user"
+// @Param watcherId query int false "Only tasks watched by this user"
+// @Param createdByMe query bool false "Only tasks created by the current user"
+// @Param assignedToMe query bool false "Only tasks assigned to the current user"
+// @Param cf query object false "Custom field equality filters as cf[<fieldId>]=value"
+// @Param cfMin query object false "Custom field lower bounds as cfMin[<fieldId>]=value"
+// @Param cfMax query object false "Custom field upper bounds as cfMax[<fieldId>]=value"
+// @Param sort query string false "Comma separated sort keys (priority, dueAt, createdAt, title, rank, cf:<fieldId>), prefix with - for descending"
 // @Success 200 {array} domain.Task
 // @Router /tasks [get]
 func (h *TaskHandler) GetTasks(c *gin.Context) {
-	filter := domain.TaskFilter{
-		Search: c.Query("search"),
-		Page:   1,
-		Limit:  10,
+	filter, ok := taskFilterFromQuery(c)
+	if !ok {
+		return
 	}
-	tasks, err := h.service.GetAllTasks(filter)
+	tasks, err := h.service.GetAllTasks(filter, restActor(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, tasks)
@@ -54,8 +81,8 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	if err := h.service.CreateTask(&task); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.service.CreateTask(&task, restActor(c)); err != nil {
+		c.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusCreated, task)
@@ -76,7 +103,11 @@ func (h *TaskHandler) GetTaskByID(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
 		return
 	}
-	task, err := h.service.GetTaskByID(id)
+	task, err := h.service.GetTaskByID(id, restActor(c))
+	if errors.Is(err, domain.ErrForbidden) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
 		return
@@ -106,8 +137,41 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 		return
 	}
 	task.ID = id
-	if err := h.service.UpdateTask(&task); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.service.UpdateTask(&task, restActor(c)); err != nil {
+		c.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
+
+// ChangeTaskStatus godoc
+// @Summary Move a task to another status
+// @Description Move a task to another status following its workspace workflow; with cascade, completing a task also completes its subtasks
+// @Tags tasks
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Param status body object true "New status"
+// @Success 200 {object} domain.Task
+// @Failure 409 {object} gin.H
+// @Router /tasks/{id}/status [put]
+func (h *TaskHandler) ChangeTaskStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	var req struct {
+		Status  domain.TaskStatus `json:"status" binding:"required"`
+		Cascade bool              `json:"cascade"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": utils.TranslateError(err)})
+		return
+	}
+	task, err := h.service.ChangeStatus(id, req.Status, restActor(c), req.Cascade)
+	if err != nil {
+		c.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, task)
@@ -120,6 +184,7 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 // @Accept  json
 // @Produce  json
 // @Param id path int true "Task ID"
+// @Param subtasks query string false "What to do with subtasks: reparent (default) or cascade"
 // @Success 200 {object} gin.H
 // @Router /tasks/{id} [delete]
 func (h *TaskHandler) DeleteTask(c *gin.Context) {
@@ -128,9 +193,471 @@ func (h *TaskHandler) DeleteTask(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
 		return
 	}
-	if err := h.service.DeleteTask(id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	policy := domain.DeletePolicy(c.DefaultQuery("subtasks", string(domain.DeleteReparent)))
+	if policy != domain.DeleteReparent && policy != domain.DeleteCascade {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid subtasks policy, expected reparent or cascade"})
+		return
+	}
+	if err := h.service.DeleteTask(id, policy, restActor(c)); err != nil {
+		c.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
+
+// ArchiveTask godoc
+// @Summary Archive a task
+// @Description Hide a task from the default task listings without deleting it
+// @Tags tasks
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Success 200 {object} domain.Task
+// @Failure 409 {object} gin.H
+// @Router /tasks/{id}/archive [post]
+func (h *TaskHandler) ArchiveTask(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	task, err := h.service.ArchiveTask(id, restActor(c))
+	if err != nil {
+		c.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
+
+// UnarchiveTask godoc
+// @Summary Unarchive a task
+// @Description Bring an archived task back to the default task listings
+// @Tags tasks
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Success 200 {object} domain.Task
+// @Failure 409 {object} gin.H
+// @Router /tasks/{id}/archive [delete]
+func (h *TaskHandler) UnarchiveTask(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	task, err := h.service.UnarchiveTask(id, restActor(c))
+	if err != nil {
+		c.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
+
+// GetSubtasks godoc
+// @Summary Get the subtasks of a task
+// @Description Get the direct subtasks of a task and its progress rolled up from all descendants
+// @Tags tasks
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Success 200 {object} gin.H
+// @Router /tasks/{id}/subtasks [get]
+func (h *TaskHandler) GetSubtasks(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	progress, err := h.service.GetProgress(id, restActor(c))
+	if err != nil {
+		c.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	subtasks, err := h.service.GetSubtasks(id, restActor(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"subtasks": subtasks, "progress": progress})
+}
+
+// GetOccurrences godoc
+// @Summary Preview the next occurrences of a recurring task
+// @Description Preview the next due dates of a recurring task in its timezone
+// @Tags tasks
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Param count query int false "Number of occurrences (default 5, max 100)"
+// @Success 200 {array} string
+// @Router /tasks/{id}/occurrences [get]
+func (h *TaskHandler) GetOccurrences(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	count, err := strconv.Atoi(c.DefaultQuery("count", "5"))
+	if err != nil || count < 1 || count > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid count, expected 1 to 100"})
+		return
+	}
+	occurrences, err := h.service.PreviewOccurrences(id, count, restActor(c))
+	if err != nil {
+		c.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, occurrences)
+}
+
+// SkipOccurrence godoc
+// @Summary Skip the current occurrence of a recurring task
+// @Description Move a recurring task to its next occurrence without completing it
+// @Tags tasks
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Success 200 {object} domain.Task
+// @Failure 409 {object} gin.H
+// @Router /tasks/{id}/recurrence/skip [post]
+func (h *TaskHandler) SkipOccurrence(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	task, err := h.service.SkipOccurrence(id, restActor(c))
+	if err != nil {
+		c.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
+
+// EndSeries godoc
+// @Summary End a recurring series
+// @Description Stop a recurring task from repeating, keeping the current occurrence
+// @Tags tasks
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Success 200 {object} domain.Task
+// @Router /tasks/{id}/recurrence [delete]
+func (h *TaskHandler) EndSeries(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	task, err := h.service.EndSeries(id, restActor(c))
+	if err != nil {
+		c.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
+
+// GetTaskHistory godoc
+// @Summary Get the change history of a task
+// @Description Get the activity log of a task, newest first, with field-level diffs, actor and source
+// @Tags tasks
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Success 200 {object} domain.ActivityConnection
+// @Router /tasks/{id}/history [get]
+func (h *TaskHandler) GetTaskHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+	history, err := h.service.GetHistory(domain.ActivityFilter{TaskID: id, Page: page, Limit: limit}, restActor(c))
+	if err != nil {
+		c.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, history)
+}
+
+// AssignTask godoc
+// @Summary Assign a user to a task
+// @Description Add a user to the assignees of a task
+// @Tags tasks
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Param userId path int true "User ID"
+// @Success 200 {object} domain.Task
+// @Router /tasks/{id}/assignees/{userId} [post]
+func (h *TaskHandler) AssignTask(c *gin.Context) {
+	h.changeMembers(c, h.service.AssignTask)
+}
+
+// UnassignTask godoc
+// @Summary Unassign a user from a task
+// @Description Remove a user from the assignees of a task
+// @Tags tasks
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Param userId path int true "User ID"
+// @Success 200 {object} domain.Task
+// @Router /tasks/{id}/assignees/{userId} [delete]
+func (h *TaskHandler) UnassignTask(c *gin.Context) {
+	h.changeMembers(c, h.service.UnassignTask)
+}
+
+// WatchTask godoc
+// @Summary Watch a task
+// @Description Add a user to the watchers of a task
+// @Tags tasks
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Param userId path int true "User ID"
+// @Success 200 {object} domain.Task
+// @Router /tasks/{id}/watchers/{userId} [post]
+func (h *TaskHandler) WatchTask(c *gin.Context) {
+	h.changeMembers(c, h.service.WatchTask)
+}
+
+// UnwatchTask godoc
+// @Summary Stop watching a task
+// @Description Remove a user from the watchers of a task
+// @Tags tasks
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Param userId path int true "User ID"
+// @Success 200 {object} domain.Task
+// @Router /tasks/{id}/watchers/{userId} [delete]
+func (h *TaskHandler) UnwatchTask(c *gin.Context) {
+	h.changeMembers(c, h.service.UnwatchTask)
+}
+
+func (h *TaskHandler) changeMembers(c *gin.Context, change func(taskID, userID int, actor domain.Actor) (*domain.Task, error)) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	userID, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	task, err := change(taskID, userID, restActor(c))
+	if err != nil {
+		c.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
+
+// restActor is the authenticated user making a change through the REST API.
+func restActor(c *gin.Context) domain.Actor {
+	userID, _ := middleware.UserIDFromContext(c.Request.Context())
+	return domain.Actor{UserID: userID, Source: domain.SourceREST}
+}
+
+// taskFilterFromQuery builds a task filter from the query string, writing a 400 response
+// and returning false when a parameter is malformed.
+func taskFilterFromQuery(c *gin.Context) (domain.TaskFilter, bool) {
+	filter := domain.TaskFilter{
+		Search: c.Query("search"),
+		Page:   1,
+		Limit:  10,
+	}
+	if v := c.Query("page"); v != "" {
+		page, err := strconv.Atoi(v)
+		if err != nil || page < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page"})
+			return filter, false
+		}
+		filter.Page = page
+	}
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return filter, false
+		}
+		filter.Limit = limit
+	}
+	if v := c.Query("projectId"); v != "" {
+		projectID, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+			return filter, false
+		}
+		filter.ProjectID = projectID
+	}
+	if v := c.Query("sprintId"); v != "" {
+		sprintID, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sprint ID"})
+			return filter, false
+		}
+		filter.SprintID = sprintID
+	}
+	if v := c.Query("dueBefore"); v != "" {
+		dueBefore, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dueBefore, expected RFC3339"})
+			return filter, false
+		}
+		filter.DueBefore = &dueBefore
+	}
+	if v := c.Query("dueAfter"); v != "" {
+		dueAfter, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dueAfter, expected RFC3339"})
+			return filter, false
+		}
+		filter.DueAfter = &dueAfter
+	}
+	if v := c.Query("overdue"); v != "" {
+		overdue, err := strconv.ParseBool(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid overdue flag"})
+			return filter, false
+		}
+		filter.Overdue = &overdue
+	}
+	if v := c.Query("includeArchived"); v != "" {
+		includeArchived, err := strconv.ParseBool(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid includeArchived flag"})
+			return filter, false
+		}
+		filter.IncludeArchived = includeArchived
+	}
+	if v := c.Query("labelsAny"); v != "" {
+		ids, err := parseIDList(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid labelsAny"})
+			return filter, false
+		}
+		filter.LabelsAny = ids
+	}
+	if v := c.Query("labelsAll"); v != "" {
+		ids, err := parseIDList(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid labelsAll"})
+			return filter, false
+		}
+		filter.LabelsAll = ids
+	}
+	for param, target := range map[string]*int{"creatorId": &filter.CreatorID, "assigneeId": &filter.AssigneeID, "watcherId": &filter.WatcherID} {
+		if v := c.Query(param); v != "" {
+			id, err := strconv.Atoi(v)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param})
+				return filter, false
+			}
+			*target = id
+		}
+	}
+	for param, target := range map[string]*int{"createdByMe": &filter.CreatorID, "assignedToMe": &filter.AssigneeID} {
+		v := c.Query(param)
+		if v == "" {
+			continue
+		}
+		me, err := strconv.ParseBool(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param + " flag"})
+			return filter, false
+		}
+		if !me {
+			continue
+		}
+		userID, ok := middleware.UserIDFromContext(c.Request.Context())
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required for " + param})
+			return filter, false
+		}
+		*target = userID
+	}
+	customFields, err := customFieldFiltersFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return filter, false
+	}
+	filter.CustomFields = customFields
+	if v := c.Query("sort"); v != "" {
+		sort, err := domain.ParseTaskSort(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return filter, false
+		}
+		filter.Sort = sort
+	}
+	return filter, true
+}
+
+// customFieldFiltersFromQuery collects the cf[<id>], cfMin[<id>] and cfMax[<id>] parameters
+// into one filter per field, ordered by field ID.
+func customFieldFiltersFromQuery(c *gin.Context) ([]domain.CustomFieldFilter, error) {
+	byField := map[int]*domain.CustomFieldFilter{}
+	var ids []int
+	for param, set := range map[string]func(*domain.CustomFieldFilter, string){
+		"cf":    func(f *domain.CustomFieldFilter, v string) { f.Equals = v },
+		"cfMin": func(f *domain.CustomFieldFilter, v string) { f.Min = v },
+		"cfMax": func(f *domain.CustomFieldFilter, v string) { f.Max = v },
+	} {
+		for key, v := range c.QueryMap(param) {
+			fieldID, err := strconv.Atoi(key)
+			if err != nil || fieldID <= 0 {
+				return nil, fmt.Errorf("%w: invalid field ID %q", domain.ErrInvalidFieldFilter, key)
+			}
+			if byField[fieldID] == nil {
+				byField[fieldID] = &domain.CustomFieldFilter{FieldID: fieldID}
+				ids = append(ids, fieldID)
+			}
+			set(byField[fieldID], v)
+		}
+	}
+	sort.Ints(ids)
+	filters := make([]domain.CustomFieldFilter, len(ids))
+	for i, id := range ids {
+		filters[i] = *byField[id]
+	}
+	return filters, nil
+}
+
+// taskErrorStatus maps task service errors to HTTP status codes.
+func taskErrorStatus(err error) int {
+	var transitionErr *domain.InvalidTransitionError
+	var blockedErr *domain.BlockedError
+	switch {
+	case errors.Is(err, domain.ErrInvalidSchedule), errors.Is(err, domain.ErrInvalidTimezone), errors.Is(err, domain.ErrUnknownStatus),
+		errors.Is(err, domain.ErrUnknownPriority), errors.Is(err, domain.ErrTaskCycle), errors.Is(err, domain.ErrNegativeEstimate),
+		errors.Is(err, domain.ErrParentScope), errors.Is(err, domain.ErrInvalidFieldFilter), errors.Is(err, domain.ErrInvalidSort),
+		errors.Is(err, domain.ErrNotMember):
+		return http.StatusBadRequest
+	case errors.As(err, &transitionErr), errors.As(err, &blockedErr), errors.Is(err, domain.ErrAlreadyArchived), errors.Is(err, domain.ErrNotArchived),
+		errors.Is(err, domain.ErrProjectScope):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// parseIDList parses a comma separated list of IDs such as "1,2,3".
+func parseIDList(v string) ([]int, error) {
+	var ids []int
+	for _, part := range strings.Split(v, ",") {
+		id, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
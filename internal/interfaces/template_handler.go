@@ -0,0 +1,198 @@
+package interfaces
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type TemplateHandler struct {
+	service *application.TemplateService
+	tasks   *application.TaskService
+}
+
+func NewTemplateHandler(service *application.TemplateService, tasks *application.TaskService) *TemplateHandler {
+	return &TemplateHandler{service: service, tasks: tasks}
+}
+
+// GetTemplates godoc
+// @Summary Get the task templates of a workspace
+// @Description Get the task templates of a workspace ordered by name
+// @Tags templates
+// @Produce  json
+// @Param workspaceId query int false "Workspace ID"
+// @Success 200 {array} domain.TaskTemplate
+// @Router /templates [get]
+func (h *TemplateHandler) GetTemplates(c *gin.Context) {
+	workspaceID := 0
+	if v := c.Query("workspaceId"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+			return
+		}
+		workspaceID = id
+	}
+	templates, err := h.service.GetTemplates(workspaceID, restActor(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, templates)
+}
+
+// CreateTemplate godoc
+// @Summary Create a task template
+// @Description Create a template with a task, its subtasks, labels, checklist and relative due dates; texts may contain {{placeholders}}
+// @Tags templates
+// @Accept  json
+// @Produce  json
+// @Param template body domain.TaskTemplate true "Template"
+// @Success 201 {object} domain.TaskTemplate
+// @Router /templates [post]
+func (h *TemplateHandler) CreateTemplate(c *gin.Context) {
+	var template domain.TaskTemplate
+	if err := c.ShouldBindJSON(&template); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	template.ID = 0
+	if err := h.service.CreateTemplate(&template, restActor(c)); err != nil {
+		c.JSON(templateErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, template)
+}
+
+// GetTemplateByID godoc
+// @Summary Get a task template by ID
+// @Description Get a task template by ID
+// @Tags templates
+// @Produce  json
+// @Param id path int true "Template ID"
+// @Success 200 {object} domain.TaskTemplate
+// @Router /templates/{id} [get]
+func (h *TemplateHandler) GetTemplateByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+	template, err := h.service.GetTemplate(id, restActor(c))
+	if errors.Is(err, domain.ErrForbidden) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+		return
+	}
+	c.JSON(http.StatusOK, template)
+}
+
+// UpdateTemplate godoc
+// @Summary Update a task template
+// @Description Replace the name and content of a task template
+// @Tags templates
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Template ID"
+// @Param template body domain.TaskTemplate true "Template"
+// @Success 200 {object} domain.TaskTemplate
+// @Router /templates/{id} [put]
+func (h *TemplateHandler) UpdateTemplate(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+	var template domain.TaskTemplate
+	if err := c.ShouldBindJSON(&template); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	template.ID = id
+	if err := h.service.UpdateTemplate(&template, restActor(c)); err != nil {
+		c.JSON(templateErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, template)
+}
+
+// DeleteTemplate godoc
+// @Summary Delete a task template
+// @Description Delete a task template; tasks created from it are kept
+// @Tags templates
+// @Param id path int true "Template ID"
+// @Success 204
+// @Router /templates/{id} [delete]
+func (h *TemplateHandler) DeleteTemplate(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+	if err := h.service.DeleteTemplate(id, restActor(c)); err != nil {
+		c.JSON(templateErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// InstantiateTemplate godoc
+// @Summary Create tasks from a template
+// @Description Create the template's task with its subtasks, filling in every placeholder; nothing is created when a step fails
+// @Tags templates
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Template ID"
+// @Param params body domain.TemplateParams true "Placeholder values and where to create the tasks"
+// @Success 201 {object} domain.Task
+// @Router /templates/{id}/instantiate [post]
+func (h *TemplateHandler) InstantiateTemplate(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+	var params domain.TemplateParams
+	if err := c.ShouldBindJSON(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	template, err := h.service.GetTemplate(id, restActor(c))
+	if errors.Is(err, domain.ErrForbidden) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+		return
+	}
+	task, err := h.tasks.InstantiateTemplate(template, params, restActor(c))
+	if err != nil {
+		c.JSON(templateErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, task)
+}
+
+// templateErrorStatus maps template errors to HTTP status codes.
+func templateErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidTemplate), errors.Is(err, domain.ErrMissingPlaceholder), errors.Is(err, domain.ErrTemplateLabel),
+		errors.Is(err, domain.ErrUnknownPriority), errors.Is(err, domain.ErrNegativeEstimate):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,377 @@
+package interfaces
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+	"task-manager-app/backend/internal/middleware"
+	"task-manager-app/backend/pkg/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type TimeHandler struct {
+	service *application.TimeService
+}
+
+func NewTimeHandler(service *application.TimeService) *TimeHandler {
+	return &TimeHandler{service: service}
+}
+
+type timeEntryRequest struct {
+	StartedAt time.Time `json:"startedAt" binding:"required"`
+	EndedAt   time.Time `json:"endedAt" binding:"required"`
+	Note      string    `json:"note"`
+}
+
+// StartTimer godoc
+// @Summary Start a timer on a task
+// @Description Start measuring the current user's time on a task; a user can only run one timer at a time
+// @Tags time
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Param timer body object false "Optional note"
+// @Success 201 {object} domain.TimeEntry
+// @Failure 409 {object} gin.H
+// @Router /tasks/{id}/timer [post]
+func (h *TimeHandler) StartTimer(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	var req struct {
+		Note string `json:"note"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": utils.TranslateError(err)})
+			return
+		}
+	}
+	if _, ok := middleware.UserIDFromContext(c.Request.Context()); !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	entry, err := h.service.StartTimer(taskID, req.Note, restActor(c))
+	if err != nil {
+		c.JSON(timeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, entry)
+}
+
+// StopTimer godoc
+// @Summary Stop the running timer
+// @Description Stop the current user's running timer
+// @Tags time
+// @Produce  json
+// @Success 200 {object} domain.TimeEntry
+// @Failure 404 {object} gin.H
+// @Router /timer/stop [post]
+func (h *TimeHandler) StopTimer(c *gin.Context) {
+	userID, ok := middleware.UserIDFromContext(c.Request.Context())
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	entry, err := h.service.StopTimer(userID)
+	if err != nil {
+		c.JSON(timeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, entry)
+}
+
+// GetRunningTimer godoc
+// @Summary Get the running timer
+// @Description Get the current user's running timer
+// @Tags time
+// @Produce  json
+// @Success 200 {object} domain.TimeEntry
+// @Failure 404 {object} gin.H
+// @Router /timer [get]
+func (h *TimeHandler) GetRunningTimer(c *gin.Context) {
+	userID, ok := middleware.UserIDFromContext(c.Request.Context())
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	entry, err := h.service.GetRunningTimer(userID)
+	if err == nil && entry == nil {
+		err = domain.ErrNoRunningTimer
+	}
+	if err != nil {
+		c.JSON(timeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, entry)
+}
+
+// LogTime godoc
+// @Summary Log time on a task
+// @Description Record time the current user spent on a task by hand
+// @Tags time
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Param entry body object true "startedAt, endedAt (RFC3339) and optional note"
+// @Success 201 {object} domain.TimeEntry
+// @Router /tasks/{id}/time-entries [post]
+func (h *TimeHandler) LogTime(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	var req timeEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": utils.TranslateError(err)})
+		return
+	}
+	if _, ok := middleware.UserIDFromContext(c.Request.Context()); !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	entry, err := h.service.LogTime(taskID, req.StartedAt, req.EndedAt, req.Note, restActor(c))
+	if err != nil {
+		c.JSON(timeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, entry)
+}
+
+// GetTaskTimeEntries godoc
+// @Summary Get the time entries of a task
+// @Description Get the time entries of a task, newest first
+// @Tags time
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Success 200 {object} domain.TimeEntryConnection
+// @Router /tasks/{id}/time-entries [get]
+func (h *TimeHandler) GetTaskTimeEntries(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	filter, ok := timeEntryFilterFromQuery(c)
+	if !ok {
+		return
+	}
+	filter.TaskID = taskID
+	entries, err := h.service.GetEntries(filter, restActor(c))
+	if err != nil {
+		c.JSON(timeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, entries)
+}
+
+// GetTaskTime godoc
+// @Summary Compare estimates with tracked time
+// @Description Get the original and remaining estimates of a task next to the time tracked on it
+// @Tags time
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Success 200 {object} domain.TaskTime
+// @Router /tasks/{id}/time [get]
+func (h *TimeHandler) GetTaskTime(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	taskTime, err := h.service.GetTaskTime(taskID, restActor(c))
+	if err != nil {
+		c.JSON(timeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, taskTime)
+}
+
+// GetTimeEntries godoc
+// @Summary List time entries
+// @Description List time entries, newest first
+// @Tags time
+// @Produce  json
+// @Param taskId query int false "Task ID"
+// @Param projectId query int false "Project ID"
+// @Param userId query int false "User ID"
+// @Param from query string false "Entries started at or after (RFC3339)"
+// @Param to query string false "Entries started before (RFC3339)"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Success 200 {object} domain.TimeEntryConnection
+// @Router /time-entries [get]
+func (h *TimeHandler) GetTimeEntries(c *gin.Context) {
+	filter, ok := timeEntryFilterFromQuery(c)
+	if !ok {
+		return
+	}
+	entries, err := h.service.GetEntries(filter, restActor(c))
+	if err != nil {
+		c.JSON(timeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, entries)
+}
+
+// GetTimeReport godoc
+// @Summary Sum tracked time
+// @Description Sum the finished time entries matching the filter, per task, project and user
+// @Tags time
+// @Produce  json
+// @Param taskId query int false "Task ID"
+// @Param projectId query int false "Project ID"
+// @Param userId query int false "User ID"
+// @Param from query string false "Entries started at or after (RFC3339)"
+// @Param to query string false "Entries started before (RFC3339)"
+// @Success 200 {object} domain.TimeReport
+// @Router /time-entries/report [get]
+func (h *TimeHandler) GetTimeReport(c *gin.Context) {
+	filter, ok := timeEntryFilterFromQuery(c)
+	if !ok {
+		return
+	}
+	report, err := h.service.GetReport(filter, restActor(c))
+	if err != nil {
+		c.JSON(timeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// GetTimeEntry godoc
+// @Summary Get a time entry by ID
+// @Description Get a time entry by ID
+// @Tags time
+// @Produce  json
+// @Param id path int true "Time entry ID"
+// @Success 200 {object} domain.TimeEntry
+// @Router /time-entries/{id} [get]
+func (h *TimeHandler) GetTimeEntry(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid time entry ID"})
+		return
+	}
+	entry, err := h.service.GetEntry(id, restActor(c))
+	if err != nil {
+		c.JSON(timeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, entry)
+}
+
+// UpdateTimeEntry godoc
+// @Summary Correct a time entry
+// @Description Change the start, end or note of one of the current user's time entries
+// @Tags time
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Time entry ID"
+// @Param entry body domain.TimeEntryUpdate true "Fields to change"
+// @Success 200 {object} domain.TimeEntry
+// @Failure 403 {object} gin.H
+// @Router /time-entries/{id} [put]
+func (h *TimeHandler) UpdateTimeEntry(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid time entry ID"})
+		return
+	}
+	var update domain.TimeEntryUpdate
+	if err := c.ShouldBindJSON(&update); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": utils.TranslateError(err)})
+		return
+	}
+	userID, ok := middleware.UserIDFromContext(c.Request.Context())
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	entry, err := h.service.UpdateEntry(id, userID, update)
+	if err != nil {
+		c.JSON(timeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, entry)
+}
+
+// DeleteTimeEntry godoc
+// @Summary Delete a time entry
+// @Description Delete one of the current user's time entries
+// @Tags time
+// @Param id path int true "Time entry ID"
+// @Success 204
+// @Failure 403 {object} gin.H
+// @Router /time-entries/{id} [delete]
+func (h *TimeHandler) DeleteTimeEntry(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid time entry ID"})
+		return
+	}
+	userID, ok := middleware.UserIDFromContext(c.Request.Context())
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	if err := h.service.DeleteEntry(id, userID); err != nil {
+		c.JSON(timeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// timeEntryFilterFromQuery reads the time entry filter from the query string, writing a 400 response
+// and returning false when a parameter is invalid.
+func timeEntryFilterFromQuery(c *gin.Context) (domain.TimeEntryFilter, bool) {
+	filter := domain.TimeEntryFilter{Page: 1, Limit: 20}
+	ints := map[string]*int{"taskId": &filter.TaskID, "projectId": &filter.ProjectID, "userId": &filter.UserID, "page": &filter.Page, "limit": &filter.Limit}
+	for param, target := range ints {
+		if v := c.Query(param); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param})
+				return filter, false
+			}
+			*target = n
+		}
+	}
+	for param, target := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		if v := c.Query(param); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param + " date, expected RFC3339"})
+				return filter, false
+			}
+			*target = &t
+		}
+	}
+	return filter, true
+}
+
+// timeErrorStatus maps time service errors to HTTP status codes.
+func timeErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidTimeEntry):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrTimeEntryForbidden), errors.Is(err, domain.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrTimerRunning):
+		return http.StatusConflict
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, domain.ErrNoRunningTimer):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
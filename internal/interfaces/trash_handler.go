@@ -0,0 +1,164 @@
+package interfaces
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type TrashHandler struct {
+	service *application.TrashService
+}
+
+func NewTrashHandler(service *application.TrashService) *TrashHandler {
+	return &TrashHandler{service: service}
+}
+
+// GetDeletedTasks godoc
+// @Summary List deleted tasks
+// @Description List the tasks in the trash, most recently deleted first
+// @Tags trash
+// @Produce  json
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Param search query string false "Search in title"
+// @Success 200 {object} domain.TaskConnection
+// @Router /trash/tasks [get]
+func (h *TrashHandler) GetDeletedTasks(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+	tasks, err := h.service.GetDeletedTasks(domain.TaskFilter{Search: c.Query("search"), Page: page, Limit: limit}, restActor(c))
+	if err != nil {
+		c.JSON(trashErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, tasks)
+}
+
+// RestoreTask godoc
+// @Summary Restore a deleted task
+// @Description Take a task out of the trash together with the subtasks deleted with it
+// @Tags trash
+// @Produce  json
+// @Param id path int true "Task ID"
+// @Success 200 {object} domain.Task
+// @Router /trash/tasks/{id}/restore [post]
+func (h *TrashHandler) RestoreTask(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	task, err := h.service.RestoreTask(id, restActor(c))
+	if err != nil {
+		c.JSON(trashErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
+
+// PurgeTask godoc
+// @Summary Permanently delete a task
+// @Description Permanently delete a task from the trash with its comments, history and attachments
+// @Tags trash
+// @Param id path int true "Task ID"
+// @Success 204
+// @Router /trash/tasks/{id} [delete]
+func (h *TrashHandler) PurgeTask(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	if err := h.service.PurgeTask(c.Request.Context(), id, restActor(c)); err != nil {
+		c.JSON(trashErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetDeletedUsers godoc
+// @Summary List deleted users
+// @Description List the users in the trash, most recently deleted first
+// @Tags trash
+// @Produce  json
+// @Success 200 {array} domain.User
+// @Router /trash/users [get]
+func (h *TrashHandler) GetDeletedUsers(c *gin.Context) {
+	users, err := h.service.GetDeletedUsers(restActor(c))
+	if err != nil {
+		c.JSON(trashErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, users)
+}
+
+// RestoreUser godoc
+// @Summary Restore a deleted user
+// @Description Take a user out of the trash; fails if their email has been registered again meanwhile
+// @Tags trash
+// @Produce  json
+// @Param id path int true "User ID"
+// @Success 200 {object} domain.User
+// @Failure 409 {object} gin.H
+// @Router /trash/users/{id}/restore [post]
+func (h *TrashHandler) RestoreUser(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	user, err := h.service.RestoreUser(id, restActor(c))
+	if err != nil {
+		c.JSON(trashErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+// PurgeUser godoc
+// @Summary Permanently delete a user
+// @Description Permanently delete a user from the trash
+// @Tags trash
+// @Param id path int true "User ID"
+// @Success 204
+// @Router /trash/users/{id} [delete]
+func (h *TrashHandler) PurgeUser(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	if err := h.service.PurgeUser(id, restActor(c)); err != nil {
+		c.JSON(trashErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// trashErrorStatus maps trash service errors to HTTP status codes.
+func trashErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrEmailTaken):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrForbidden):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -1,12 +1,14 @@
 package interfaces
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"task-manager-app/backend/internal/application"
 	"task-manager-app/backend/internal/domain"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type UserHandler struct {
@@ -62,9 +64,9 @@ func (h *UserHandler) Login(c *gin.Context) {
 
 // GetUsers handles fetching all users
 func (h *UserHandler) GetUsers(c *gin.Context) {
-	users, err := h.service.GetAllUsers()
+	users, err := h.service.GetAllUsers(restActor(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, users)
@@ -78,9 +80,13 @@ func (h *UserHandler) GetUserByID(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
 	}
-	user, err := h.service.GetUserByID(userID)
+	user, err := h.service.GetUserByID(userID, restActor(c))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		status := userErrorStatus(err)
+		if status == http.StatusInternalServerError {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, user)
@@ -106,8 +112,8 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		LastName: req.LastName,
 		Avatar:   req.Avatar,
 	}
-	if err := h.service.UpdateUser(user); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.service.UpdateUser(user, restActor(c)); err != nil {
+		c.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "User updated successfully"})
@@ -121,9 +127,21 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
 	}
-	if err := h.service.DeleteUser(userID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.service.DeleteUser(userID, restActor(c)); err != nil {
+		c.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
+
+// userErrorStatus maps user service errors to HTTP status codes.
+func userErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
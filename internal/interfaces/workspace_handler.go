@@ -0,0 +1,226 @@
+package interfaces
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type WorkspaceHandler struct {
+	service *application.WorkspaceService
+}
+
+func NewWorkspaceHandler(service *application.WorkspaceService) *WorkspaceHandler {
+	return &WorkspaceHandler{service: service}
+}
+
+// CreateWorkspace godoc
+// @Summary Create a new workspace
+// @Description Create a new workspace owned by the authenticated user
+// @Tags workspaces
+// @Accept  json
+// @Produce  json
+// @Param workspace body domain.Workspace true "Workspace"
+// @Success 201 {object} domain.Workspace
+// @Router /workspaces [post]
+func (h *WorkspaceHandler) CreateWorkspace(c *gin.Context) {
+	var workspace domain.Workspace
+	if err := c.ShouldBindJSON(&workspace); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.service.CreateWorkspace(&workspace, restActor(c)); err != nil {
+		c.JSON(workspaceErrorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, workspace)
+}
+
+// GetWorkspaceByID godoc
+// @Summary Get a workspace by ID
+// @Description Get a workspace by ID
+// @Tags workspaces
+// @Produce  json
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} domain.Workspace
+// @Router /workspaces/{id} [get]
+func (h *WorkspaceHandler) GetWorkspaceByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+	workspace, err := h.service.GetWorkspaceByID(id, restActor(c))
+	if errors.Is(err, domain.ErrForbidden) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Workspace not found"})
+		return
+	}
+	c.JSON(http.StatusOK, workspace)
+}
+
+// GetWorkflow godoc
+// @Summary Get the workflow of a workspace
+// @Description List the status transitions allowed in a workspace
+// @Tags workspaces
+// @Produce  json
+// @Param id path int true "Workspace ID"
+// @Success 200 {array} domain.WorkflowTransition
+// @Router /workspaces/{id}/workflow [get]
+func (h *WorkspaceHandler) GetWorkflow(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+	transitions, err := h.service.GetWorkflow(id, restActor(c))
+	if err != nil {
+		c.JSON(workspaceErrorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, transitions)
+}
+
+// UpdateWorkflow godoc
+// @Summary Replace the workflow of a workspace
+// @Description Replace the status transitions allowed in a workspace; an empty list restores the default workflow
+// @Tags workspaces
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Workspace ID"
+// @Param transitions body []domain.WorkflowTransition true "Transitions"
+// @Success 200 {array} domain.WorkflowTransition
+// @Router /workspaces/{id}/workflow [put]
+func (h *WorkspaceHandler) UpdateWorkflow(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+	var transitions []domain.WorkflowTransition
+	if err := c.ShouldBindJSON(&transitions); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.service.SetWorkflow(id, transitions, restActor(c)); err != nil {
+		c.JSON(workspaceErrorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, transitions)
+}
+
+// GetMembers godoc
+// @Summary List the members of a workspace
+// @Description List the members of a workspace with their roles
+// @Tags workspaces
+// @Produce  json
+// @Param id path int true "Workspace ID"
+// @Success 200 {array} domain.WorkspaceMember
+// @Router /workspaces/{id}/members [get]
+func (h *WorkspaceHandler) GetMembers(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+	members, err := h.service.GetMembers(id, restActor(c))
+	if err != nil {
+		c.JSON(workspaceErrorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, members)
+}
+
+type setMemberRequest struct {
+	Role domain.Role `json:"role" binding:"required"`
+}
+
+// SetMember godoc
+// @Summary Add a workspace member or change their role
+// @Description Give a user a role (owner, admin, member or viewer) in a workspace; a workspace always keeps at least one owner
+// @Tags workspaces
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Workspace ID"
+// @Param userId path int true "User ID"
+// @Param member body setMemberRequest true "Role"
+// @Success 200 {object} domain.WorkspaceMember
+// @Failure 403 {object} gin.H
+// @Failure 409 {object} gin.H
+// @Router /workspaces/{id}/members/{userId} [put]
+func (h *WorkspaceHandler) SetMember(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+	userID, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	var req setMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	member := domain.WorkspaceMember{WorkspaceID: id, UserID: userID, Role: req.Role}
+	if err := h.service.SetMember(&member, restActor(c)); err != nil {
+		c.JSON(workspaceErrorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, member)
+}
+
+// RemoveMember godoc
+// @Summary Remove a member from a workspace
+// @Description Take a user's role in a workspace away; the last owner cannot be removed
+// @Tags workspaces
+// @Param id path int true "Workspace ID"
+// @Param userId path int true "User ID"
+// @Success 204
+// @Failure 403 {object} gin.H
+// @Failure 409 {object} gin.H
+// @Router /workspaces/{id}/members/{userId} [delete]
+func (h *WorkspaceHandler) RemoveMember(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+	userID, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	if err := h.service.RemoveMember(id, userID, restActor(c)); err != nil {
+		c.JSON(workspaceErrorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// workspaceErrorStatus maps workspace service errors to HTTP status codes, falling back
+// to the given status for anything else.
+func workspaceErrorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, domain.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrUnknownStatus), errors.Is(err, domain.ErrInvalidRole):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrNotMember), errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrLastOwner):
+		return http.StatusConflict
+	default:
+		return fallback
+	}
+}
@@ -2,58 +2,80 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
+	"task-manager-app/backend/internal/domain"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v4"
 )
 
 type contextKey string
 
-const userIDKey contextKey = "user_id"
+const claimsKey contextKey = "claims"
 
-func AuthMiddleware(jwtSecret []byte) gin.HandlerFunc {
+var ErrAuthorizationFormat = errors.New("invalid authorization format")
+
+// TokenValidator validates access tokens; application.TokenService implements it.
+type TokenValidator interface {
+	Validate(token string) (*domain.AccessClaims, error)
+}
+
+// AuthMiddleware rejects requests without a valid bearer token and stores the token's
+// claims in the request context.
+func AuthMiddleware(tokens TokenValidator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.Next()
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
 			return
 		}
 
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization format"})
+		claims, err := Authenticate(tokens, authHeader)
+		if err != nil {
+			c.JSON(AuthErrorStatus(err), gin.H{"error": err.Error()})
 			c.Abort()
 			return
 		}
+		c.Request = c.Request.WithContext(WithClaims(c.Request.Context(), claims))
+		c.Next()
+	}
+}
 
-		tokenString := parts[1]
+// Authenticate validates the bearer token of an Authorization header.
+func Authenticate(tokens TokenValidator, authHeader string) (*domain.AccessClaims, error) {
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return nil, ErrAuthorizationFormat
+	}
+	return tokens.Validate(parts[1])
+}
 
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return jwtSecret, nil
-		})
+// AuthErrorStatus maps an Authenticate error to an HTTP status: 500 when the token could
+// not be checked, 401 otherwise.
+func AuthErrorStatus(err error) int {
+	if errors.Is(err, ErrAuthorizationFormat) || errors.Is(err, domain.ErrInvalidToken) || errors.Is(err, domain.ErrTokenRevoked) {
+		return http.StatusUnauthorized
+	}
+	return http.StatusInternalServerError
+}
 
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
-			c.Abort()
-			return
-		}
+// WithClaims returns a copy of ctx carrying the claims of the authenticated token.
+func WithClaims(ctx context.Context, claims *domain.AccessClaims) context.Context {
+	return context.WithValue(ctx, claimsKey, claims)
+}
 
-		if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-			if userID, ok := claims["user_id"].(string); ok {
-				ctx := context.WithValue(c.Request.Context(), userIDKey, userID)
-				c.Request = c.Request.WithContext(ctx)
-			}
-		} else {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
-			c.Abort()
-			return
-		}
+// ClaimsFromContext returns the claims of the token the request was authenticated with.
+func ClaimsFromContext(ctx context.Context) (*domain.AccessClaims, bool) {
+	claims, ok := ctx.Value(claimsKey).(*domain.AccessClaims)
+	return claims, ok
+}
 
-		c.Next()
+// UserIDFromContext returns the authenticated user's ID stored by AuthMiddleware.
+func UserIDFromContext(ctx context.Context) (int, bool) {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return 0, false
 	}
+	return claims.UserID, true
 }
@@ -12,6 +12,7 @@ import (
 	"task-manager-app/backend/internal/interfaces"
 	"task-manager-app/backend/internal/tests"
 
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
 )
@@ -20,40 +21,62 @@ func setupTaskDB() (*gorm.DB, error) {
 	return tests.SetupTestDB()
 }
 
-func TestCreateTask(t *testing.T) {
+// setupTaskRouter sets up a router with a signed up user, returning the user's ID and token.
+func setupTaskRouter(t *testing.T) (*gorm.DB, *gin.Engine, int, string) {
+	t.Setenv("JWT_SECRET", "integration-secret")
 	db, err := setupTaskDB()
 	assert.NoError(t, err)
 	router := interfaces.SetupRouter(db)
+	userID, token := signUp(t, db, router, "owner@example.com")
+	return db, router, userID, token
+}
+
+func TestCreateTask(t *testing.T) {
+	_, router, userID, token := setupTaskRouter(t)
 
 	task := domain.Task{Title: "Test Task", Description: "Test Description"}
 	taskJSON, _ := json.Marshal(task)
 
 	req, _ := http.NewRequest("POST", "/tasks", bytes.NewBuffer(taskJSON))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", token)
 	res := httptest.NewRecorder()
 	router.ServeHTTP(res, req)
 
 	assert.Equal(t, http.StatusCreated, res.Code)
+	var created domain.Task
+	assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &created))
+	assert.Equal(t, userID, created.UserID)
+
+	req, _ = http.NewRequest("POST", "/tasks", bytes.NewBuffer(taskJSON))
+	req.Header.Set("Content-Type", "application/json")
+	res = httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusUnauthorized, res.Code, "anonymous requests cannot create tasks")
 }
 
 func TestGetTasks(t *testing.T) {
-	db, err := setupTaskDB()
-	assert.NoError(t, err)
-	router := interfaces.SetupRouter(db)
+	db, router, userID, token := setupTaskRouter(t)
+
+	db.Create(&domain.Task{Title: "Mine", UserID: userID})
+	db.Create(&domain.Task{Title: "Someone else's", UserID: userID + 1})
 
 	req, _ := http.NewRequest("GET", "/tasks", nil)
+	req.Header.Set("Authorization", token)
 	res := httptest.NewRecorder()
 	router.ServeHTTP(res, req)
 
 	assert.Equal(t, http.StatusOK, res.Code)
+	var tasks domain.TaskConnection
+	assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &tasks))
+	assert.Equal(t, 1, tasks.PageInfo.TotalCount)
+	assert.Equal(t, "Mine", tasks.Edges[0].Node.Title)
 }
 
 func TestUpdateTask(t *testing.T) {
-	db, err := setupTaskDB()
-	assert.NoError(t, err)
-	router := interfaces.SetupRouter(db)
+	db, router, userID, token := setupTaskRouter(t)
 
-	task := domain.Task{Title: "Test Task", Description: "Test Description"}
+	task := domain.Task{Title: "Test Task", Description: "Test Description", UserID: userID}
 	db.Create(&task)
 
 	updatedTask := domain.Task{Title: "Updated Task", Description: "Updated Description"}
@@ -61,6 +84,7 @@ func TestUpdateTask(t *testing.T) {
 
 	req, _ := http.NewRequest("PUT", "/tasks/"+strconv.Itoa(task.ID), bytes.NewBuffer(taskJSON))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", token)
 	res := httptest.NewRecorder()
 	router.ServeHTTP(res, req)
 
@@ -68,16 +92,115 @@ func TestUpdateTask(t *testing.T) {
 }
 
 func TestDeleteTask(t *testing.T) {
-	db, err := setupTaskDB()
-	assert.NoError(t, err)
-	router := interfaces.SetupRouter(db)
+	db, router, userID, token := setupTaskRouter(t)
 
-	task := domain.Task{Title: "Test Task", Description: "Test Description"}
+	task := domain.Task{Title: "Test Task", Description: "Test Description", UserID: userID}
 	db.Create(&task)
 
 	req, _ := http.NewRequest("DELETE", "/tasks/"+strconv.Itoa(task.ID), nil)
+	req.Header.Set("Authorization", token)
 	res := httptest.NewRecorder()
 	router.ServeHTTP(res, req)
 
 	assert.Equal(t, http.StatusNoContent, res.Code)
 }
+
+func TestTaskHistory(t *testing.T) {
+	db, router, userID, token := setupTaskRouter(t)
+
+	task := domain.Task{Title: "Test Task", Description: "Test Description", Status: domain.StatusTodo, UserID: userID}
+	db.Create(&task)
+
+	taskJSON, _ := json.Marshal(domain.Task{Title: "Renamed Task", Description: "Test Description"})
+	req, _ := http.NewRequest("PUT", "/tasks/"+strconv.Itoa(task.ID), bytes.NewBuffer(taskJSON))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", token)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	req, _ = http.NewRequest("GET", "/tasks/"+strconv.Itoa(task.ID)+"/history", nil)
+	req.Header.Set("Authorization", token)
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusOK, res.Code)
+
+	var history domain.ActivityConnection
+	assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &history))
+	assert.Equal(t, 1, history.PageInfo.TotalCount)
+	entry := history.Edges[0].Node
+	assert.Equal(t, domain.SourceREST, entry.Source)
+	assert.Equal(t, []domain.FieldChange{{Field: "title", Old: "Test Task", New: "Renamed Task"}}, entry.Changes)
+
+	req, _ = http.NewRequest("GET", "/tasks/9999/history", nil)
+	req.Header.Set("Authorization", token)
+	res = httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusNotFound, res.Code)
+}
+
+func TestTaskAccess(t *testing.T) {
+	db, router, userID, _ := setupTaskRouter(t)
+	_, otherToken := signUp(t, db, router, "other@example.com")
+
+	task := domain.Task{Title: "Private", UserID: userID}
+	db.Create(&task)
+
+	taskJSON, _ := json.Marshal(domain.Task{Title: "Taken over"})
+	for _, method := range []string{"GET", "PUT", "DELETE"} {
+		req, _ := http.NewRequest(method, "/tasks/"+strconv.Itoa(task.ID), bytes.NewBuffer(taskJSON))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", otherToken)
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		assert.Equal(t, http.StatusForbidden, res.Code, method)
+	}
+}
+
+func TestWorkspaceAccess(t *testing.T) {
+	db, router, userID, _ := setupTaskRouter(t)
+	viewerID, viewerToken := signUp(t, db, router, "viewer@example.com")
+	_, outsiderToken := signUp(t, db, router, "outsider@example.com")
+
+	workspace := domain.Workspace{Name: "Team", OwnerID: userID}
+	db.Create(&workspace)
+	db.Create(&domain.WorkspaceMember{WorkspaceID: workspace.ID, UserID: viewerID, Role: domain.RoleViewer})
+	project := domain.Project{Name: "Website", OwnerID: userID, WorkspaceID: workspace.ID}
+	db.Create(&project)
+	task := domain.Task{Title: "Design", UserID: userID, WorkspaceID: workspace.ID, ProjectID: project.ID}
+	db.Create(&task)
+
+	do := func(method, path, token string, body any) int {
+		payload, _ := json.Marshal(body)
+		req, _ := http.NewRequest(method, path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		return res.Code
+	}
+	projectPath, taskPath := "/projects/"+strconv.Itoa(project.ID), "/tasks/"+strconv.Itoa(task.ID)
+
+	assert.Equal(t, http.StatusUnauthorized, do("GET", projectPath+"/tasks", "", nil))
+
+	// Non-members see nothing of the workspace
+	for _, path := range []string{
+		projectPath,
+		projectPath + "/tasks",
+		projectPath + "/board",
+		taskPath + "/attachments",
+		taskPath + "/comments",
+		taskPath + "/checklist",
+		"/time-entries?projectId=" + strconv.Itoa(project.ID),
+		"/time-entries/report?projectId=" + strconv.Itoa(project.ID),
+	} {
+		assert.Equal(t, http.StatusForbidden, do("GET", path, outsiderToken, nil), path)
+	}
+
+	// Viewers read but do not change
+	assert.Equal(t, http.StatusOK, do("GET", projectPath+"/tasks", viewerToken, nil))
+	assert.Equal(t, http.StatusOK, do("GET", taskPath+"/attachments", viewerToken, nil))
+	assert.Equal(t, http.StatusForbidden, do("PUT", projectPath, viewerToken, domain.Project{Name: "Renamed"}))
+	assert.Equal(t, http.StatusForbidden, do("POST", taskPath+"/checklist", viewerToken, gin.H{"text": "Sketch"}))
+	assert.Equal(t, http.StatusForbidden, do("POST", taskPath+"/timer", viewerToken, nil))
+}
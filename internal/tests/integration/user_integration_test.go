@@ -5,12 +5,14 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	"task-manager-app/backend/internal/domain"
 	"task-manager-app/backend/internal/interfaces"
 	"task-manager-app/backend/internal/tests"
 
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
 )
@@ -19,7 +21,32 @@ func setupUserDB() (*gorm.DB, error) {
 	return tests.SetupTestDB()
 }
 
+// signUp registers a user through the API and logs them in, returning their ID and a
+// bearer token for the Authorization header.
+func signUp(t *testing.T, db *gorm.DB, router *gin.Engine, email string) (int, string) {
+	body, _ := json.Marshal(domain.UserRegister{Name: "John", LastName: "Doe", Email: email, Password: "password"})
+	req, _ := http.NewRequest("POST", "/users", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusCreated, res.Code)
+
+	body, _ = json.Marshal(domain.UserLogin{Email: email, Password: "password"})
+	req, _ = http.NewRequest("POST", "/login", bytes.NewBuffer(body))
+	res = httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusOK, res.Code)
+	var login struct {
+		Token string `json:"token"`
+	}
+	assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &login))
+
+	var user domain.User
+	assert.NoError(t, db.Where("email = ?", email).First(&user).Error)
+	return user.ID, "Bearer " + login.Token
+}
+
 func TestUserIntegration(t *testing.T) {
+	t.Setenv("JWT_SECRET", "integration-secret")
 	db, err := setupUserDB()
 	if err != nil {
 		t.Fatalf("Failed to set up database: %v", err)
@@ -37,7 +64,6 @@ func TestUserIntegration(t *testing.T) {
 		body, _ := json.Marshal(user)
 
 		req, _ := http.NewRequest("POST", "/users", bytes.NewBuffer(body))
-		req.Header.Set("Authorization", "Bearer valid_token")
 		res := httptest.NewRecorder()
 
 		router.ServeHTTP(res, req)
@@ -45,33 +71,51 @@ func TestUserIntegration(t *testing.T) {
 		assert.Equal(t, http.StatusCreated, res.Code)
 	})
 
+	userID, token := signUp(t, db, router, "jane@example.com")
+	otherID, _ := signUp(t, db, router, "other@example.com")
+
 	t.Run("GET /users", func(t *testing.T) {
 		req, _ := http.NewRequest("GET", "/users", nil)
-		req.Header.Set("Authorization", "Bearer valid_token")
+		req.Header.Set("Authorization", token)
 		res := httptest.NewRecorder()
 
 		router.ServeHTTP(res, req)
 
 		assert.Equal(t, http.StatusOK, res.Code)
+		var users []domain.User
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &users))
+		assert.Len(t, users, 1, "users without a workspace in common are not listed")
 	})
 
 	t.Run("PUT /users/:id", func(t *testing.T) {
 		user := domain.User{Name: "Jane Doe", Email: "jane@example.com", LastName: "Doe", Avatar: ""}
 		body, _ := json.Marshal(user)
 
-		req, _ := http.NewRequest("PUT", "/users/1", bytes.NewBuffer(body))
-		req.Header.Set("Authorization", "Bearer valid_token")
+		req, _ := http.NewRequest("PUT", "/users/"+strconv.Itoa(userID), bytes.NewBuffer(body))
+		req.Header.Set("Authorization", token)
 		res := httptest.NewRecorder()
 
 		router.ServeHTTP(res, req)
 
 		assert.Equal(t, http.StatusOK, res.Code)
+
+		req, _ = http.NewRequest("PUT", "/users/"+strconv.Itoa(otherID), bytes.NewBuffer(body))
+		req.Header.Set("Authorization", token)
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		assert.Equal(t, http.StatusForbidden, res.Code)
 	})
 
 	t.Run("DELETE /users/:id", func(t *testing.T) {
-		req, _ := http.NewRequest("DELETE", "/users/1", nil)
-		req.Header.Set("Authorization", "Bearer valid_token")
+		req, _ := http.NewRequest("DELETE", "/users/"+strconv.Itoa(otherID), nil)
+		req.Header.Set("Authorization", token)
 		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		assert.Equal(t, http.StatusForbidden, res.Code)
+
+		req, _ = http.NewRequest("DELETE", "/users/"+strconv.Itoa(userID), nil)
+		req.Header.Set("Authorization", token)
+		res = httptest.NewRecorder()
 
 		router.ServeHTTP(res, req)
 
@@ -8,12 +8,12 @@ import (
 )
 
 func SetupTestDB() (*gorm.DB, error) {
-	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{TranslateError: true})
 	if err != nil {
 		return nil, err
 	}
 
-	err = db.AutoMigrate(&domain.Task{}, &domain.User{})
+	err = db.AutoMigrate(&domain.Task{}, &domain.User{}, &domain.Workspace{}, &domain.WorkflowTransition{}, &domain.Label{}, &domain.Project{}, &domain.TaskDependency{}, &domain.Comment{}, &domain.CommentMention{}, &domain.CommentRevision{}, &domain.Attachment{}, &domain.Activity{}, &domain.TimeEntry{}, &domain.ChecklistItem{}, &domain.CustomField{}, &domain.CustomFieldValue{}, &domain.TaskTemplate{}, &domain.BoardColumn{}, &domain.Sprint{}, &domain.RefreshToken{}, &domain.RevokedToken{}, &domain.WorkspaceMember{})
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,311 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+	"task-manager-app/backend/internal/infrastructure"
+	"task-manager-app/backend/internal/interfaces/graphql/resolver"
+	"task-manager-app/backend/internal/tests"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func newAccessPolicy(db *gorm.DB) *application.AccessPolicy {
+	return application.NewAccessPolicy(infrastructure.NewUserRepository(db), infrastructure.NewWorkspaceRepository(db))
+}
+
+// accessFixture is a workspace with one user per role, someone outside it and a superadmin.
+type accessFixture struct {
+	tasks      *application.TaskService
+	workspaces *application.WorkspaceService
+	users      *application.UserService
+	workspace  *domain.Workspace
+
+	owner, admin, member, viewer, outsider, superadmin domain.Actor
+}
+
+func setupAccessFixture(t *testing.T) *accessFixture {
+	db, err := tests.SetupTestDB()
+	assert.NoError(t, err)
+	policy := newAccessPolicy(db)
+	userRepo := infrastructure.NewUserRepository(db)
+	workspaceRepo := infrastructure.NewWorkspaceRepository(db)
+	f := &accessFixture{
+		tasks:      application.NewTaskService(infrastructure.NewTaskRepository(db), workspaceRepo, infrastructure.NewActivityRepository(db), policy),
+		workspaces: application.NewWorkspaceService(workspaceRepo, policy),
+		users:      application.NewUserService(userRepo, infrastructure.NewRefreshTokenRepository(db), newTokenService(db, time.Minute), time.Hour, policy),
+	}
+
+	actors := make([]domain.Actor, 6)
+	for i, name := range []string{"owner", "admin", "member", "viewer", "outsider", "superadmin"} {
+		user := &domain.User{Name: name, Email: name + "@example.com", Superadmin: name == "superadmin"}
+		assert.NoError(t, userRepo.Create(user))
+		actors[i] = domain.Actor{UserID: user.ID, Source: domain.SourceREST}
+	}
+	f.owner, f.admin, f.member, f.viewer, f.outsider, f.superadmin = actors[0], actors[1], actors[2], actors[3], actors[4], actors[5]
+
+	f.workspace = &domain.Workspace{Name: "Team"}
+	assert.NoError(t, f.workspaces.CreateWorkspace(f.workspace, f.owner))
+	assert.Equal(t, f.owner.UserID, f.workspace.OwnerID)
+	for _, m := range []struct {
+		actor domain.Actor
+		role  domain.Role
+	}{{f.admin, domain.RoleAdmin}, {f.member, domain.RoleMember}, {f.viewer, domain.RoleViewer}} {
+		member := &domain.WorkspaceMember{WorkspaceID: f.workspace.ID, UserID: m.actor.UserID, Role: m.role}
+		assert.NoError(t, f.workspaces.SetMember(member, f.owner))
+	}
+	return f
+}
+
+func TestRolePermissions(t *testing.T) {
+	all := []domain.Permission{
+		domain.PermViewTasks, domain.PermCreateTasks, domain.PermEditTasks, domain.PermDeleteTasks,
+		domain.PermManageWorkspace, domain.PermManageMembers, domain.PermManageOwners,
+	}
+	granted := map[domain.Role]int{
+		domain.RoleViewer: 1,
+		domain.RoleMember: 3,
+		domain.RoleAdmin:  6,
+		domain.RoleOwner:  7,
+	}
+	for role, count := range granted {
+		assert.True(t, role.IsValid())
+		for i, perm := range all {
+			assert.Equal(t, i < count, role.Can(perm), fmt.Sprintf("%s %s", role, perm))
+		}
+	}
+	assert.False(t, domain.Role(domain.RoleSuperadmin).IsValid(), "superadmin is not a workspace role")
+	assert.False(t, domain.Role("").Can(domain.PermViewTasks))
+}
+
+func TestWorkspaceTaskAccess(t *testing.T) {
+	f := setupAccessFixture(t)
+
+	task := &domain.Task{Title: "Plan", WorkspaceID: f.workspace.ID}
+	assert.NoError(t, f.tasks.CreateTask(task, f.member))
+	assert.Equal(t, f.member.UserID, task.UserID, "the actor becomes the creator")
+	assert.ErrorIs(t, f.tasks.CreateTask(&domain.Task{Title: "x", WorkspaceID: f.workspace.ID}, f.viewer), domain.ErrForbidden)
+	assert.ErrorIs(t, f.tasks.CreateTask(&domain.Task{Title: "x", WorkspaceID: f.workspace.ID}, f.outsider), domain.ErrForbidden)
+	assert.ErrorIs(t, f.tasks.CreateTask(&domain.Task{Title: "x"}, domain.Actor{Source: domain.SourceREST}), domain.ErrForbidden,
+		"anonymous requests cannot create even personal tasks")
+
+	for _, actor := range []domain.Actor{f.owner, f.admin, f.member, f.viewer, f.superadmin} {
+		_, err := f.tasks.GetTaskByID(task.ID, actor)
+		assert.NoError(t, err)
+	}
+	_, err := f.tasks.GetTaskByID(task.ID, f.outsider)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+
+	task.Title = "Plan the launch"
+	assert.ErrorIs(t, f.tasks.UpdateTask(task, f.viewer), domain.ErrForbidden)
+	assert.NoError(t, f.tasks.UpdateTask(task, f.member))
+	_, err = f.tasks.ChangeStatus(task.ID, domain.StatusInProgress, f.viewer, false)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+
+	// Moving a task needs the right to create tasks where it goes
+	other := &domain.Workspace{Name: "Other"}
+	assert.NoError(t, f.workspaces.CreateWorkspace(other, f.outsider))
+	task.WorkspaceID = other.ID
+	assert.ErrorIs(t, f.tasks.UpdateTask(task, f.member), domain.ErrForbidden)
+	task.WorkspaceID = f.workspace.ID
+
+	// Members delete only the tasks they created; admins delete any
+	byOwner := &domain.Task{Title: "Budget", WorkspaceID: f.workspace.ID}
+	assert.NoError(t, f.tasks.CreateTask(byOwner, f.owner))
+	assert.ErrorIs(t, f.tasks.DeleteTask(byOwner.ID, domain.DeleteCascade, f.member), domain.ErrForbidden)
+	assert.NoError(t, f.tasks.DeleteTask(byOwner.ID, domain.DeleteCascade, f.admin))
+	assert.ErrorIs(t, f.tasks.DeleteTask(task.ID, domain.DeleteCascade, f.viewer), domain.ErrForbidden)
+	assert.NoError(t, f.tasks.DeleteTask(task.ID, domain.DeleteCascade, f.member))
+}
+
+func TestPersonalTaskAccess(t *testing.T) {
+	f := setupAccessFixture(t)
+
+	task := &domain.Task{Title: "Dentist"}
+	assert.NoError(t, f.tasks.CreateTask(task, f.owner))
+	_, err := f.tasks.GetTaskByID(task.ID, f.admin)
+	assert.ErrorIs(t, err, domain.ErrForbidden, "personal tasks are not shared with the workspace")
+	_, err = f.tasks.AssignTask(task.ID, f.member.UserID, f.admin)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+
+	_, err = f.tasks.AssignTask(task.ID, f.member.UserID, f.owner)
+	assert.NoError(t, err)
+	_, err = f.tasks.WatchTask(task.ID, f.viewer.UserID, f.owner)
+	assert.NoError(t, err)
+
+	task, err = f.tasks.GetTaskByID(task.ID, f.member)
+	assert.NoError(t, err)
+	task.Title = "Dentist at 3"
+	assert.NoError(t, f.tasks.UpdateTask(task, f.member), "assignees work on the task")
+	assert.ErrorIs(t, f.tasks.UpdateTask(task, f.viewer), domain.ErrForbidden, "watchers only see it")
+	_, err = f.tasks.UnwatchTask(task.ID, f.viewer.UserID, f.viewer)
+	assert.NoError(t, err, "watchers may stop watching")
+
+	task.UserID = f.member.UserID
+	assert.NoError(t, f.tasks.UpdateTask(task, f.owner))
+	stored, err := f.tasks.GetTaskByID(task.ID, f.superadmin)
+	assert.NoError(t, err)
+	assert.Equal(t, f.owner.UserID, stored.UserID, "updates do not change the creator")
+}
+
+func TestSubtaskParentAccess(t *testing.T) {
+	f := setupAccessFixture(t)
+
+	parent := &domain.Task{Title: "Launch", WorkspaceID: f.workspace.ID}
+	assert.NoError(t, f.tasks.CreateTask(parent, f.owner))
+	private := &domain.Task{Title: "Private"}
+	assert.NoError(t, f.tasks.CreateTask(private, f.outsider))
+
+	assert.ErrorIs(t, f.tasks.CreateTask(&domain.Task{Title: "Sneak in", ParentID: private.ID}, f.member), domain.ErrForbidden)
+	assert.ErrorIs(t, f.tasks.CreateTask(&domain.Task{Title: "Step", ParentID: parent.ID, WorkspaceID: f.workspace.ID}, f.viewer), domain.ErrForbidden)
+	assert.ErrorIs(t, f.tasks.CreateTask(&domain.Task{Title: "Step", ParentID: parent.ID}, f.member), domain.ErrParentScope,
+		"a personal task cannot roll up into a workspace task")
+	assert.NoError(t, f.tasks.CreateTask(&domain.Task{Title: "Step", ParentID: parent.ID, WorkspaceID: f.workspace.ID}, f.member))
+
+	mine := &domain.Task{Title: "Mine"}
+	assert.NoError(t, f.tasks.CreateTask(mine, f.member))
+	mine.ParentID = parent.ID
+	assert.ErrorIs(t, f.tasks.UpdateTask(mine, f.member), domain.ErrParentScope)
+	mine.ParentID = private.ID
+	assert.ErrorIs(t, f.tasks.UpdateTask(mine, f.member), domain.ErrForbidden)
+
+	// A task with subtasks keeps its workspace, or their rollup would leak out of it
+	parent.WorkspaceID = 0
+	assert.ErrorIs(t, f.tasks.UpdateTask(parent, f.owner), domain.ErrParentScope)
+}
+
+func TestTaskMemberAccess(t *testing.T) {
+	f := setupAccessFixture(t)
+
+	shared := &domain.Task{Title: "Launch", WorkspaceID: f.workspace.ID}
+	assert.NoError(t, f.tasks.CreateTask(shared, f.member))
+	_, err := f.tasks.AssignTask(shared.ID, f.outsider.UserID, f.owner)
+	assert.ErrorIs(t, err, domain.ErrNotMember, "workspace tasks only take members of the workspace")
+	_, err = f.tasks.WatchTask(shared.ID, f.outsider.UserID, f.owner)
+	assert.ErrorIs(t, err, domain.ErrNotMember)
+	_, err = f.tasks.AssignTask(shared.ID, f.viewer.UserID, f.member)
+	assert.NoError(t, err)
+
+	// On personal tasks only the creator brings people in
+	private := &domain.Task{Title: "Private"}
+	assert.NoError(t, f.tasks.CreateTask(private, f.outsider))
+	_, err = f.tasks.AssignTask(private.ID, f.member.UserID, f.outsider)
+	assert.NoError(t, err)
+	_, err = f.tasks.AssignTask(private.ID, f.viewer.UserID, f.member)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	_, err = f.tasks.WatchTask(private.ID, f.viewer.UserID, f.member)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	_, err = f.tasks.WatchTask(private.ID, f.member.UserID, f.member)
+	assert.NoError(t, err)
+}
+
+func TestTaskListAccess(t *testing.T) {
+	f := setupAccessFixture(t)
+
+	assert.NoError(t, f.tasks.CreateTask(&domain.Task{Title: "Shared", WorkspaceID: f.workspace.ID}, f.owner))
+	assert.NoError(t, f.tasks.CreateTask(&domain.Task{Title: "Owner's own"}, f.owner))
+	watched := &domain.Task{Title: "Outsider's own"}
+	assert.NoError(t, f.tasks.CreateTask(watched, f.outsider))
+	_, err := f.tasks.WatchTask(watched.ID, f.viewer.UserID, f.outsider)
+	assert.NoError(t, err)
+
+	titles := func(actor domain.Actor) []string {
+		tasks, err := f.tasks.GetAllTasks(domain.TaskFilter{}, actor)
+		assert.NoError(t, err)
+		var titles []string
+		for _, edge := range tasks.Edges {
+			titles = append(titles, edge.Node.Title)
+		}
+		return titles
+	}
+	assert.ElementsMatch(t, []string{"Shared", "Owner's own"}, titles(f.owner))
+	assert.ElementsMatch(t, []string{"Shared", "Outsider's own"}, titles(f.viewer))
+	assert.ElementsMatch(t, []string{"Outsider's own"}, titles(f.outsider))
+	assert.Len(t, titles(f.superadmin), 3)
+	assert.Empty(t, titles(domain.Actor{Source: domain.SourceREST}))
+}
+
+func TestWorkspaceMemberManagement(t *testing.T) {
+	f := setupAccessFixture(t)
+	id := f.workspace.ID
+
+	members, err := f.workspaces.GetMembers(id, f.viewer)
+	assert.NoError(t, err)
+	assert.Len(t, members, 4)
+	_, err = f.workspaces.GetMembers(id, f.outsider)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+
+	assert.ErrorIs(t, f.workspaces.SetMember(&domain.WorkspaceMember{WorkspaceID: id, UserID: f.outsider.UserID, Role: "guest"}, f.owner), domain.ErrInvalidRole)
+	assert.ErrorIs(t, f.workspaces.SetMember(&domain.WorkspaceMember{WorkspaceID: id, UserID: f.outsider.UserID, Role: domain.RoleViewer}, f.member), domain.ErrForbidden)
+	assert.NoError(t, f.workspaces.SetMember(&domain.WorkspaceMember{WorkspaceID: id, UserID: f.outsider.UserID, Role: domain.RoleViewer}, f.admin))
+
+	// Only owners grant or take away the owner role
+	assert.ErrorIs(t, f.workspaces.SetMember(&domain.WorkspaceMember{WorkspaceID: id, UserID: f.admin.UserID, Role: domain.RoleOwner}, f.admin), domain.ErrForbidden)
+	assert.ErrorIs(t, f.workspaces.RemoveMember(id, f.owner.UserID, f.admin), domain.ErrForbidden)
+
+	// The last owner stays until someone else owns the workspace
+	assert.ErrorIs(t, f.workspaces.SetMember(&domain.WorkspaceMember{WorkspaceID: id, UserID: f.owner.UserID, Role: domain.RoleAdmin}, f.owner), domain.ErrLastOwner)
+	assert.ErrorIs(t, f.workspaces.RemoveMember(id, f.owner.UserID, f.owner), domain.ErrLastOwner)
+	assert.NoError(t, f.workspaces.SetMember(&domain.WorkspaceMember{WorkspaceID: id, UserID: f.admin.UserID, Role: domain.RoleOwner}, f.owner))
+	assert.NoError(t, f.workspaces.RemoveMember(id, f.owner.UserID, f.admin))
+
+	assert.ErrorIs(t, f.workspaces.RemoveMember(id, f.owner.UserID, f.admin), domain.ErrNotMember)
+	// Creating the workspace gives no rights once the membership is gone
+	assert.ErrorIs(t, f.workspaces.SetWorkflow(id, nil, f.owner), domain.ErrForbidden)
+	assert.ErrorIs(t, f.workspaces.SetMember(&domain.WorkspaceMember{WorkspaceID: id, UserID: f.owner.UserID, Role: domain.RoleOwner}, f.owner), domain.ErrForbidden)
+	_, err = f.users.GetUserByID(f.owner.UserID, f.member)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	assert.NoError(t, f.workspaces.RemoveMember(id, f.outsider.UserID, f.superadmin))
+	_, err = f.workspaces.GetWorkspaceByID(id, f.outsider)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	assert.ErrorIs(t, f.workspaces.SetWorkflow(id, nil, f.member), domain.ErrForbidden)
+	assert.NoError(t, f.workspaces.SetWorkflow(id, nil, f.admin))
+}
+
+func TestUserAccess(t *testing.T) {
+	f := setupAccessFixture(t)
+
+	_, err := f.users.GetUserByID(f.viewer.UserID, f.member)
+	assert.NoError(t, err, "members of a workspace see each other")
+	_, err = f.users.GetUserByID(f.outsider.UserID, f.member)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	_, err = f.users.GetUserByID(f.member.UserID, f.outsider)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+
+	users, err := f.users.GetAllUsers(f.outsider)
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	users, err = f.users.GetAllUsers(f.superadmin)
+	assert.NoError(t, err)
+	assert.Len(t, users, 6)
+
+	// Workspace roles give no rights over accounts; only their owner or a superadmin edits them
+	viewer, err := f.users.GetUserByID(f.viewer.UserID, f.owner)
+	assert.NoError(t, err)
+	viewer.Name = "Renamed"
+	assert.ErrorIs(t, f.users.UpdateUser(viewer, f.owner), domain.ErrForbidden)
+	assert.ErrorIs(t, f.users.DeleteUser(f.viewer.UserID, f.owner), domain.ErrForbidden)
+
+	viewer.Superadmin = true
+	assert.NoError(t, f.users.UpdateUser(viewer, f.viewer))
+	assert.Equal(t, "Renamed", viewer.Name)
+	assert.False(t, viewer.Superadmin, "users cannot make themselves superadmins")
+	assert.NoError(t, f.users.DeleteUser(f.viewer.UserID, f.superadmin))
+}
+
+func TestPresentErrorForbidden(t *testing.T) {
+	presented := resolvers.PresentError(context.Background(), fmt.Errorf("update task: %w", domain.ErrForbidden))
+	assert.Equal(t, "FORBIDDEN", presented.Extensions["code"])
+
+	presented = resolvers.PresentError(context.Background(), domain.ErrCommentForbidden)
+	assert.Equal(t, "FORBIDDEN", presented.Extensions["code"])
+
+	presented = resolvers.PresentError(context.Background(), errors.New("boom"))
+	assert.Nil(t, presented.Extensions["code"])
+}
@@ -0,0 +1,114 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+	"task-manager-app/backend/internal/infrastructure"
+	"task-manager-app/backend/internal/tests"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeS3 is a minimal S3 stand-in keeping objects in memory and checking requests are signed.
+func fakeS3(t *testing.T) *httptest.Server {
+	var mu sync.Mutex
+	objects := map[string][]byte{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=test-key/") || !strings.Contains(auth, "/us-east-1/s3/aws4_request") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			objects[r.URL.Path] = body
+		case http.MethodGet:
+			object, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(object))
+		case http.MethodDelete:
+			delete(objects, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+}
+
+func setupAttachmentServices(t *testing.T, blobs domain.BlobStore) (*application.AttachmentService, *domain.Task) {
+	db, err := tests.SetupTestDB()
+	assert.NoError(t, err)
+	policy := newAccessPolicy(db)
+
+	taskRepo := infrastructure.NewTaskRepository(db)
+	task := &domain.Task{Title: "With files", UserID: 1}
+	assert.NoError(t, application.NewTaskService(taskRepo, infrastructure.NewWorkspaceRepository(db), infrastructure.NewActivityRepository(db), policy).CreateTask(task, domain.SystemActor()))
+	limits := application.AttachmentLimits{MaxSize: 1 << 20, AllowedTypes: []string{"image/", "text/plain"}}
+	return application.NewAttachmentService(infrastructure.NewAttachmentRepository(db), taskRepo, blobs, limits, policy), task
+}
+
+func TestAttachmentStores(t *testing.T) {
+	local, err := infrastructure.NewLocalBlobStore(t.TempDir())
+	assert.NoError(t, err)
+	server := fakeS3(t)
+	defer server.Close()
+	s3 := infrastructure.NewS3BlobStore(infrastructure.S3Config{
+		Endpoint: server.URL, Bucket: "attachments", AccessKey: "test-key", SecretKey: "secret",
+	}, server.Client())
+
+	for name, blobs := range map[string]domain.BlobStore{"local": local, "s3": s3} {
+		t.Run(name, func(t *testing.T) {
+			service, task := setupAttachmentServices(t, blobs)
+			ctx := context.Background()
+			content := "hello attachments, this is plain text"
+			owner := domain.Actor{UserID: task.UserID, Source: domain.SourceREST}
+
+			attachment, err := service.Upload(ctx, task.ID, "../../notes.txt", strings.NewReader(content), int64(len(content)), owner)
+			assert.NoError(t, err)
+			assert.Equal(t, "notes.txt", attachment.Filename)
+			assert.Equal(t, "text/plain; charset=utf-8", attachment.ContentType)
+			sum := sha256.Sum256([]byte(content))
+			assert.Equal(t, hex.EncodeToString(sum[:]), attachment.Checksum)
+
+			_, reader, err := service.Open(ctx, attachment.ID, owner)
+			assert.NoError(t, err)
+			_, err = reader.Seek(6, io.SeekStart)
+			assert.NoError(t, err)
+			part := make([]byte, 11)
+			_, err = io.ReadFull(reader, part)
+			assert.NoError(t, err)
+			assert.Equal(t, "attachments", string(part))
+			assert.NoError(t, reader.Close())
+
+			_, err = service.Upload(ctx, task.ID, "big.txt", strings.NewReader(content), 2<<20, owner)
+			assert.ErrorIs(t, err, domain.ErrAttachmentTooLarge)
+			_, err = service.Upload(ctx, task.ID, "page.html", strings.NewReader("<html><body>x</body></html>"), 27, owner)
+			assert.ErrorIs(t, err, domain.ErrAttachmentType)
+			_, err = service.Upload(ctx, task.ID, "short.txt", strings.NewReader("short"), 10, owner)
+			assert.Error(t, err)
+
+			stranger := domain.Actor{UserID: 99, Source: domain.SourceREST}
+			_, _, err = service.Open(ctx, attachment.ID, stranger)
+			assert.ErrorIs(t, err, domain.ErrForbidden)
+			assert.ErrorIs(t, service.DeleteAttachment(ctx, attachment.ID, stranger), domain.ErrForbidden)
+			assert.NoError(t, service.DeleteAttachment(ctx, attachment.ID, owner))
+			_, err = blobs.Get(ctx, attachment.StorageKey, 0, -1)
+			assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package unit
+
+import (
+	"strconv"
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+	"task-manager-app/backend/internal/infrastructure"
+	"task-manager-app/backend/internal/tests"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupBoardServices(t *testing.T) (*application.BoardService, *application.TaskService, *domain.Project) {
+	db, err := tests.SetupTestDB()
+	assert.NoError(t, err)
+	policy := newAccessPolicy(db)
+
+	taskRepo := infrastructure.NewTaskRepository(db)
+	projectRepo := infrastructure.NewProjectRepository(db)
+	tasks := application.NewTaskService(taskRepo, infrastructure.NewWorkspaceRepository(db), infrastructure.NewActivityRepository(db), policy)
+	project := &domain.Project{Name: "Website", OwnerID: 1}
+	assert.NoError(t, projectRepo.Create(project))
+	return application.NewBoardService(infrastructure.NewBoardRepository(db), projectRepo, taskRepo, tasks, policy), tasks, project
+}
+
+func laneTitles(lane domain.BoardLane) []string {
+	var titles []string
+	for _, edge := range lane.Tasks.Edges {
+		titles = append(titles, edge.Node.Title)
+	}
+	return titles
+}
+
+func TestRankBetween(t *testing.T) {
+	rank, err := domain.RankBetween("", "")
+	assert.NoError(t, err)
+	_, err = domain.RankBetween("b", "a")
+	assert.ErrorIs(t, err, domain.ErrInvalidRank)
+
+	// Repeatedly inserting at the same spot keeps producing ranks in between
+	lower, upper := "", rank
+	for i := 0; i < 200; i++ {
+		mid, err := domain.RankBetween(lower, upper)
+		assert.NoError(t, err)
+		assert.True(t, lower < mid && mid < upper, "%q < %q < %q", lower, mid, upper)
+		if i%2 == 0 {
+			lower = mid
+		} else {
+			upper = mid
+		}
+	}
+
+	ranks := domain.SpreadRanks(100)
+	for i := 1; i < len(ranks); i++ {
+		assert.Less(t, ranks[i-1], ranks[i])
+	}
+}
+
+func TestBoardByStatus(t *testing.T) {
+	boards, tasks, project := setupBoardServices(t)
+	actor := domain.Actor{UserID: 1, Source: domain.SourceREST}
+
+	var created []*domain.Task
+	for _, title := range []string{"A", "B", "C"} {
+		task := &domain.Task{Title: title, UserID: 1, ProjectID: project.ID}
+		assert.NoError(t, tasks.CreateTask(task, domain.SystemActor()))
+		created = append(created, task)
+	}
+	a, b, c := created[0], created[1], created[2]
+
+	board, err := boards.GetBoard(project.ID, 50, actor)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.GroupByStatus, board.GroupBy)
+	assert.Len(t, board.Columns, len(domain.TaskStatuses))
+	assert.Equal(t, "In progress", board.Columns[1].Name)
+	assert.Equal(t, []string{"A", "B", "C"}, laneTitles(board.Columns[0]), "unranked tasks keep their creation order")
+
+	// Moving C above A ranks the column once and then only touches C
+	moved, err := boards.MoveTask(c.ID, "todo", 0, a.ID, actor)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, moved.Rank)
+	board, err = boards.GetBoard(project.ID, 50, actor)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"C", "A", "B"}, laneTitles(board.Columns[0]))
+	rankOfB := board.Columns[0].Tasks.Edges[2].Node.Rank
+
+	_, err = boards.MoveTask(b.ID, "todo", c.ID, a.ID, actor)
+	assert.NoError(t, err)
+	board, err = boards.GetBoard(project.ID, 50, actor)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"C", "B", "A"}, laneTitles(board.Columns[0]))
+	assert.NotEqual(t, rankOfB, board.Columns[0].Tasks.Edges[1].Node.Rank)
+
+	_, err = boards.MoveTask(a.ID, "todo", c.ID, c.ID, actor)
+	assert.ErrorIs(t, err, domain.ErrInvalidMove, "neighbours must be adjacent")
+	_, err = boards.MoveTask(a.ID, "todo", b.ID, 0, actor)
+	assert.NoError(t, err)
+
+	// Moving to another status column changes the status under the workflow
+	moved, err = boards.MoveTask(b.ID, "in_progress", 0, 0, actor)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusInProgress, moved.Status)
+	_, err = boards.MoveTask(c.ID, "in_review", 0, 0, actor)
+	var transitionErr *domain.InvalidTransitionError
+	assert.ErrorAs(t, err, &transitionErr)
+	_, err = boards.MoveTask(c.ID, "in_progress", b.ID, 0, actor)
+	assert.NoError(t, err)
+	_, err = boards.MoveTask(a.ID, "in_progress", c.ID, 0, actor)
+	assert.NoError(t, err)
+	_, err = boards.MoveTask(a.ID, "backlog", 0, 0, actor)
+	assert.ErrorIs(t, err, domain.ErrUnknownColumn)
+
+	board, err = boards.GetBoard(project.ID, 2, actor)
+	assert.NoError(t, err)
+	assert.Empty(t, laneTitles(board.Columns[0]))
+	assert.Equal(t, []string{"B", "C"}, laneTitles(board.Columns[1]), "columns are paginated")
+	assert.Equal(t, 3, board.Columns[1].Tasks.PageInfo.TotalCount)
+
+	// Editing a task does not lose its place on the board
+	moved.Title = "B renamed"
+	assert.NoError(t, tasks.UpdateTask(moved, actor))
+	board, err = boards.GetBoard(project.ID, 50, actor)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"B renamed", "C", "A"}, laneTitles(board.Columns[1]))
+
+	loose := &domain.Task{Title: "No project", UserID: 1}
+	assert.NoError(t, tasks.CreateTask(loose, domain.SystemActor()))
+	_, err = boards.MoveTask(loose.ID, "todo", 0, 0, actor)
+	assert.ErrorIs(t, err, domain.ErrNoBoard)
+}
+
+func TestBoardCustomColumns(t *testing.T) {
+	boards, tasks, project := setupBoardServices(t)
+	actor := domain.Actor{UserID: 1, Source: domain.SourceGraphQL}
+
+	_, err := boards.SetColumns(project.ID, []domain.BoardColumn{{Name: "Ideas"}, {Name: " ideas "}}, actor)
+	assert.ErrorIs(t, err, domain.ErrInvalidBoardColumns)
+	columns, err := boards.SetColumns(project.ID, []domain.BoardColumn{{Name: "Ideas"}, {Name: "Doing"}, {Name: "Shipped"}}, actor)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, []int{columns[0].Position, columns[1].Position, columns[2].Position})
+	ideas, doing, shipped := columns[0], columns[1], columns[2]
+
+	first := &domain.Task{Title: "First", UserID: 1, ProjectID: project.ID}
+	second := &domain.Task{Title: "Second", UserID: 1, ProjectID: project.ID}
+	assert.NoError(t, tasks.CreateTask(first, domain.SystemActor()))
+	assert.NoError(t, tasks.CreateTask(second, domain.SystemActor()))
+
+	board, err := boards.GetBoard(project.ID, 50, actor)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.GroupByCustom, board.GroupBy)
+	assert.Equal(t, strconv.Itoa(ideas.ID), board.Columns[0].Key)
+	assert.Equal(t, []string{"First", "Second"}, laneTitles(board.Columns[0]), "tasks start in the first column")
+
+	_, err = boards.MoveTask(first.ID, "todo", 0, 0, actor)
+	assert.ErrorIs(t, err, domain.ErrUnknownColumn, "status columns are replaced by the custom ones")
+	moved, err := boards.MoveTask(first.ID, strconv.Itoa(shipped.ID), 0, 0, actor)
+	assert.NoError(t, err)
+	assert.Equal(t, shipped.ID, moved.ColumnID)
+	assert.Equal(t, domain.StatusTodo, moved.Status, "custom columns leave the status alone")
+	_, err = boards.MoveTask(second.ID, strconv.Itoa(doing.ID), 0, first.ID, actor)
+	assert.ErrorIs(t, err, domain.ErrInvalidMove, "neighbours must be in the target column")
+
+	// Dropping a column sends its tasks back to the first one
+	columns, err = boards.SetColumns(project.ID, []domain.BoardColumn{{ID: doing.ID, Name: "In flight"}, {ID: ideas.ID, Name: "Ideas"}}, actor)
+	assert.NoError(t, err)
+	assert.Equal(t, "In flight", columns[0].Name)
+	board, err = boards.GetBoard(project.ID, 50, actor)
+	assert.NoError(t, err)
+	assert.Empty(t, laneTitles(board.Columns[1]))
+	assert.ElementsMatch(t, []string{"First", "Second"}, laneTitles(board.Columns[0]))
+	history, err := tasks.GetHistory(domain.ActivityFilter{TaskID: first.ID}, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.FieldChange{{Field: "columnId", Old: strconv.Itoa(shipped.ID), New: ""}}, history.Edges[0].Node.Changes)
+
+	_, err = boards.SetColumns(project.ID, []domain.BoardColumn{{ID: shipped.ID, Name: "Back"}}, actor)
+	assert.ErrorIs(t, err, domain.ErrUnknownColumn)
+	_, err = boards.SetColumns(project.ID, nil, actor)
+	assert.NoError(t, err)
+	board, err = boards.GetBoard(project.ID, 50, actor)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.GroupByStatus, board.GroupBy)
+}
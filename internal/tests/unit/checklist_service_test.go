@@ -0,0 +1,84 @@
+package unit
+
+import (
+	"encoding/json"
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+	"task-manager-app/backend/internal/infrastructure"
+	"task-manager-app/backend/internal/tests"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecklist(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	assert.NoError(t, err)
+	policy := newAccessPolicy(db)
+	taskRepo := infrastructure.NewTaskRepository(db)
+	service := application.NewChecklistService(infrastructure.NewChecklistRepository(db), taskRepo, policy)
+
+	task := &domain.Task{Title: "Release", UserID: 1}
+	assert.NoError(t, taskRepo.Create(task))
+	other := &domain.Task{Title: "Other", UserID: 1}
+	assert.NoError(t, taskRepo.Create(other))
+	owner := domain.Actor{UserID: 1, Source: domain.SourceREST}
+	stranger := domain.Actor{UserID: 7, Source: domain.SourceREST}
+
+	_, err = service.AddItem(task.ID, "  ", owner)
+	assert.ErrorIs(t, err, domain.ErrEmptyChecklistItem)
+	tag, err := service.AddItem(task.ID, "Tag the release", owner)
+	assert.NoError(t, err)
+	notes, err := service.AddItem(task.ID, "Write release notes", owner)
+	assert.NoError(t, err)
+	announce, err := service.AddItem(task.ID, "Announce it", owner)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, []int{tag.Position, notes.Position, announce.Position})
+	foreign, err := service.AddItem(other.ID, "Unrelated", owner)
+	assert.NoError(t, err)
+
+	_, err = service.ToggleItem(notes.ID, stranger)
+	assert.ErrorIs(t, err, domain.ErrForbidden, "only users who can edit the task tick its items")
+	checked, err := service.ToggleItem(notes.ID, owner)
+	assert.NoError(t, err)
+	assert.True(t, checked.Checked)
+	assert.Equal(t, 1, checked.CheckedBy)
+	assert.NotNil(t, checked.CheckedAt)
+
+	_, err = service.ReorderChecklist(task.ID, []int{announce.ID, tag.ID}, owner)
+	assert.ErrorIs(t, err, domain.ErrChecklistOrder, "every item must be listed")
+	_, err = service.ReorderChecklist(task.ID, []int{announce.ID, tag.ID, foreign.ID}, owner)
+	assert.ErrorIs(t, err, domain.ErrChecklistOrder, "items of other tasks are rejected")
+	items, err := service.ReorderChecklist(task.ID, []int{notes.ID, tag.ID, announce.ID}, owner)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Write release notes", "Tag the release", "Announce it"}, checklistTexts(items))
+
+	// Listings carry the checklist and its counter without extra queries per task
+	tasks, err := taskRepo.FindAll(domain.TaskFilter{Search: "Release"})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ChecklistProgress{Done: 1, Total: 3}, tasks.Edges[0].Node.ChecklistProgress())
+	assert.Equal(t, "Write release notes", tasks.Edges[0].Node.Checklist[0].Text)
+	body, err := json.Marshal(tasks.Edges[0].Node)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"checklistProgress":{"done":1,"total":3}`)
+
+	unchecked, err := service.ToggleItem(notes.ID, owner)
+	assert.NoError(t, err)
+	assert.False(t, unchecked.Checked)
+	assert.Zero(t, unchecked.CheckedBy)
+	assert.Nil(t, unchecked.CheckedAt)
+
+	assert.NoError(t, service.DeleteItem(tag.ID, owner))
+	items, err = service.GetChecklist(task.ID, owner)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Write release notes", "Announce it"}, checklistTexts(items))
+	assert.Error(t, service.DeleteItem(tag.ID, owner))
+}
+
+func checklistTexts(items []domain.ChecklistItem) []string {
+	texts := make([]string, len(items))
+	for i, item := range items {
+		texts[i] = item.Text
+	}
+	return texts
+}
@@ -0,0 +1,118 @@
+package unit
+
+import (
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+	"task-manager-app/backend/internal/infrastructure"
+	"task-manager-app/backend/internal/tests"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupCommentServices(t *testing.T) (*application.CommentService, *application.TaskService, domain.UserRepository) {
+	db, err := tests.SetupTestDB()
+	assert.NoError(t, err)
+	policy := newAccessPolicy(db)
+
+	taskRepo := infrastructure.NewTaskRepository(db)
+	userRepo := infrastructure.NewUserRepository(db)
+	return application.NewCommentService(infrastructure.NewCommentRepository(db), taskRepo, userRepo, policy),
+		application.NewTaskService(taskRepo, infrastructure.NewWorkspaceRepository(db), infrastructure.NewActivityRepository(db), policy),
+		userRepo
+}
+
+func TestParseMentions(t *testing.T) {
+	mentions := domain.ParseMentions("Ping @Ana@Example.com and @bob@example.org. Again @ana@example.com, not me@example.com")
+	assert.Equal(t, []string{"ana@example.com", "bob@example.org"}, mentions)
+}
+
+func TestCommentThreadsAndMentions(t *testing.T) {
+	comments, tasks, users := setupCommentServices(t)
+
+	owner := &domain.User{Email: "owner@example.com", Name: "Owner"}
+	ana := &domain.User{Email: "ana@example.com", Name: "Ana"}
+	assert.NoError(t, users.Create(owner))
+	assert.NoError(t, users.Create(ana))
+	assert.NoError(t, users.Create(&domain.User{Email: "bob@example.com", Name: "Bob"}))
+	task := &domain.Task{Title: "Discuss", UserID: owner.ID}
+	assert.NoError(t, tasks.CreateTask(task, domain.SystemActor()))
+	_, err := tasks.WatchTask(task.ID, ana.ID, domain.SystemActor())
+	assert.NoError(t, err)
+	asOwner := domain.Actor{UserID: owner.ID, Source: domain.SourceREST}
+	asAna := domain.Actor{UserID: ana.ID, Source: domain.SourceREST}
+
+	root, err := comments.AddComment(task.ID, 0, "What do you think @ana@example.com? cc @ghost@example.com @bob@example.com", asOwner)
+	assert.NoError(t, err)
+	assert.Len(t, root.Mentions, 1, "unknown users and users who cannot see the task are not mentioned")
+	assert.Equal(t, ana.ID, root.Mentions[0].UserID)
+
+	reply, err := comments.AddComment(task.ID, root.ID, "Looks good", asAna)
+	assert.NoError(t, err)
+	nested, err := comments.AddComment(task.ID, reply.ID, "Thanks", asOwner)
+	assert.NoError(t, err)
+	assert.Equal(t, root.ID, nested.ParentID)
+
+	_, err = comments.AddComment(task.ID, 0, "   ", asOwner)
+	assert.ErrorIs(t, err, domain.ErrEmptyComment)
+
+	for i := 0; i < 2; i++ {
+		_, err = comments.AddComment(task.ID, 0, "More", asAna)
+		assert.NoError(t, err)
+	}
+	page, err := comments.GetComments(domain.CommentFilter{TaskID: task.ID, Page: 1, Limit: 2}, asAna)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, page.PageInfo.TotalCount)
+	assert.True(t, page.PageInfo.HasNextPage)
+	assert.Len(t, page.Edges, 2)
+	assert.Len(t, page.Edges[0].Node.Replies, 2)
+}
+
+func TestCommentEditPermissionsAndHistory(t *testing.T) {
+	comments, tasks, users := setupCommentServices(t)
+
+	owner := &domain.User{Email: "owner@example.com"}
+	author := &domain.User{Email: "author@example.com"}
+	watcher := &domain.User{Email: "watcher@example.com"}
+	stranger := &domain.User{Email: "stranger@example.com"}
+	for _, user := range []*domain.User{owner, author, watcher, stranger} {
+		assert.NoError(t, users.Create(user))
+	}
+	task := &domain.Task{Title: "Review", UserID: owner.ID}
+	assert.NoError(t, tasks.CreateTask(task, domain.SystemActor()))
+	for _, user := range []*domain.User{author, watcher} {
+		_, err := tasks.WatchTask(task.ID, user.ID, domain.SystemActor())
+		assert.NoError(t, err)
+	}
+	asOwner := domain.Actor{UserID: owner.ID, Source: domain.SourceREST}
+	asAuthor := domain.Actor{UserID: author.ID, Source: domain.SourceREST}
+	asWatcher := domain.Actor{UserID: watcher.ID, Source: domain.SourceREST}
+	asStranger := domain.Actor{UserID: stranger.ID, Source: domain.SourceREST}
+	comment, err := comments.AddComment(task.ID, 0, "First draft", asAuthor)
+	assert.NoError(t, err)
+
+	_, err = comments.UpdateComment(comment.ID, "Hijacked", asWatcher)
+	assert.ErrorIs(t, err, domain.ErrCommentForbidden)
+	_, err = comments.UpdateComment(comment.ID, "Hijacked", asStranger)
+	assert.ErrorIs(t, err, domain.ErrForbidden, "users who cannot see the task cannot see its comments")
+	_, err = comments.GetComment(comment.ID, asStranger)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+
+	edited, err := comments.UpdateComment(comment.ID, "Second draft for @owner@example.com", asAuthor)
+	assert.NoError(t, err)
+	assert.NotNil(t, edited.EditedAt)
+	assert.Len(t, edited.Mentions, 1)
+	_, err = comments.UpdateComment(comment.ID, "Final", asOwner)
+	assert.NoError(t, err)
+
+	history, err := comments.GetHistory(comment.ID, asAuthor)
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, "First draft", history[0].Body)
+	assert.Equal(t, owner.ID, history[1].EditedBy)
+
+	assert.ErrorIs(t, comments.DeleteComment(comment.ID, asWatcher), domain.ErrCommentForbidden)
+	assert.NoError(t, comments.DeleteComment(comment.ID, asOwner))
+	_, err = comments.GetComment(comment.ID, asOwner)
+	assert.Error(t, err)
+}
@@ -0,0 +1,153 @@
+package unit
+
+import (
+	"strconv"
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+	"task-manager-app/backend/internal/infrastructure"
+	"task-manager-app/backend/internal/tests"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomFieldDefinitions(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	assert.NoError(t, err)
+	policy := newAccessPolicy(db)
+	service := application.NewCustomFieldService(infrastructure.NewCustomFieldRepository(db),
+		infrastructure.NewTaskRepository(db), infrastructure.NewUserRepository(db), policy)
+	system := domain.SystemActor()
+
+	outsider := domain.Actor{UserID: 5, Source: domain.SourceREST}
+	assert.ErrorIs(t, service.CreateField(&domain.CustomField{WorkspaceID: 1, Name: "Size", Type: domain.FieldText}, outsider), domain.ErrForbidden)
+	assert.ErrorIs(t, service.CreateField(&domain.CustomField{WorkspaceID: 1, Name: "Size", Type: "color"}, system), domain.ErrInvalidCustomField)
+	assert.ErrorIs(t, service.CreateField(&domain.CustomField{WorkspaceID: 1, Name: "Size", Type: domain.FieldSelect}, system), domain.ErrCustomFieldOptions)
+	assert.ErrorIs(t, service.CreateField(&domain.CustomField{WorkspaceID: 1, Name: "Size", Type: domain.FieldSelect, Options: []string{"S", "S"}}, system),
+		domain.ErrCustomFieldOptions)
+
+	points := &domain.CustomField{WorkspaceID: 1, Name: "Story points", Type: domain.FieldNumber, Options: []string{"ignored"}}
+	assert.NoError(t, service.CreateField(points, system))
+	assert.Nil(t, points.Options, "only select fields keep options")
+	assert.ErrorIs(t, service.CreateField(&domain.CustomField{WorkspaceID: 1, Name: "story POINTS", Type: domain.FieldText}, system), domain.ErrDuplicateCustomField)
+	assert.NoError(t, service.CreateField(&domain.CustomField{WorkspaceID: 2, Name: "Story points", Type: domain.FieldText}, system))
+
+	points.Name = "Points"
+	points.Type = domain.FieldText
+	assert.NoError(t, service.UpdateField(points, system))
+	_, err = service.GetField(points.ID, outsider)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	stored, err := service.GetField(points.ID, system)
+	assert.NoError(t, err)
+	assert.Equal(t, "Points", stored.Name)
+	assert.Equal(t, domain.FieldNumber, stored.Type, "the type cannot change")
+}
+
+func TestCustomFieldValues(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	assert.NoError(t, err)
+	policy := newAccessPolicy(db)
+	taskRepo := infrastructure.NewTaskRepository(db)
+	userRepo := infrastructure.NewUserRepository(db)
+	service := application.NewCustomFieldService(infrastructure.NewCustomFieldRepository(db), taskRepo, userRepo, policy)
+	system := domain.SystemActor()
+
+	user := &domain.User{Email: "reviewer@example.com", Name: "Reviewer"}
+	assert.NoError(t, userRepo.Create(user))
+	points := &domain.CustomField{WorkspaceID: 1, Name: "Points", Type: domain.FieldNumber}
+	assert.NoError(t, service.CreateField(points, system))
+	tags := &domain.CustomField{WorkspaceID: 1, Name: "Platforms", Type: domain.FieldMultiSelect, Options: []string{"web", "ios", "android"}}
+	assert.NoError(t, service.CreateField(tags, system))
+	launch := &domain.CustomField{WorkspaceID: 1, Name: "Launch", Type: domain.FieldDate}
+	assert.NoError(t, service.CreateField(launch, system))
+	reviewer := &domain.CustomField{WorkspaceID: 1, Name: "Reviewer", Type: domain.FieldUser}
+	assert.NoError(t, service.CreateField(reviewer, system))
+	foreign := &domain.CustomField{WorkspaceID: 2, Name: "Points", Type: domain.FieldNumber}
+	assert.NoError(t, service.CreateField(foreign, system))
+
+	small := &domain.Task{Title: "Small", UserID: 1, WorkspaceID: 1}
+	big := &domain.Task{Title: "Big", UserID: 1, WorkspaceID: 1}
+	unsized := &domain.Task{Title: "Unsized", UserID: 1, WorkspaceID: 1}
+	for _, task := range []*domain.Task{small, big, unsized} {
+		assert.NoError(t, taskRepo.Create(task))
+	}
+	number := func(n float64) *float64 { return &n }
+
+	_, err = service.SetValue(small.ID, foreign.ID, domain.CustomFieldValue{Number: number(1)}, system)
+	assert.ErrorIs(t, err, domain.ErrCustomFieldWorkspace)
+	_, err = service.SetValue(small.ID, points.ID, domain.CustomFieldValue{Text: "three"}, system)
+	assert.ErrorIs(t, err, domain.ErrInvalidFieldValue)
+	_, err = service.SetValue(small.ID, tags.ID, domain.CustomFieldValue{Options: []string{"web", "desktop"}}, system)
+	assert.ErrorIs(t, err, domain.ErrInvalidFieldValue)
+	_, err = service.SetValue(small.ID, reviewer.ID, domain.CustomFieldValue{UserID: 999}, system)
+	assert.ErrorIs(t, err, domain.ErrInvalidFieldValue)
+
+	_, err = service.SetValue(small.ID, points.ID, domain.CustomFieldValue{Number: number(2), Text: "dropped"}, system)
+	assert.NoError(t, err)
+	_, err = service.SetValue(big.ID, points.ID, domain.CustomFieldValue{Number: number(8)}, system)
+	assert.NoError(t, err)
+	task, err := service.SetValue(big.ID, points.ID, domain.CustomFieldValue{Number: number(13)}, system)
+	assert.NoError(t, err)
+	assert.Len(t, task.CustomFields, 1, "setting a value again replaces it")
+	assert.Equal(t, 13.0, *task.CustomFields[0].Number)
+	assert.Empty(t, task.CustomFields[0].Text)
+	_, err = service.SetValue(big.ID, tags.ID, domain.CustomFieldValue{Options: []string{"ios", "web"}}, system)
+	assert.NoError(t, err)
+	_, err = service.SetValue(small.ID, tags.ID, domain.CustomFieldValue{Options: []string{"android"}}, system)
+	assert.NoError(t, err)
+	day := time.Date(2026, 3, 14, 15, 30, 0, 0, time.UTC)
+	task, err = service.SetValue(small.ID, launch.ID, domain.CustomFieldValue{Date: &day}, system)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC), task.CustomFields[len(task.CustomFields)-1].Date.UTC())
+	_, err = service.SetValue(big.ID, reviewer.ID, domain.CustomFieldValue{UserID: user.ID}, system)
+	assert.ErrorIs(t, err, domain.ErrInvalidFieldValue, "user values only point at members of the workspace")
+	assert.NoError(t, infrastructure.NewWorkspaceRepository(db).SaveMember(&domain.WorkspaceMember{WorkspaceID: 1, UserID: user.ID, Role: domain.RoleViewer}))
+	_, err = service.SetValue(big.ID, reviewer.ID, domain.CustomFieldValue{UserID: user.ID}, system)
+	assert.NoError(t, err)
+
+	titles := func(filter domain.TaskFilter) []string {
+		tasks, err := taskRepo.FindAll(filter)
+		assert.NoError(t, err)
+		var result []string
+		for _, edge := range tasks.Edges {
+			result = append(result, edge.Node.Title)
+		}
+		return result
+	}
+	assert.Equal(t, []string{"Big"}, titles(domain.TaskFilter{CustomFields: []domain.CustomFieldFilter{{FieldID: points.ID, Min: "5"}}}))
+	assert.Equal(t, []string{"Small"}, titles(domain.TaskFilter{CustomFields: []domain.CustomFieldFilter{{FieldID: points.ID, Min: "1", Max: "5"}}}))
+	assert.Equal(t, []string{"Big"}, titles(domain.TaskFilter{CustomFields: []domain.CustomFieldFilter{{FieldID: tags.ID, Equals: "web"}}}))
+	assert.Equal(t, []string{"Small"}, titles(domain.TaskFilter{CustomFields: []domain.CustomFieldFilter{{FieldID: launch.ID, Equals: "2026-03-14"}}}))
+	assert.Equal(t, []string{"Big"}, titles(domain.TaskFilter{CustomFields: []domain.CustomFieldFilter{{FieldID: reviewer.ID, Equals: strconv.Itoa(user.ID)}}}))
+	assert.Empty(t, titles(domain.TaskFilter{CustomFields: []domain.CustomFieldFilter{{FieldID: tags.ID, Equals: "w_b"}}}), "options match literally")
+	assert.Empty(t, titles(domain.TaskFilter{CustomFields: []domain.CustomFieldFilter{{FieldID: tags.ID, Equals: "%"}}}))
+	_, err = taskRepo.FindAll(domain.TaskFilter{CustomFields: []domain.CustomFieldFilter{{FieldID: points.ID, Equals: "many"}}})
+	assert.ErrorIs(t, err, domain.ErrInvalidFieldFilter)
+
+	// Fields of workspaces the user is not in cannot be filtered or sorted by
+	outside := &domain.TaskAccess{UserID: 2, WorkspaceIDs: []int{2}}
+	_, err = taskRepo.FindAll(domain.TaskFilter{Access: outside, CustomFields: []domain.CustomFieldFilter{{FieldID: points.ID, Min: "5"}}})
+	assert.ErrorIs(t, err, domain.ErrInvalidFieldFilter)
+	_, err = taskRepo.FindAll(domain.TaskFilter{Access: outside, Sort: []domain.TaskSort{{Field: domain.SortByCustomField, FieldID: points.ID}}})
+	assert.ErrorIs(t, err, domain.ErrInvalidSort)
+	_, err = taskRepo.FindAll(domain.TaskFilter{Access: outside, CustomFields: []domain.CustomFieldFilter{{FieldID: foreign.ID, Min: "5"}}})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"Small", "Big", "Unsized"}, titles(domain.TaskFilter{Sort: []domain.TaskSort{{Field: domain.SortByCustomField, FieldID: points.ID}}}))
+	assert.Equal(t, []string{"Big", "Small", "Unsized"}, titles(domain.TaskFilter{Sort: []domain.TaskSort{{Field: domain.SortByCustomField, FieldID: points.ID, Desc: true}}}),
+		"tasks without a value stay last in both directions")
+	sorts, err := domain.ParseTaskSort("-cf:3,title")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.TaskSort{Field: domain.SortByCustomField, FieldID: 3, Desc: true}, sorts[0])
+	_, err = domain.ParseTaskSort("customField")
+	assert.ErrorIs(t, err, domain.ErrInvalidSort)
+
+	task, err = service.ClearValue(big.ID, points.ID, system)
+	assert.NoError(t, err)
+	assert.Len(t, task.CustomFields, 2)
+	assert.NoError(t, service.DeleteField(tags.ID, system))
+	task, err = taskRepo.FindByID(big.ID)
+	assert.NoError(t, err)
+	assert.Len(t, task.CustomFields, 1, "deleting a field removes its values")
+}
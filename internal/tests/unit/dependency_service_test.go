@@ -0,0 +1,102 @@
+package unit
+
+import (
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+	"task-manager-app/backend/internal/infrastructure"
+	"task-manager-app/backend/internal/tests"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupDependencyServices(t *testing.T) (*application.DependencyService, *application.TaskService, *application.ProjectService) {
+	db, err := tests.SetupTestDB()
+	assert.NoError(t, err)
+	policy := newAccessPolicy(db)
+
+	taskRepo := infrastructure.NewTaskRepository(db)
+	projectRepo := infrastructure.NewProjectRepository(db)
+	return application.NewDependencyService(infrastructure.NewDependencyRepository(db), taskRepo, projectRepo, policy),
+		application.NewTaskService(taskRepo, infrastructure.NewWorkspaceRepository(db), infrastructure.NewActivityRepository(db), policy),
+		application.NewProjectService(projectRepo, taskRepo, policy)
+}
+
+func TestDependenciesBlockCompletion(t *testing.T) {
+	dependencies, tasks, _ := setupDependencyServices(t)
+	actor := domain.Actor{UserID: 1, Source: domain.SourceREST}
+
+	design := &domain.Task{Title: "Design", UserID: 1}
+	build := &domain.Task{Title: "Build", UserID: 1}
+	ship := &domain.Task{Title: "Ship", UserID: 1}
+	for _, task := range []*domain.Task{design, build, ship} {
+		assert.NoError(t, tasks.CreateTask(task, domain.SystemActor()))
+	}
+
+	_, err := dependencies.AddDependency(build.ID, build.ID, actor)
+	assert.ErrorIs(t, err, domain.ErrSelfDependency)
+	_, err = dependencies.AddDependency(build.ID, design.ID, actor)
+	assert.NoError(t, err)
+	_, err = dependencies.AddDependency(ship.ID, build.ID, actor)
+	assert.NoError(t, err)
+	_, err = dependencies.AddDependency(design.ID, ship.ID, actor)
+	assert.ErrorIs(t, err, domain.ErrDependencyCycle)
+	_, err = dependencies.AddDependency(design.ID, build.ID, domain.Actor{UserID: 2, Source: domain.SourceREST})
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+
+	_, err = tasks.ChangeStatus(build.ID, domain.StatusDone, domain.Actor{UserID: 1}, false)
+	var blocked *domain.BlockedError
+	assert.ErrorAs(t, err, &blocked)
+	assert.Equal(t, []int{design.ID}, blocked.BlockerIDs)
+
+	_, err = tasks.ChangeStatus(design.ID, domain.StatusCancelled, domain.Actor{UserID: 1}, false)
+	assert.NoError(t, err)
+	_, err = tasks.ChangeStatus(build.ID, domain.StatusDone, domain.Actor{UserID: 1}, false)
+	assert.NoError(t, err)
+
+	blockers, err := dependencies.GetBlockers(ship.ID, actor)
+	assert.NoError(t, err)
+	assert.Len(t, blockers, 1)
+	assert.NoError(t, tasks.DeleteTask(build.ID, domain.DeleteReparent, domain.SystemActor()))
+	blockers, err = dependencies.GetBlockers(ship.ID, actor)
+	assert.NoError(t, err)
+	assert.Empty(t, blockers)
+}
+
+func TestProjectDependencyGraphCriticalPath(t *testing.T) {
+	dependencies, tasks, projects := setupDependencyServices(t)
+	actor := domain.Actor{UserID: 1, Source: domain.SourceREST}
+
+	project := &domain.Project{Name: "Launch", OwnerID: 1}
+	assert.NoError(t, projects.CreateProject(project, actor))
+
+	spec := &domain.Task{Title: "Spec", UserID: 1, ProjectID: project.ID, EstimateMinutes: 60}
+	backend := &domain.Task{Title: "Backend", UserID: 1, ProjectID: project.ID, EstimateMinutes: 240}
+	frontend := &domain.Task{Title: "Frontend", UserID: 1, ProjectID: project.ID, EstimateMinutes: 120}
+	release := &domain.Task{Title: "Release", UserID: 1, ProjectID: project.ID, EstimateMinutes: 30}
+	for _, task := range []*domain.Task{spec, backend, frontend, release} {
+		assert.NoError(t, tasks.CreateTask(task, domain.SystemActor()))
+	}
+	for _, edge := range [][2]int{{backend.ID, spec.ID}, {frontend.ID, spec.ID}, {release.ID, backend.ID}, {release.ID, frontend.ID}} {
+		_, err := dependencies.AddDependency(edge[0], edge[1], actor)
+		assert.NoError(t, err)
+	}
+
+	graph, err := dependencies.GetProjectGraph(project.ID, actor)
+	assert.NoError(t, err)
+	assert.Len(t, graph.Nodes, 4)
+	assert.Len(t, graph.Edges, 4)
+
+	var path []string
+	for _, task := range graph.CriticalPath {
+		path = append(path, task.Title)
+	}
+	assert.Equal(t, []string{"Spec", "Backend", "Release"}, path)
+	nodes := map[int]domain.DependencyNode{}
+	for _, node := range graph.Nodes {
+		nodes[node.Task.ID] = node
+		assert.Equal(t, node.Task.ID != frontend.ID, node.Critical)
+	}
+	// Release waits for the longer backend branch: 60 + 240 minutes
+	assert.Equal(t, 300.0, nodes[release.ID].EarliestStart.Sub(nodes[spec.ID].EarliestStart).Minutes())
+}
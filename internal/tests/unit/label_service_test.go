@@ -0,0 +1,80 @@
+package unit
+
+import (
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+	"task-manager-app/backend/internal/infrastructure"
+	"task-manager-app/backend/internal/tests"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupLabelServices(t *testing.T) (*application.LabelService, *application.TaskService) {
+	db, err := tests.SetupTestDB()
+	assert.NoError(t, err)
+	policy := newAccessPolicy(db)
+
+	taskRepo := infrastructure.NewTaskRepository(db)
+	return application.NewLabelService(infrastructure.NewLabelRepository(db), taskRepo, policy),
+		application.NewTaskService(taskRepo, infrastructure.NewWorkspaceRepository(db), infrastructure.NewActivityRepository(db), policy)
+}
+
+func TestCreateLabel(t *testing.T) {
+	labels, _ := setupLabelServices(t)
+	actor := domain.Actor{UserID: 1, Source: domain.SourceREST}
+
+	system := domain.SystemActor()
+
+	label := &domain.Label{Name: "backend", Color: "#1e90ff"}
+	assert.NoError(t, labels.CreateLabel(label, system))
+	assert.NotZero(t, label.ID)
+
+	assert.ErrorIs(t, labels.CreateLabel(&domain.Label{Name: "Backend"}, system), domain.ErrDuplicateLabel)
+	assert.ErrorIs(t, labels.CreateLabel(&domain.Label{Name: "ops", Color: "blue"}, system), domain.ErrInvalidLabel)
+
+	// Labels outside any workspace are shared, so only superadmins manage them
+	assert.ErrorIs(t, labels.CreateLabel(&domain.Label{Name: "ops"}, actor), domain.ErrForbidden)
+	assert.ErrorIs(t, labels.DeleteLabel(label.ID, actor), domain.ErrForbidden)
+	_, err := labels.GetLabelByID(label.ID, actor)
+	assert.NoError(t, err)
+}
+
+func TestFilterTasksByLabels(t *testing.T) {
+	labels, tasks := setupLabelServices(t)
+	actor := domain.Actor{UserID: 1, Source: domain.SourceREST}
+
+	bug := &domain.Label{Name: "bug"}
+	urgent := &domain.Label{Name: "urgent"}
+	assert.NoError(t, labels.CreateLabel(bug, domain.SystemActor()))
+	assert.NoError(t, labels.CreateLabel(urgent, domain.SystemActor()))
+
+	both := &domain.Task{Title: "Both", UserID: 1}
+	onlyBug := &domain.Task{Title: "Only bug", UserID: 1}
+	none := &domain.Task{Title: "None", UserID: 1}
+	for _, task := range []*domain.Task{both, onlyBug, none} {
+		assert.NoError(t, tasks.CreateTask(task, domain.SystemActor()))
+	}
+	_, err := labels.AddLabelToTask(both.ID, bug.ID, actor)
+	assert.NoError(t, err)
+	tagged, err := labels.AddLabelToTask(both.ID, urgent.ID, actor)
+	assert.NoError(t, err)
+	assert.Len(t, tagged.Labels, 2)
+	_, err = labels.AddLabelToTask(onlyBug.ID, bug.ID, actor)
+	assert.NoError(t, err)
+	_, err = labels.AddLabelToTask(none.ID, bug.ID, domain.Actor{UserID: 2, Source: domain.SourceREST})
+	assert.ErrorIs(t, err, domain.ErrForbidden, "tagging a task takes editing rights on it")
+
+	result, err := tasks.GetAllTasks(domain.TaskFilter{LabelsAny: []int{bug.ID, urgent.ID}}, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.PageInfo.TotalCount)
+
+	result, err = tasks.GetAllTasks(domain.TaskFilter{LabelsAll: []int{bug.ID, urgent.ID}}, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.PageInfo.TotalCount)
+	assert.Equal(t, "Both", result.Edges[0].Node.Title)
+
+	untagged, err := labels.RemoveLabelFromTask(both.ID, urgent.ID, actor)
+	assert.NoError(t, err)
+	assert.Len(t, untagged.Labels, 1)
+}
@@ -0,0 +1,105 @@
+package unit
+
+import (
+	"strconv"
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+	"task-manager-app/backend/internal/infrastructure"
+	"task-manager-app/backend/internal/tests"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupProjectServices(t *testing.T) (*application.ProjectService, *application.TaskService) {
+	db, err := tests.SetupTestDB()
+	assert.NoError(t, err)
+	policy := newAccessPolicy(db)
+
+	taskRepo := infrastructure.NewTaskRepository(db)
+	return application.NewProjectService(infrastructure.NewProjectRepository(db), taskRepo, policy),
+		application.NewTaskService(taskRepo, infrastructure.NewWorkspaceRepository(db), infrastructure.NewActivityRepository(db), policy)
+}
+
+func TestMoveTasksBetweenProjects(t *testing.T) {
+	projects, tasks := setupProjectServices(t)
+	actor := domain.Actor{UserID: 1, Source: domain.SourceREST}
+
+	inbox := &domain.Project{Name: "Inbox", OwnerID: 1}
+	release := &domain.Project{Name: "Release", OwnerID: 1, Color: "#ff8800"}
+	assert.NoError(t, projects.CreateProject(inbox, actor))
+	assert.NoError(t, projects.CreateProject(release, actor))
+
+	first := &domain.Task{Title: "First", UserID: 1, ProjectID: inbox.ID}
+	second := &domain.Task{Title: "Second", UserID: 1, ProjectID: inbox.ID}
+	assert.NoError(t, tasks.CreateTask(first, domain.SystemActor()))
+	assert.NoError(t, tasks.CreateTask(second, domain.SystemActor()))
+
+	// Projects outside a workspace are their owner's alone
+	stranger := domain.Actor{UserID: 2, Source: domain.SourceREST}
+	_, err := projects.GetProjectTasks(inbox.ID, domain.TaskFilter{}, stranger)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	_, err = projects.MoveTasks([]int{first.ID}, release.ID, stranger)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	visible, err := projects.GetProjects(domain.ProjectFilter{}, stranger)
+	assert.NoError(t, err)
+	assert.Empty(t, visible)
+
+	// A missing task aborts the whole move
+	_, err = projects.MoveTasks([]int{first.ID, 9999}, release.ID, actor)
+	assert.Error(t, err)
+	listed, err := projects.GetProjectTasks(inbox.ID, domain.TaskFilter{}, actor)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, listed.PageInfo.TotalCount)
+
+	moved, err := projects.MoveTasks([]int{first.ID, second.ID}, release.ID, actor)
+	assert.NoError(t, err)
+	assert.Len(t, moved, 2)
+	history, err := tasks.GetHistory(domain.ActivityFilter{TaskID: first.ID}, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ActivityUpdated, history.Edges[0].Node.Action, "moves show up in the task's history")
+	assert.Equal(t, actor.UserID, history.Edges[0].Node.ActorID)
+	assert.Equal(t, []domain.FieldChange{{Field: "projectId", Old: strconv.Itoa(inbox.ID), New: strconv.Itoa(release.ID)}}, history.Edges[0].Node.Changes)
+	listed, err = projects.GetProjectTasks(release.ID, domain.TaskFilter{Page: 1, Limit: 1}, actor)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, listed.PageInfo.TotalCount)
+	assert.Len(t, listed.Edges, 1)
+	assert.True(t, listed.PageInfo.HasNextPage)
+
+	// Updating a task does not move it; moves go through MoveTasks
+	first.ProjectID = inbox.ID
+	assert.NoError(t, tasks.UpdateTask(first, domain.SystemActor()))
+	stored, err := tasks.GetTaskByID(first.ID, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Equal(t, release.ID, stored.ProjectID)
+
+	// Tasks stay in the workspace of their project
+	system := domain.SystemActor()
+	first.WorkspaceID = 5
+	assert.ErrorIs(t, tasks.UpdateTask(first, system), domain.ErrProjectScope)
+	first.WorkspaceID = 0
+	release.WorkspaceID = 5
+	assert.ErrorIs(t, projects.UpdateProject(release, system), domain.ErrProjectScope)
+	release.WorkspaceID = 0
+	elsewhere := &domain.Task{Title: "Elsewhere", WorkspaceID: 5}
+	assert.NoError(t, tasks.CreateTask(elsewhere, system))
+	_, err = projects.MoveTasks([]int{elsewhere.ID}, release.ID, system)
+	assert.ErrorIs(t, err, domain.ErrProjectScope)
+
+	release.Archived = true
+	assert.NoError(t, projects.UpdateProject(release, actor))
+	_, err = projects.MoveTasks([]int{first.ID}, release.ID, actor)
+	assert.ErrorIs(t, err, domain.ErrProjectArchived)
+
+	visible, err = projects.GetProjects(domain.ProjectFilter{}, actor)
+	assert.NoError(t, err)
+	assert.Len(t, visible, 1)
+
+	assert.NoError(t, projects.DeleteProject(release.ID, actor))
+	orphan, err := tasks.GetTaskByID(first.ID, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Zero(t, orphan.ProjectID)
+	history, err = tasks.GetHistory(domain.ActivityFilter{TaskID: first.ID}, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.FieldChange{{Field: "projectId", Old: strconv.Itoa(release.ID), New: ""}}, history.Edges[0].Node.Changes)
+}
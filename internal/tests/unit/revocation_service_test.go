@@ -0,0 +1,98 @@
+package unit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+	"task-manager-app/backend/internal/infrastructure"
+	"task-manager-app/backend/internal/middleware"
+	"task-manager-app/backend/internal/tests"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupLogout returns a user service and a router answering 200 on /me for requests the
+// auth middleware accepts.
+func setupLogout(t *testing.T) (*application.UserService, *application.TokenService, *application.RevocationService, *gin.Engine) {
+	db, err := tests.SetupTestDB()
+	assert.NoError(t, err)
+	revocations := application.NewRevocationService(infrastructure.NewRevocationRepository(db), time.Minute)
+	tokens := application.NewTokenService(testTokenConfig, revocations)
+	service := application.NewUserService(infrastructure.NewUserRepository(db), infrastructure.NewRefreshTokenRepository(db), tokens, time.Hour, newAccessPolicy(db))
+	user := &domain.User{Name: "John", LastName: "Doe", Email: "john@example.com"}
+	assert.NoError(t, user.HashPassword("secret123"))
+	assert.NoError(t, service.Register(user))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/me", middleware.AuthMiddleware(tokens), func(c *gin.Context) {
+		claims, ok := middleware.ClaimsFromContext(c.Request.Context())
+		assert.True(t, ok)
+		assert.Equal(t, user.ID, claims.UserID)
+		assert.NotEmpty(t, claims.TokenID)
+		c.Status(http.StatusOK)
+	})
+	return service, tokens, revocations, router
+}
+
+func getMe(router *gin.Engine, token string) int {
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestLogoutRevokesSession(t *testing.T) {
+	service, tokens, revocations, router := setupLogout(t)
+	_, login, err := service.Login("john@example.com", "secret123")
+	assert.NoError(t, err)
+	_, other, err := service.Login("john@example.com", "secret123")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, getMe(router, login.AccessToken))
+
+	claims, err := tokens.Validate(login.AccessToken)
+	assert.NoError(t, err)
+	assert.NoError(t, service.Logout(claims))
+
+	// Both tokens of the session stop working, the other session is untouched
+	assert.Equal(t, http.StatusUnauthorized, getMe(router, login.AccessToken))
+	_, _, err = service.Refresh(login.RefreshToken)
+	assert.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+	assert.Equal(t, http.StatusOK, getMe(router, other.AccessToken))
+	_, _, err = service.Refresh(other.RefreshToken)
+	assert.NoError(t, err)
+
+	// Entries are pruned once the token they revoke has expired
+	pruned, err := revocations.Prune(claims.ExpiresAt.Add(testTokenConfig.ClockSkew + time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, pruned)
+}
+
+func TestLogoutEverywhere(t *testing.T) {
+	service, _, revocations, router := setupLogout(t)
+	user, first, err := service.Login("john@example.com", "secret123")
+	assert.NoError(t, err)
+	_, second, err := service.Login("john@example.com", "secret123")
+	assert.NoError(t, err)
+
+	assert.NoError(t, service.LogoutEverywhere(user.ID))
+	for _, tokens := range []*domain.TokenPair{first, second} {
+		assert.Equal(t, http.StatusUnauthorized, getMe(router, tokens.AccessToken))
+		_, _, err = service.Refresh(tokens.RefreshToken)
+		assert.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+	}
+
+	// Tokens issued later are not affected; token times have a one-second resolution
+	revoked, err := revocations.IsRevoked("", "", user.ID, time.Now().Add(2*time.Second))
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	pruned, err := revocations.Prune(time.Now().Add(2 * time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, pruned)
+}
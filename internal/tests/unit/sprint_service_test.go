@@ -0,0 +1,210 @@
+package unit
+
+import (
+	"strconv"
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+	"task-manager-app/backend/internal/infrastructure"
+	"task-manager-app/backend/internal/tests"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSprintBurndownReplaysHistory(t *testing.T) {
+	day := func(d, hour int) time.Time { return time.Date(2026, 3, d, hour, 0, 0, 0, time.UTC) }
+	sprint := &domain.Sprint{ID: 7, Name: "Sprint 7", StartDate: day(2, 0), EndDate: day(6, 0)}
+	remaining := 30
+	tasks := []domain.Task{
+		// Planned before the sprint, re-estimated on day 3 and done just after midnight UTC on day 5
+		{ID: 1, Status: domain.StatusDone, EstimateMinutes: 120, RemainingMinutes: &remaining, SprintID: 7, CreatedAt: day(1, 9)},
+		// Added on day 3
+		{ID: 2, Status: domain.StatusTodo, EstimateMinutes: 60, SprintID: 7, CreatedAt: day(1, 9)},
+		// Created in the sprint on day 2, dropped on day 5
+		{ID: 3, Status: domain.StatusTodo, EstimateMinutes: 45, CreatedAt: day(2, 10)},
+		// Cancelled on day 3
+		{ID: 4, Status: domain.StatusCancelled, EstimateMinutes: 15, SprintID: 7, CreatedAt: day(1, 9)},
+	}
+	activities := []domain.Activity{
+		{TaskID: 1, Action: domain.ActivityUpdated, CreatedAt: day(1, 10), Changes: []domain.FieldChange{{Field: "sprintId", New: "7"}}},
+		{TaskID: 1, Action: domain.ActivityUpdated, CreatedAt: day(3, 10), Changes: []domain.FieldChange{{Field: "remainingMinutes", New: "30"}}},
+		{TaskID: 1, Action: domain.ActivityUpdated, CreatedAt: day(5, 1), Changes: []domain.FieldChange{{Field: "status", Old: "in_progress", New: "done"}}},
+		{TaskID: 2, Action: domain.ActivityUpdated, CreatedAt: day(3, 11), Changes: []domain.FieldChange{{Field: "sprintId", New: "7"}}},
+		{TaskID: 3, Action: domain.ActivityCreated, CreatedAt: day(2, 10)},
+		{TaskID: 3, Action: domain.ActivityUpdated, CreatedAt: day(2, 10), Changes: []domain.FieldChange{{Field: "sprintId", New: "7"}}},
+		{TaskID: 3, Action: domain.ActivityUpdated, CreatedAt: day(5, 10), Changes: []domain.FieldChange{{Field: "sprintId", Old: "7", New: "8"}}},
+		{TaskID: 4, Action: domain.ActivityUpdated, CreatedAt: day(1, 10), Changes: []domain.FieldChange{{Field: "sprintId", New: "7"}}},
+		{TaskID: 4, Action: domain.ActivityUpdated, CreatedAt: day(3, 9), Changes: []domain.FieldChange{{Field: "status", Old: "todo", New: "cancelled"}}},
+	}
+
+	// Halfway through day 5 the sprint has four points, the last one as of now
+	burndown := domain.SprintBurndown(sprint, tasks, activities, day(5, 12))
+	assert.Len(t, burndown.Points, 4)
+	type counts struct{ scope, remaining, completed, remainingMinutes, completedMinutes int }
+	var got []counts
+	for _, p := range burndown.Points {
+		got = append(got, counts{p.ScopeCount, p.RemainingCount, p.CompletedCount, p.RemainingMinutes, p.CompletedMinutes})
+		assert.Equal(t, p.RemainingMinutes+p.CompletedMinutes, p.ScopeMinutes)
+	}
+	assert.Equal(t, []counts{
+		{3, 3, 0, 120 + 45 + 15, 0}, // day 2
+		{3, 3, 0, 30 + 45 + 60, 0},  // day 3: re-estimated, one added, one cancelled
+		{3, 3, 0, 30 + 45 + 60, 0},  // day 4
+		{2, 1, 1, 60, 120},          // day 5 so far
+	}, got)
+	assert.Equal(t, day(2, 0), burndown.Points[0].Date)
+
+	// Days are counted in the sprint's timezone
+	sprint.Timezone = "America/Sao_Paulo"
+	burndown = domain.SprintBurndown(sprint, tasks, activities, day(6, 12))
+	assert.Len(t, burndown.Points, 5)
+	assert.Equal(t, 1, burndown.Points[2].CompletedCount, "day 4 ends at 03:00 UTC on day 5")
+
+	// A sprint that has not started has no points yet
+	assert.Empty(t, domain.SprintBurndown(sprint, tasks, activities, day(1, 12)).Points)
+}
+
+func TestSprints(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	assert.NoError(t, err)
+	policy := newAccessPolicy(db)
+	taskRepo := infrastructure.NewTaskRepository(db)
+	projectRepo := infrastructure.NewProjectRepository(db)
+	tasks := application.NewTaskService(taskRepo, infrastructure.NewWorkspaceRepository(db), infrastructure.NewActivityRepository(db), policy)
+	service := application.NewSprintService(infrastructure.NewSprintRepository(db), projectRepo, tasks, policy)
+	actor := domain.Actor{UserID: 1, Source: domain.SourceREST}
+
+	project := &domain.Project{Name: "Website", OwnerID: 1}
+	other := &domain.Project{Name: "Mobile", OwnerID: 1}
+	assert.NoError(t, projectRepo.Create(project))
+	assert.NoError(t, projectRepo.Create(other))
+
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -3)
+	assert.ErrorIs(t, service.CreateSprint(&domain.Sprint{ProjectID: project.ID, Name: "Backwards", StartDate: start, EndDate: start.AddDate(0, 0, -1)}, actor), domain.ErrInvalidSprint)
+	assert.ErrorIs(t, service.CreateSprint(&domain.Sprint{ProjectID: project.ID, Name: "Zone", StartDate: start, EndDate: start, Timezone: "Mars/Olympus"}, actor), domain.ErrSprintTimezone)
+	sprint := &domain.Sprint{ProjectID: project.ID, Name: " Sprint 1 ", StartDate: start.Add(15 * time.Hour), EndDate: start.AddDate(0, 0, 13)}
+	assert.NoError(t, service.CreateSprint(sprint, actor))
+	assert.Equal(t, "Sprint 1", sprint.Name)
+	assert.Equal(t, start, sprint.StartDate, "dates are calendar days")
+	stranger := domain.Actor{UserID: 2, Source: domain.SourceREST}
+	_, err = service.GetSprint(sprint.ID, stranger)
+	assert.ErrorIs(t, err, domain.ErrForbidden, "sprints belong to projects the actor must see")
+
+	design := &domain.Task{Title: "Design", UserID: 1, ProjectID: project.ID, EstimateMinutes: 60}
+	build := &domain.Task{Title: "Build", UserID: 1, ProjectID: project.ID, EstimateMinutes: 120}
+	elsewhere := &domain.Task{Title: "App", UserID: 1, ProjectID: other.ID}
+	for _, task := range []*domain.Task{design, build, elsewhere} {
+		assert.NoError(t, tasks.CreateTask(task, domain.SystemActor()))
+	}
+
+	_, err = service.AddTasks(sprint.ID, []int{design.ID, elsewhere.ID}, actor)
+	assert.ErrorIs(t, err, domain.ErrSprintProject)
+	added, err := service.AddTasks(sprint.ID, []int{design.ID, build.ID, design.ID}, actor)
+	assert.NoError(t, err)
+	assert.Len(t, added, 2)
+	listed, err := tasks.GetAllTasks(domain.TaskFilter{SprintID: sprint.ID}, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, listed.PageInfo.TotalCount)
+
+	// Editing a task keeps it in the sprint
+	design.Title = "Design v2"
+	assert.NoError(t, tasks.UpdateTask(design, actor))
+	assert.Equal(t, sprint.ID, design.SprintID)
+
+	// Pretend the sprint was planned on its first day
+	planned := start.Add(time.Hour)
+	assert.NoError(t, db.Model(&domain.Task{}).Where("id IN ?", []int{design.ID, build.ID}).Update("created_at", planned).Error)
+	assert.NoError(t, db.Model(&domain.Activity{}).Where("task_id IN ?", []int{design.ID, build.ID}).Update("created_at", planned).Error)
+
+	_, err = tasks.ChangeStatus(design.ID, domain.StatusDone, actor, false)
+	assert.NoError(t, err)
+	_, err = service.RemoveTask(sprint.ID, elsewhere.ID, actor)
+	assert.ErrorIs(t, err, domain.ErrNotInSprint)
+	removed, err := service.RemoveTask(sprint.ID, build.ID, actor)
+	assert.NoError(t, err)
+	assert.Zero(t, removed.SprintID)
+
+	burndown, err := service.GetBurndown(sprint.ID, actor)
+	assert.NoError(t, err)
+	assert.Len(t, burndown.Points, 4, "three days past and today")
+	first, today := burndown.Points[0], burndown.Points[3]
+	assert.Equal(t, 2, first.ScopeCount, "the removed task still counts before it was removed")
+	assert.Equal(t, 180, first.RemainingMinutes)
+	assert.Equal(t, 1, today.ScopeCount)
+	assert.Equal(t, 0, today.RemainingCount)
+	assert.Equal(t, 60, today.CompletedMinutes)
+
+	// Moving a task to another project takes it out of the sprint
+	_, err = service.AddTasks(sprint.ID, []int{build.ID}, actor)
+	assert.NoError(t, err)
+	assert.NoError(t, projectRepo.MoveTasks([]int{build.ID}, other.ID, domain.SystemActor()))
+	moved, err := tasks.GetTaskByID(build.ID, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Zero(t, moved.SprintID)
+
+	assert.NoError(t, service.DeleteSprint(sprint.ID, actor))
+	kept, err := tasks.GetTaskByID(design.ID, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Zero(t, kept.SprintID)
+	sprints, err := service.GetSprints(project.ID, actor)
+	assert.NoError(t, err)
+	assert.Empty(t, sprints)
+}
+
+func TestSprintBurndownAfterMoveAndDelete(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	assert.NoError(t, err)
+	policy := newAccessPolicy(db)
+	taskRepo := infrastructure.NewTaskRepository(db)
+	projectRepo := infrastructure.NewProjectRepository(db)
+	tasks := application.NewTaskService(taskRepo, infrastructure.NewWorkspaceRepository(db), infrastructure.NewActivityRepository(db), policy)
+	projects := application.NewProjectService(projectRepo, taskRepo, policy)
+	service := application.NewSprintService(infrastructure.NewSprintRepository(db), projectRepo, tasks, policy)
+	actor := domain.Actor{UserID: 1, Source: domain.SourceREST}
+
+	project := &domain.Project{Name: "Website", OwnerID: 1}
+	other := &domain.Project{Name: "Mobile", OwnerID: 1}
+	assert.NoError(t, projectRepo.Create(project))
+	assert.NoError(t, projectRepo.Create(other))
+
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -3)
+	sprint := &domain.Sprint{ProjectID: project.ID, Name: "Sprint 1", StartDate: start, EndDate: start.AddDate(0, 0, 13)}
+	next := &domain.Sprint{ProjectID: project.ID, Name: "Sprint 2", StartDate: start.AddDate(0, 0, 14), EndDate: start.AddDate(0, 0, 27)}
+	assert.NoError(t, service.CreateSprint(sprint, actor))
+	assert.NoError(t, service.CreateSprint(next, actor))
+
+	design := &domain.Task{Title: "Design", UserID: 1, ProjectID: project.ID, EstimateMinutes: 60}
+	build := &domain.Task{Title: "Build", UserID: 1, ProjectID: project.ID, EstimateMinutes: 120}
+	ship := &domain.Task{Title: "Ship", UserID: 1, ProjectID: project.ID, EstimateMinutes: 30}
+	for _, task := range []*domain.Task{design, build, ship} {
+		assert.NoError(t, tasks.CreateTask(task, domain.SystemActor()))
+	}
+	_, err = service.AddTasks(sprint.ID, []int{design.ID, build.ID, ship.ID}, actor)
+	assert.NoError(t, err)
+	planned := start.Add(time.Hour)
+	ids := []int{design.ID, build.ID, ship.ID}
+	assert.NoError(t, db.Model(&domain.Task{}).Where("id IN ?", ids).Update("created_at", planned).Error)
+	assert.NoError(t, db.Model(&domain.Activity{}).Where("task_id IN ?", ids).Update("created_at", planned).Error)
+
+	// Today one task moves to another project and one is pushed to a sprint that is then dropped
+	_, err = projects.MoveTasks([]int{build.ID}, other.ID, actor)
+	assert.NoError(t, err)
+	_, err = service.AddTasks(next.ID, []int{ship.ID}, actor)
+	assert.NoError(t, err)
+	assert.NoError(t, service.DeleteSprint(next.ID, actor))
+	history, err := tasks.GetHistory(domain.ActivityFilter{TaskID: ship.ID}, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.FieldChange{{Field: "sprintId", Old: strconv.Itoa(next.ID), New: ""}}, history.Edges[0].Node.Changes)
+
+	burndown, err := service.GetBurndown(sprint.ID, actor)
+	assert.NoError(t, err)
+	assert.Len(t, burndown.Points, 4)
+	first, today := burndown.Points[0], burndown.Points[3]
+	assert.Equal(t, 3, first.ScopeCount, "tasks count until they leave the sprint")
+	assert.Equal(t, 60+120+30, first.RemainingMinutes)
+	assert.Equal(t, 1, today.ScopeCount)
+	assert.Equal(t, 60, today.RemainingMinutes)
+}
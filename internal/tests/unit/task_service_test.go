@@ -6,6 +6,7 @@ import (
 	"task-manager-app/backend/internal/infrastructure"
 	"task-manager-app/backend/internal/tests"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -15,7 +16,7 @@ func setupTaskService(t *testing.T) *application.TaskService {
 	assert.NoError(t, err)
 
 	repo := infrastructure.NewTaskRepository(db)
-	return application.NewTaskService(repo)
+	return application.NewTaskService(repo, infrastructure.NewWorkspaceRepository(db), infrastructure.NewActivityRepository(db), newAccessPolicy(db))
 }
 
 func TestCreateTask(t *testing.T) {
@@ -25,7 +26,7 @@ func TestCreateTask(t *testing.T) {
 		Title:  "Test Task",
 		UserID: 1,
 	}
-	err := service.CreateTask(task)
+	err := service.CreateTask(task, domain.SystemActor())
 	assert.NoError(t, err)
 	assert.NotZero(t, task.ID)
 }
@@ -37,14 +38,14 @@ func TestUpdateTask(t *testing.T) {
 		Title:  "Test Task",
 		UserID: 1,
 	}
-	err := service.CreateTask(task)
+	err := service.CreateTask(task, domain.SystemActor())
 	assert.NoError(t, err)
 
 	task.Title = "Updated Task"
-	err = service.UpdateTask(task)
+	err = service.UpdateTask(task, domain.Actor{UserID: 1})
 	assert.NoError(t, err)
 
-	updatedTask, err := service.GetTaskByID(task.ID)
+	updatedTask, err := service.GetTaskByID(task.ID, domain.SystemActor())
 	assert.NoError(t, err)
 	assert.Equal(t, "Updated Task", updatedTask.Title)
 }
@@ -56,13 +57,13 @@ func TestDeleteTask(t *testing.T) {
 		Title:  "Test Task",
 		UserID: 1,
 	}
-	err := service.CreateTask(task)
+	err := service.CreateTask(task, domain.SystemActor())
 	assert.NoError(t, err)
 
-	err = service.DeleteTask(task.ID)
+	err = service.DeleteTask(task.ID, domain.DeleteReparent, domain.SystemActor())
 	assert.NoError(t, err)
 
-	deletedTask, err := service.GetTaskByID(task.ID)
+	deletedTask, err := service.GetTaskByID(task.ID, domain.SystemActor())
 	assert.Error(t, err)
 	assert.Nil(t, deletedTask)
 }
@@ -74,10 +75,441 @@ func TestGetTask(t *testing.T) {
 		Title:  "Test Task",
 		UserID: 1,
 	}
-	err := service.CreateTask(task)
+	err := service.CreateTask(task, domain.SystemActor())
 	assert.NoError(t, err)
 
-	retrievedTask, err := service.GetTaskByID(task.ID)
+	retrievedTask, err := service.GetTaskByID(task.ID, domain.SystemActor())
 	assert.NoError(t, err)
 	assert.Equal(t, "Test Task", retrievedTask.Title)
 }
+
+func TestCreateTaskInvalidSchedule(t *testing.T) {
+	service := setupTaskService(t)
+
+	start := time.Now()
+	due := start.Add(-time.Hour)
+	task := &domain.Task{
+		Title:   "Test Task",
+		UserID:  1,
+		StartAt: &start,
+		DueAt:   &due,
+	}
+	err := service.CreateTask(task, domain.SystemActor())
+	assert.ErrorIs(t, err, domain.ErrInvalidSchedule)
+
+	task = &domain.Task{Title: "Test Task", UserID: 1, Timezone: "Mars/Olympus_Mons"}
+	err = service.CreateTask(task, domain.SystemActor())
+	assert.ErrorIs(t, err, domain.ErrInvalidTimezone)
+}
+
+func TestGetTasksDueFilters(t *testing.T) {
+	service := setupTaskService(t)
+
+	past := time.Now().Add(-48 * time.Hour)
+	future := time.Now().Add(48 * time.Hour)
+	assert.NoError(t, service.CreateTask(&domain.Task{Title: "Late", UserID: 1, DueAt: &past, Timezone: "America/Sao_Paulo"}, domain.SystemActor()))
+	assert.NoError(t, service.CreateTask(&domain.Task{Title: "Late but done", UserID: 1, DueAt: &past, IsCompleted: true}, domain.SystemActor()))
+	assert.NoError(t, service.CreateTask(&domain.Task{Title: "Upcoming", UserID: 1, DueAt: &future}, domain.SystemActor()))
+	assert.NoError(t, service.CreateTask(&domain.Task{Title: "Someday", UserID: 1}, domain.SystemActor()))
+
+	overdue := true
+	tasks, err := service.GetAllTasks(domain.TaskFilter{Overdue: &overdue}, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Len(t, tasks.Edges, 1)
+	assert.Equal(t, "Late", tasks.Edges[0].Node.Title)
+	assert.True(t, tasks.Edges[0].Node.IsOverdue(time.Now()))
+
+	notOverdue := false
+	tasks, err = service.GetAllTasks(domain.TaskFilter{Overdue: &notOverdue}, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Len(t, tasks.Edges, 3)
+
+	now := time.Now()
+	tasks, err = service.GetAllTasks(domain.TaskFilter{DueAfter: &now}, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Len(t, tasks.Edges, 1)
+	assert.Equal(t, "Upcoming", tasks.Edges[0].Node.Title)
+
+	tasks, err = service.GetAllTasks(domain.TaskFilter{DueBefore: &now}, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Len(t, tasks.Edges, 2)
+}
+
+func TestChangeTaskStatus(t *testing.T) {
+	service := setupTaskService(t)
+
+	task := &domain.Task{Title: "Test Task", UserID: 1}
+	assert.NoError(t, service.CreateTask(task, domain.SystemActor()))
+	assert.Equal(t, domain.StatusTodo, task.Status)
+
+	_, err := service.ChangeStatus(task.ID, domain.StatusInReview, domain.Actor{UserID: 1}, false)
+	var transitionErr *domain.InvalidTransitionError
+	assert.ErrorAs(t, err, &transitionErr)
+
+	_, err = service.ChangeStatus(task.ID, domain.StatusInProgress, domain.Actor{UserID: 7}, false)
+	assert.ErrorIs(t, err, domain.ErrForbidden, "a personal task is only its owner's to change")
+
+	updated, err := service.ChangeStatus(task.ID, domain.StatusInProgress, domain.Actor{UserID: 1}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusInProgress, updated.Status)
+	assert.Equal(t, 1, updated.StatusChangedBy)
+	assert.NotNil(t, updated.StatusChangedAt)
+	assert.False(t, updated.IsCompleted)
+
+	// Flipping the legacy flag moves the task to done
+	updated.IsCompleted = true
+	assert.NoError(t, service.UpdateTask(updated, domain.Actor{UserID: 1}))
+	stored, err := service.GetTaskByID(task.ID, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusDone, stored.Status)
+	assert.True(t, stored.IsCompleted)
+}
+
+func TestChangeTaskStatusWorkspaceWorkflow(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	assert.NoError(t, err)
+	workspaceRepo := infrastructure.NewWorkspaceRepository(db)
+	service := application.NewTaskService(infrastructure.NewTaskRepository(db), workspaceRepo, infrastructure.NewActivityRepository(db), newAccessPolicy(db))
+	workspaces := application.NewWorkspaceService(workspaceRepo, newAccessPolicy(db))
+
+	workspace := &domain.Workspace{Name: "Strict", OwnerID: 1}
+	assert.NoError(t, workspaces.CreateWorkspace(workspace, domain.SystemActor()))
+	assert.NoError(t, workspaces.SetWorkflow(workspace.ID, []domain.WorkflowTransition{
+		{FromStatus: domain.StatusTodo, ToStatus: domain.StatusInProgress},
+		{FromStatus: domain.StatusInProgress, ToStatus: domain.StatusInReview},
+		{FromStatus: domain.StatusInReview, ToStatus: domain.StatusDone},
+	}, domain.SystemActor()))
+
+	task := &domain.Task{Title: "Test Task", UserID: 1, WorkspaceID: workspace.ID}
+	assert.NoError(t, service.CreateTask(task, domain.SystemActor()))
+
+	_, err = service.ChangeStatus(task.ID, domain.StatusDone, domain.Actor{UserID: 1}, false)
+	var transitionErr *domain.InvalidTransitionError
+	assert.ErrorAs(t, err, &transitionErr)
+
+	for _, status := range []domain.TaskStatus{domain.StatusInProgress, domain.StatusInReview, domain.StatusDone} {
+		_, err = service.ChangeStatus(task.ID, status, domain.Actor{UserID: 1}, false)
+		assert.NoError(t, err)
+	}
+
+	err = workspaces.SetWorkflow(workspace.ID, []domain.WorkflowTransition{{FromStatus: "todo", ToStatus: "archived"}}, domain.SystemActor())
+	assert.ErrorIs(t, err, domain.ErrUnknownStatus)
+}
+
+func TestGetTasksSorted(t *testing.T) {
+	service := setupTaskService(t)
+
+	soon := time.Now().Add(time.Hour)
+	later := time.Now().Add(24 * time.Hour)
+	assert.NoError(t, service.CreateTask(&domain.Task{Title: "B", UserID: 1, Priority: domain.PriorityHigh}, domain.SystemActor()))
+	assert.NoError(t, service.CreateTask(&domain.Task{Title: "A", UserID: 1, Priority: domain.PriorityHigh, DueAt: &later}, domain.SystemActor()))
+	assert.NoError(t, service.CreateTask(&domain.Task{Title: "C", UserID: 1, Priority: domain.PriorityHigh, DueAt: &soon}, domain.SystemActor()))
+	assert.NoError(t, service.CreateTask(&domain.Task{Title: "D", UserID: 1, Priority: domain.PriorityUrgent}, domain.SystemActor()))
+	assert.NoError(t, service.CreateTask(&domain.Task{Title: "E", UserID: 1}, domain.SystemActor()))
+
+	sort, err := domain.ParseTaskSort("-priority,dueAt")
+	assert.NoError(t, err)
+	tasks, err := service.GetAllTasks(domain.TaskFilter{Sort: sort}, domain.SystemActor())
+	assert.NoError(t, err)
+
+	var titles []string
+	for _, edge := range tasks.Edges {
+		titles = append(titles, edge.Node.Title)
+	}
+	assert.Equal(t, []string{"D", "C", "A", "B", "E"}, titles)
+
+	_, err = domain.ParseTaskSort("-priority,color")
+	assert.ErrorIs(t, err, domain.ErrInvalidSort)
+}
+
+func TestSubtaskHierarchy(t *testing.T) {
+	service := setupTaskService(t)
+
+	root := &domain.Task{Title: "Release", UserID: 1}
+	assert.NoError(t, service.CreateTask(root, domain.SystemActor()))
+	child := &domain.Task{Title: "Backend", UserID: 1, ParentID: root.ID}
+	assert.NoError(t, service.CreateTask(child, domain.SystemActor()))
+	leafA := &domain.Task{Title: "API", UserID: 1, ParentID: child.ID}
+	leafB := &domain.Task{Title: "Migrations", UserID: 1, ParentID: child.ID}
+	leafC := &domain.Task{Title: "Docs", UserID: 1, ParentID: root.ID}
+	for _, task := range []*domain.Task{leafA, leafB, leafC} {
+		assert.NoError(t, service.CreateTask(task, domain.SystemActor()))
+	}
+
+	// Nesting the root under one of its descendants is rejected
+	root.ParentID = leafA.ID
+	assert.ErrorIs(t, service.UpdateTask(root, domain.Actor{UserID: 1}), domain.ErrTaskCycle)
+	root.ParentID = 0
+
+	_, err := service.ChangeStatus(leafA.ID, domain.StatusDone, domain.Actor{UserID: 1}, false)
+	assert.NoError(t, err)
+	progress, err := service.GetProgress(root.ID, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.InDelta(t, 100.0/3, progress, 0.01)
+
+	_, err = service.ChangeStatus(child.ID, domain.StatusDone, domain.Actor{UserID: 1}, true)
+	assert.NoError(t, err)
+	progress, err = service.GetProgress(child.ID, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Equal(t, 100.0, progress)
+
+	// Deleting the middle task moves its subtasks up to the root
+	assert.NoError(t, service.DeleteTask(child.ID, domain.DeleteReparent, domain.SystemActor()))
+	children, err := service.GetSubtasks(root.ID, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Len(t, children, 3)
+
+	assert.NoError(t, service.DeleteTask(root.ID, domain.DeleteCascade, domain.SystemActor()))
+	_, err = service.GetTaskByID(leafB.ID, domain.SystemActor())
+	assert.Error(t, err)
+}
+
+func TestRecurringTaskCompletion(t *testing.T) {
+	service := setupTaskService(t)
+
+	ny, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	due := time.Date(2024, 3, 7, 9, 0, 0, 0, ny) // Thursday, before the switch to daylight saving time
+	task := &domain.Task{Title: "Standup notes", UserID: 1, DueAt: &due, Timezone: "America/New_York",
+		Recurrence: "freq=weekly;byday=MO,TH;count=3"}
+	assert.NoError(t, service.CreateTask(task, domain.SystemActor()))
+	assert.Equal(t, "FREQ=WEEKLY;BYDAY=MO,TH;COUNT=3", task.Recurrence)
+
+	for occurrence, want := range []time.Time{
+		time.Date(2024, 3, 11, 9, 0, 0, 0, ny),
+		time.Date(2024, 3, 14, 9, 0, 0, 0, ny),
+	} {
+		task.Status = domain.StatusDone
+		assert.NoError(t, service.UpdateTask(task, domain.Actor{UserID: 1}))
+		assert.Empty(t, task.Recurrence)
+
+		open, err := service.GetAllTasks(domain.TaskFilter{Search: "Standup"}, domain.SystemActor())
+		assert.NoError(t, err)
+		assert.Equal(t, occurrence+2, open.PageInfo.TotalCount)
+		task = &open.Edges[len(open.Edges)-1].Node
+		assert.Equal(t, domain.StatusTodo, task.Status)
+		assert.Equal(t, occurrence+2, task.Occurrence)
+		assert.True(t, want.Equal(*task.DueAt), "got %s, want %s", task.DueAt, want)
+	}
+
+	// The third occurrence is the last one allowed by COUNT
+	upcoming, err := service.PreviewOccurrences(task.ID, 5, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Empty(t, upcoming)
+	task.Status = domain.StatusDone
+	assert.NoError(t, service.UpdateTask(task, domain.Actor{UserID: 1}))
+	all, err := service.GetAllTasks(domain.TaskFilter{Search: "Standup"}, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, all.PageInfo.TotalCount)
+}
+
+func TestRecurringTaskPreviewSkipAndEnd(t *testing.T) {
+	service := setupTaskService(t)
+
+	due := time.Date(2024, 1, 26, 17, 0, 0, 0, time.UTC)
+	start := due.Add(-2 * time.Hour)
+	task := &domain.Task{Title: "Monthly report", UserID: 1, StartAt: &start, DueAt: &due,
+		Recurrence: "FREQ=MONTHLY;BYDAY=-1FR;UNTIL=20240531"}
+	assert.NoError(t, service.CreateTask(task, domain.SystemActor()))
+
+	upcoming, err := service.PreviewOccurrences(task.ID, 10, domain.SystemActor())
+	assert.NoError(t, err)
+	var days []string
+	for _, occurrence := range upcoming {
+		days = append(days, occurrence.Format("2006-01-02"))
+	}
+	assert.Equal(t, []string{"2024-02-23", "2024-03-29", "2024-04-26", "2024-05-31"}, days)
+
+	skipped, err := service.SkipOccurrence(task.ID, domain.Actor{UserID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-02-23", skipped.DueAt.Format("2006-01-02"))
+	assert.Equal(t, 2*time.Hour, skipped.DueAt.Sub(*skipped.StartAt))
+	assert.Equal(t, 2, skipped.Occurrence)
+
+	ended, err := service.EndSeries(task.ID, domain.Actor{UserID: 1})
+	assert.NoError(t, err)
+	assert.Empty(t, ended.Recurrence)
+	_, err = service.SkipOccurrence(task.ID, domain.Actor{UserID: 1})
+	assert.ErrorIs(t, err, domain.ErrNotRecurring)
+
+	invalid := &domain.Task{Title: "No anchor", UserID: 1, Recurrence: "FREQ=DAILY"}
+	assert.ErrorIs(t, service.CreateTask(invalid, domain.SystemActor()), domain.ErrRecurrenceAnchor)
+	invalid = &domain.Task{Title: "Bad rule", UserID: 1, DueAt: &due, Recurrence: "FREQ=HOURLY"}
+	assert.ErrorIs(t, service.CreateTask(invalid, domain.SystemActor()), domain.ErrInvalidRecurrence)
+}
+
+func TestTaskAssigneesAndWatchers(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	assert.NoError(t, err)
+	users := infrastructure.NewUserRepository(db)
+	service := application.NewTaskService(infrastructure.NewTaskRepository(db), infrastructure.NewWorkspaceRepository(db), infrastructure.NewActivityRepository(db), newAccessPolicy(db))
+
+	creator := &domain.User{Email: "creator@example.com", Name: "Cora"}
+	assignee := &domain.User{Email: "assignee@example.com", Name: "Ari"}
+	assert.NoError(t, users.Create(creator))
+	assert.NoError(t, users.Create(assignee))
+
+	due := time.Date(2030, 1, 6, 9, 0, 0, 0, time.UTC)
+	task := &domain.Task{Title: "Hand off", UserID: creator.ID, DueAt: &due, Recurrence: "FREQ=WEEKLY"}
+	assert.NoError(t, service.CreateTask(task, domain.SystemActor()))
+	other := &domain.Task{Title: "Someone else's", UserID: assignee.ID}
+	assert.NoError(t, service.CreateTask(other, domain.SystemActor()))
+
+	updated, err := service.AssignTask(task.ID, assignee.ID, domain.Actor{UserID: creator.ID})
+	assert.NoError(t, err)
+	assert.Len(t, updated.Assignees, 1)
+	_, err = service.AssignTask(task.ID, assignee.ID, domain.Actor{UserID: creator.ID})
+	assert.NoError(t, err, "assigning twice is a no-op")
+	updated, err = service.WatchTask(task.ID, creator.ID, domain.Actor{UserID: creator.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, assignee.ID, updated.Assignees[0].ID)
+	assert.Equal(t, creator.ID, updated.Watchers[0].ID)
+	_, err = service.AssignTask(task.ID, 999, domain.Actor{UserID: creator.ID})
+	assert.Error(t, err)
+
+	assigned, err := service.GetAllTasks(domain.TaskFilter{AssigneeID: assignee.ID}, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, assigned.PageInfo.TotalCount)
+	assert.Equal(t, task.ID, assigned.Edges[0].Node.ID)
+	created, err := service.GetAllTasks(domain.TaskFilter{CreatorID: assignee.ID}, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, created.PageInfo.TotalCount)
+	assert.Equal(t, other.ID, created.Edges[0].Node.ID)
+	watched, err := service.GetAllTasks(domain.TaskFilter{WatcherID: creator.ID}, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, watched.PageInfo.TotalCount)
+
+	// The next occurrence of a recurring task keeps the same people
+	_, err = service.ChangeStatus(task.ID, domain.StatusDone, domain.Actor{UserID: assignee.ID}, false)
+	assert.NoError(t, err)
+	assigned, err = service.GetAllTasks(domain.TaskFilter{AssigneeID: assignee.ID}, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, assigned.PageInfo.TotalCount)
+
+	updated, err = service.UnassignTask(task.ID, assignee.ID, domain.Actor{UserID: creator.ID})
+	assert.NoError(t, err)
+	assert.Empty(t, updated.Assignees)
+	updated, err = service.UnwatchTask(task.ID, creator.ID, domain.Actor{UserID: creator.ID})
+	assert.NoError(t, err)
+	assert.Empty(t, updated.Watchers)
+}
+
+func TestTaskHistory(t *testing.T) {
+	service := setupTaskService(t)
+
+	due := time.Date(2030, 3, 4, 12, 0, 0, 0, time.UTC)
+	task := &domain.Task{Title: "Write report", UserID: 1, DueAt: &due, Recurrence: "FREQ=DAILY"}
+	assert.NoError(t, service.CreateTask(task, domain.SystemActor()))
+
+	task.Title = "Write the report"
+	task.Priority = domain.PriorityHigh
+	assert.NoError(t, service.UpdateTask(task, domain.Actor{UserID: 1, Source: domain.SourceREST}))
+	assert.NoError(t, service.UpdateTask(task, domain.Actor{UserID: 1, Source: domain.SourceREST}), "saving without changes")
+	_, err := service.ChangeStatus(task.ID, domain.StatusDone, domain.Actor{UserID: 1, Source: domain.SourceGraphQL}, false)
+	assert.NoError(t, err)
+
+	history, err := service.GetHistory(domain.ActivityFilter{TaskID: task.ID, Page: 1, Limit: 10}, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, history.PageInfo.TotalCount, "updates without changes are not logged")
+
+	latest := history.Edges[0].Node
+	assert.Equal(t, 1, latest.ActorID)
+	assert.Equal(t, domain.SourceGraphQL, latest.Source)
+	assert.Contains(t, latest.Changes, domain.FieldChange{Field: "status", Old: "todo", New: "done"})
+	assert.Contains(t, latest.Changes, domain.FieldChange{Field: "recurrence", Old: "FREQ=DAILY", New: ""})
+
+	first := history.Edges[1].Node
+	assert.Equal(t, domain.SourceREST, first.Source)
+	assert.Equal(t, []domain.FieldChange{
+		{Field: "title", Old: "Write report", New: "Write the report"},
+		{Field: "priority", Old: "none", New: "high"},
+	}, first.Changes)
+
+	// The next occurrence records that the system created it
+	next, err := service.GetAllTasks(domain.TaskFilter{Search: "Write the report", Sort: []domain.TaskSort{{Field: domain.SortByDueAt, Desc: true}}}, domain.SystemActor())
+	assert.NoError(t, err)
+	created, err := service.GetHistory(domain.ActivityFilter{TaskID: next.Edges[0].Node.ID, Page: 1, Limit: 10}, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ActivityCreated, created.Edges[0].Node.Action)
+	assert.Equal(t, domain.SourceSystem, created.Edges[0].Node.Source)
+}
+
+func TestTaskWritesAllOrNothing(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	assert.NoError(t, err)
+	repo := infrastructure.NewTaskRepository(db)
+	activities := infrastructure.NewActivityRepository(db)
+
+	task := &domain.Task{Title: "Plan", UserID: 1}
+	assert.NoError(t, repo.Create(task))
+	renamed := *task
+	renamed.Title = "Plan the launch"
+	change := &domain.Activity{Action: domain.ActivityUpdated, Changes: domain.DiffTasks(task, &renamed)}
+	clash := &domain.Task{ID: task.ID, Title: "Clash", UserID: 1}
+
+	// The second write fails, so the update and its activity are rolled back with it
+	assert.Error(t, repo.Apply([]domain.TaskWrite{{Task: &renamed, Activity: change}, {Task: clash, Create: true}}))
+	stored, err := repo.FindByID(task.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Plan", stored.Title)
+	history, err := activities.FindByTask(domain.ActivityFilter{TaskID: task.ID})
+	assert.NoError(t, err)
+	assert.Zero(t, history.PageInfo.TotalCount)
+}
+
+func TestArchiveTasks(t *testing.T) {
+	service := setupTaskService(t)
+	actor := domain.Actor{UserID: 1, Source: domain.SourceREST}
+
+	task := &domain.Task{Title: "Old report", UserID: 1}
+	assert.NoError(t, service.CreateTask(task, domain.SystemActor()))
+	archived, err := service.ArchiveTask(task.ID, actor)
+	assert.NoError(t, err)
+	assert.NotNil(t, archived.ArchivedAt)
+	_, err = service.ArchiveTask(task.ID, actor)
+	assert.ErrorIs(t, err, domain.ErrAlreadyArchived)
+
+	tasks, err := service.GetAllTasks(domain.TaskFilter{}, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Zero(t, tasks.PageInfo.TotalCount, "archived tasks are hidden by default")
+	tasks, err = service.GetAllTasks(domain.TaskFilter{Search: "report", IncludeArchived: true}, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, tasks.PageInfo.TotalCount)
+
+	// Editing an archived task keeps it archived
+	archived.Title = "Older report"
+	assert.NoError(t, service.UpdateTask(archived, actor))
+	assert.NotNil(t, archived.ArchivedAt)
+
+	unarchived, err := service.UnarchiveTask(task.ID, actor)
+	assert.NoError(t, err)
+	assert.Nil(t, unarchived.ArchivedAt)
+	_, err = service.UnarchiveTask(task.ID, actor)
+	assert.ErrorIs(t, err, domain.ErrNotArchived)
+
+	// Only tasks done for longer than the rule allows are archived automatically
+	done := &domain.Task{Title: "Shipped", UserID: 1, Status: domain.StatusDone}
+	assert.NoError(t, service.CreateTask(done, domain.SystemActor()))
+	open := &domain.Task{Title: "Still open", UserID: 1}
+	assert.NoError(t, service.CreateTask(open, domain.SystemActor()))
+	rule := domain.ArchiveRule{Statuses: []domain.TaskStatus{domain.StatusDone}, After: 30 * 24 * time.Hour}
+
+	count, err := service.AutoArchive(rule, time.Now())
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+	count, err = service.AutoArchive(rule, time.Now().Add(31*24*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	found, err := service.GetTaskByID(done.ID, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.NotNil(t, found.ArchivedAt)
+	found, err = service.GetTaskByID(open.ID, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Nil(t, found.ArchivedAt)
+
+	history, err := service.GetHistory(domain.ActivityFilter{TaskID: done.ID, Page: 1, Limit: 10}, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Equal(t, domain.SourceSystem, history.Edges[0].Node.Source)
+	assert.Equal(t, "archivedAt", history.Edges[0].Node.Changes[0].Field)
+}
@@ -0,0 +1,117 @@
+package unit
+
+import (
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+	"task-manager-app/backend/internal/infrastructure"
+	"task-manager-app/backend/internal/tests"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskTemplates(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	assert.NoError(t, err)
+	policy := newAccessPolicy(db)
+	taskRepo := infrastructure.NewTaskRepository(db)
+	labelRepo := infrastructure.NewLabelRepository(db)
+	activityRepo := infrastructure.NewActivityRepository(db)
+	templates := application.NewTemplateService(infrastructure.NewTemplateRepository(db), labelRepo, policy)
+	tasks := application.NewTaskService(taskRepo, infrastructure.NewWorkspaceRepository(db), activityRepo, policy)
+
+	onboarding := &domain.Label{Name: "onboarding", WorkspaceID: 1}
+	assert.NoError(t, labelRepo.Create(onboarding))
+	foreign := &domain.Label{Name: "other", WorkspaceID: 2}
+	assert.NoError(t, labelRepo.Create(foreign))
+
+	day, week := 24*60, 7*24*60
+	template := &domain.TaskTemplate{
+		WorkspaceID: 1,
+		Name:        "Customer onboarding",
+		Task: domain.TemplateTask{
+			Title:            "Onboard {{customer}}",
+			Description:      "Kick-off with {{ contact }}",
+			Priority:         domain.PriorityHigh,
+			DueOffsetMinutes: &week,
+			LabelIDs:         []int{onboarding.ID},
+			Checklist:        []string{"Send welcome mail to {{contact}}", "Create accounts"},
+			Subtasks: []domain.TemplateTask{
+				{Title: "Contract for {{customer}}", DueOffsetMinutes: &day},
+				{Title: "Training", Subtasks: []domain.TemplateTask{{Title: "Book a room"}}},
+			},
+		},
+	}
+	assert.ErrorIs(t, templates.CreateTemplate(&domain.TaskTemplate{WorkspaceID: 1, Name: "Empty", Task: domain.TemplateTask{Title: " "}}, domain.SystemActor()), domain.ErrInvalidTemplate)
+	withForeignLabel := &domain.TaskTemplate{WorkspaceID: 1, Name: "Foreign", Task: domain.TemplateTask{Title: "x", LabelIDs: []int{foreign.ID}}}
+	assert.ErrorIs(t, templates.CreateTemplate(withForeignLabel, domain.SystemActor()), domain.ErrTemplateLabel)
+	assert.NoError(t, templates.CreateTemplate(template, domain.SystemActor()))
+
+	stored, err := templates.GetTemplate(template.ID, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"contact", "customer"}, stored.Placeholders())
+	assert.Equal(t, "Book a room", stored.Task.Subtasks[1].Subtasks[0].Title)
+
+	assert.NoError(t, infrastructure.NewWorkspaceRepository(db).SaveMember(&domain.WorkspaceMember{WorkspaceID: 1, UserID: 5, Role: domain.RoleMember}))
+	actor := domain.Actor{UserID: 5, Source: domain.SourceREST}
+	assert.ErrorIs(t, templates.DeleteTemplate(stored.ID, actor), domain.ErrForbidden, "members use templates but do not manage them")
+	_, err = tasks.InstantiateTemplate(stored, domain.TemplateParams{Values: map[string]string{"customer": "Acme"}}, actor)
+	assert.ErrorIs(t, err, domain.ErrMissingPlaceholder)
+	assert.Contains(t, err.Error(), "contact")
+	all, err := taskRepo.FindAll(domain.TaskFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, all.PageInfo.TotalCount, "nothing is created when a placeholder is missing")
+
+	now := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	root, err := tasks.InstantiateTemplate(stored, domain.TemplateParams{
+		Values: map[string]string{"customer": "Acme", "contact": "Jo"},
+		Now:    now,
+	}, actor)
+	assert.NoError(t, err)
+	assert.Equal(t, "Onboard Acme", root.Title)
+	assert.Equal(t, "Kick-off with Jo", root.Description)
+	assert.Equal(t, domain.PriorityHigh, root.Priority)
+	assert.Equal(t, domain.StatusTodo, root.Status)
+	assert.Equal(t, 5, root.UserID)
+	assert.Equal(t, 1, root.WorkspaceID)
+	assert.Equal(t, now.Add(7*24*time.Hour), root.DueAt.UTC())
+	assert.Len(t, root.Labels, 1)
+	assert.Equal(t, []string{"Send welcome mail to Jo", "Create accounts"}, checklistTexts(root.Checklist))
+
+	children, err := taskRepo.FindChildren(root.ID)
+	assert.NoError(t, err)
+	assert.Len(t, children, 2)
+	descendants, err := taskRepo.FindDescendants(root.ID)
+	assert.NoError(t, err)
+	assert.Len(t, descendants, 3)
+	for _, child := range children {
+		if child.Title == "Contract for Acme" {
+			assert.Equal(t, now.Add(24*time.Hour), child.DueAt.UTC())
+		} else {
+			assert.Equal(t, "Training", child.Title)
+			assert.Nil(t, child.DueAt)
+		}
+	}
+	history, err := activityRepo.FindByTask(domain.ActivityFilter{TaskID: root.ID, Page: 1, Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ActivityCreated, history.Edges[0].Node.Action)
+
+	// A parent that does not exist leaves nothing behind
+	_, err = tasks.InstantiateTemplate(stored, domain.TemplateParams{Values: map[string]string{"customer": "B", "contact": "C"}, ParentID: 999}, actor)
+	assert.Error(t, err)
+	all, err = taskRepo.FindAll(domain.TaskFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, all.PageInfo.TotalCount)
+
+	stored.Task.Title = "Welcome {{customer}}"
+	stored.WorkspaceID = 2
+	assert.NoError(t, templates.UpdateTemplate(stored, domain.SystemActor()))
+	updated, err := templates.GetTemplate(stored.ID, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, updated.WorkspaceID, "templates stay in their workspace")
+	assert.Equal(t, "Welcome {{customer}}", updated.Task.Title)
+	assert.NoError(t, templates.DeleteTemplate(stored.ID, domain.SystemActor()))
+	_, err = templates.GetTemplate(stored.ID, domain.SystemActor())
+	assert.Error(t, err)
+}
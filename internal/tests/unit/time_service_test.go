@@ -0,0 +1,122 @@
+package unit
+
+import (
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+	"task-manager-app/backend/internal/infrastructure"
+	"task-manager-app/backend/internal/tests"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeTracking(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	assert.NoError(t, err)
+	policy := newAccessPolicy(db)
+	taskRepo := infrastructure.NewTaskRepository(db)
+	projectRepo := infrastructure.NewProjectRepository(db)
+	projects := application.NewProjectService(projectRepo, taskRepo, policy)
+	workspaceRepo := infrastructure.NewWorkspaceRepository(db)
+	service := application.NewTimeService(infrastructure.NewTimeEntryRepository(db), taskRepo, projectRepo, policy)
+	first, second, viewer := domain.Actor{UserID: 1, Source: domain.SourceREST}, domain.Actor{UserID: 2, Source: domain.SourceREST}, domain.Actor{UserID: 3, Source: domain.SourceREST}
+
+	workspace := &domain.Workspace{Name: "Team", OwnerID: 1}
+	assert.NoError(t, workspaceRepo.Create(workspace))
+	assert.NoError(t, workspaceRepo.SaveMember(&domain.WorkspaceMember{WorkspaceID: workspace.ID, UserID: 1, Role: domain.RoleOwner}))
+	assert.NoError(t, workspaceRepo.SaveMember(&domain.WorkspaceMember{WorkspaceID: workspace.ID, UserID: 2, Role: domain.RoleMember}))
+	assert.NoError(t, workspaceRepo.SaveMember(&domain.WorkspaceMember{WorkspaceID: workspace.ID, UserID: 3, Role: domain.RoleViewer}))
+	project := &domain.Project{Name: "Website", OwnerID: 1}
+	assert.NoError(t, projects.CreateProject(project, first))
+	design := &domain.Task{Title: "Design", UserID: 1, WorkspaceID: workspace.ID, ProjectID: project.ID, EstimateMinutes: 120}
+	assert.NoError(t, taskRepo.Create(design))
+	chores := &domain.Task{Title: "Chores", UserID: 1, WorkspaceID: workspace.ID}
+	assert.NoError(t, taskRepo.Create(chores))
+
+	// Tracking time takes editing rights on the task
+	_, err = service.StartTimer(design.ID, "", viewer)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	_, err = service.LogTime(design.ID, time.Now().Add(-time.Hour), time.Now(), "", viewer)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+
+	// One running timer per user, which the database enforces
+	timer, err := service.StartTimer(design.ID, "mockups", first)
+	assert.NoError(t, err)
+	assert.True(t, timer.IsRunning())
+	_, err = service.StartTimer(chores.ID, "", first)
+	assert.ErrorIs(t, err, domain.ErrTimerRunning)
+	_, err = service.StartTimer(chores.ID, "", second)
+	assert.NoError(t, err, "other users keep their own timer")
+	running, err := service.GetRunningTimer(1)
+	assert.NoError(t, err)
+	assert.Equal(t, timer.ID, running.ID)
+
+	stopped, err := service.StopTimer(1)
+	assert.NoError(t, err)
+	assert.False(t, stopped.IsRunning())
+	_, err = service.StopTimer(1)
+	assert.ErrorIs(t, err, domain.ErrNoRunningTimer)
+	running, err = service.GetRunningTimer(1)
+	assert.NoError(t, err)
+	assert.Nil(t, running)
+
+	day := time.Date(2030, 5, 6, 9, 0, 0, 0, time.UTC)
+	_, err = service.LogTime(design.ID, day, day.Add(-time.Hour), "", first)
+	assert.ErrorIs(t, err, domain.ErrInvalidTimeEntry)
+	logged, err := service.LogTime(design.ID, day, day.Add(90*time.Minute), "workshop", first)
+	assert.NoError(t, err)
+	assert.True(t, logged.Manual)
+	assert.EqualValues(t, 90*60, logged.Seconds)
+	_, err = service.LogTime(design.ID, day, day.Add(30*time.Minute), "", second)
+	assert.NoError(t, err)
+	_, err = service.LogTime(chores.ID, day.Add(24*time.Hour), day.Add(25*time.Hour), "", first)
+	assert.NoError(t, err)
+
+	// Only the author can change an entry
+	end := day.Add(time.Hour)
+	_, err = service.UpdateEntry(logged.ID, 2, domain.TimeEntryUpdate{EndedAt: &end})
+	assert.ErrorIs(t, err, domain.ErrTimeEntryForbidden)
+	updated, err := service.UpdateEntry(logged.ID, 1, domain.TimeEntryUpdate{EndedAt: &end})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3600, updated.Seconds)
+
+	from, to := day, day.Add(24*time.Hour)
+	report, err := service.GetReport(domain.TimeEntryFilter{From: &from, To: &to}, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.EqualValues(t, 90*60, report.Seconds)
+	assert.Equal(t, []domain.TimeTotal{{ID: design.ID, Seconds: 90 * 60}}, report.ByTask)
+	assert.Equal(t, []domain.TimeTotal{{ID: project.ID, Seconds: 90 * 60}}, report.ByProject)
+	assert.Equal(t, []domain.TimeTotal{{ID: 1, Seconds: 3600}, {ID: 2, Seconds: 1800}}, report.ByUser)
+
+	report, err = service.GetReport(domain.TimeEntryFilter{UserID: 1, From: &from}, first)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2*3600, report.Seconds)
+	assert.Equal(t, []domain.TimeTotal{{ID: 0, Seconds: 3600}, {ID: project.ID, Seconds: 3600}}, report.ByProject)
+
+	// Reports of other users and of foreign projects stay hidden
+	_, err = service.GetReport(domain.TimeEntryFilter{UserID: 1, From: &from}, second)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	_, err = service.GetEntries(domain.TimeEntryFilter{ProjectID: project.ID}, second)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	mine, err := service.GetReport(domain.TimeEntryFilter{From: &from, To: &to}, second)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 30*60, mine.Seconds, "without a user the report covers the caller")
+
+	entries, err := service.GetEntries(domain.TimeEntryFilter{ProjectID: project.ID, Page: 1, Limit: 10}, first)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, entries.PageInfo.TotalCount, "the stopped timer and both logged entries")
+
+	remaining := 30
+	design.RemainingMinutes = &remaining
+	assert.NoError(t, taskRepo.Update(design))
+	taskTime, err := service.GetTaskTime(design.ID, first)
+	assert.NoError(t, err)
+	assert.Equal(t, 120, taskTime.EstimateMinutes)
+	assert.Equal(t, 30, *taskTime.RemainingMinutes)
+	assert.EqualValues(t, 90*60, taskTime.TrackedSeconds-stopped.Seconds)
+	assert.Equal(t, 30*time.Minute, design.RemainingDuration())
+
+	assert.ErrorIs(t, service.DeleteEntry(logged.ID, 2), domain.ErrTimeEntryForbidden)
+	assert.NoError(t, service.DeleteEntry(logged.ID, 1))
+}
@@ -0,0 +1,218 @@
+package unit
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+	"task-manager-app/backend/internal/infrastructure"
+	"task-manager-app/backend/internal/tests"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var testSecret = []byte("test-secret")
+
+var testTokenConfig = application.TokenConfig{
+	Keys:      mustKeySet(application.NewKeySet([]application.SigningKey{application.NewHMACKey("hs256", testSecret)}, time.Minute)),
+	Issuer:    "task-manager",
+	Audience:  "task-manager-api",
+	Expiry:    time.Minute,
+	ClockSkew: 30 * time.Second,
+}
+
+func mustKeySet(keys *application.KeySet, err error) *application.KeySet {
+	if err != nil {
+		panic(err)
+	}
+	return keys
+}
+
+func newTokenService(db *gorm.DB, expiry time.Duration) *application.TokenService {
+	cfg := testTokenConfig
+	cfg.Expiry = expiry
+	return application.NewTokenService(cfg, application.NewRevocationService(infrastructure.NewRevocationRepository(db), time.Minute))
+}
+
+// signTestToken signs claims the way another issuer might, to check what Validate accepts.
+func signTestToken(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	assert.NoError(t, err)
+	return signed
+}
+
+func TestTokenIssueAndValidate(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	assert.NoError(t, err)
+	service := newTokenService(db, time.Minute)
+
+	token, issued, err := service.Issue(7, []string{"member"}, "family")
+	assert.NoError(t, err)
+	claims, err := service.Validate(token)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, claims.UserID)
+	assert.Equal(t, issued.TokenID, claims.TokenID)
+	assert.NotEmpty(t, claims.TokenID)
+	assert.Equal(t, "family", claims.SessionID)
+	assert.Equal(t, []string{"member"}, claims.Roles)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), claims.ExpiresAt, 2*time.Second)
+
+	// Every token gets its own ID
+	_, other, err := service.Issue(7, nil, "family")
+	assert.NoError(t, err)
+	assert.NotEqual(t, issued.TokenID, other.TokenID)
+
+	assert.NoError(t, service.Revoke(claims))
+	_, err = service.Validate(token)
+	assert.ErrorIs(t, err, domain.ErrTokenRevoked)
+}
+
+func TestTokenValidateRejects(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	assert.NoError(t, err)
+	service := newTokenService(db, time.Minute)
+	now := time.Now()
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"sub": "7",
+			"jti": "token-id",
+			"iss": "task-manager",
+			"aud": "task-manager-api",
+			"iat": now.Unix(),
+			"exp": now.Add(time.Minute).Unix(),
+		}
+	}
+	with := func(key string, value interface{}) jwt.MapClaims {
+		claims := valid()
+		if value == nil {
+			delete(claims, key)
+		} else {
+			claims[key] = value
+		}
+		return claims
+	}
+
+	_, err = service.Validate(signTestToken(t, jwt.SigningMethodHS256, "hs256", testSecret, valid()))
+	assert.NoError(t, err)
+
+	// Expired within the clock skew still passes, beyond it does not
+	_, err = service.Validate(signTestToken(t, jwt.SigningMethodHS256, "hs256", testSecret, with("exp", now.Add(-10*time.Second).Unix())))
+	assert.NoError(t, err)
+	_, err = service.Validate(signTestToken(t, jwt.SigningMethodHS256, "hs256", testSecret, with("exp", now.Add(-time.Minute).Unix())))
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+
+	rejected := map[string]string{
+		"unknown key ID":   signTestToken(t, jwt.SigningMethodHS256, "other", testSecret, valid()),
+		"wrong secret":     signTestToken(t, jwt.SigningMethodHS256, "hs256", []byte("other-secret"), valid()),
+		"other algorithm":  signTestToken(t, jwt.SigningMethodHS512, "hs256", testSecret, valid()),
+		"unsigned":         signTestToken(t, jwt.SigningMethodNone, "hs256", jwt.UnsafeAllowNoneSignatureType, valid()),
+		"wrong issuer":     signTestToken(t, jwt.SigningMethodHS256, "hs256", testSecret, with("iss", "someone-else")),
+		"wrong audience":   signTestToken(t, jwt.SigningMethodHS256, "hs256", testSecret, with("aud", "other-api")),
+		"no expiry":        signTestToken(t, jwt.SigningMethodHS256, "hs256", testSecret, with("exp", nil)),
+		"issued in future": signTestToken(t, jwt.SigningMethodHS256, "hs256", testSecret, with("iat", now.Add(time.Hour).Unix())),
+		"no token ID":      signTestToken(t, jwt.SigningMethodHS256, "hs256", testSecret, with("jti", nil)),
+		"username subject": signTestToken(t, jwt.SigningMethodHS256, "hs256", testSecret, with("sub", "john")),
+		"malformed":        "not.a.token",
+	}
+	for name, token := range rejected {
+		_, err := service.Validate(token)
+		assert.ErrorIs(t, err, domain.ErrInvalidToken, name)
+	}
+}
+
+// pemKey generates a private key and returns it PEM encoded, as it would be read from a file.
+func pemKey(t *testing.T, rsaKey bool) []byte {
+	var private interface{}
+	if rsaKey {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		assert.NoError(t, err)
+		private = key
+	} else {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		assert.NoError(t, err)
+		private = key
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func TestTokenKeyRotation(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	assert.NoError(t, err)
+	now := time.Now()
+	old, err := application.ParseSigningKey("old", pemKey(t, true), now.Add(-24*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, "RS256", old.Algorithm)
+	current, err := application.ParseSigningKey("current", pemKey(t, false), now.Add(-time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, "EdDSA", current.Algorithm)
+	next, err := application.ParseSigningKey("next", pemKey(t, true), now.Add(time.Hour))
+	assert.NoError(t, err)
+	_, err = application.ParseSigningKey("bad", []byte("not a key"), now)
+	assert.Error(t, err)
+
+	keys, err := application.NewKeySet([]application.SigningKey{next, old, current}, 10*time.Minute)
+	assert.NoError(t, err)
+	signing, err := keys.Signing(now)
+	assert.NoError(t, err)
+	assert.Equal(t, "current", signing.ID)
+
+	// The replaced key is accepted during the grace period only; the next one once it signs
+	_, ok := keys.Verifying("old", now)
+	assert.True(t, ok)
+	_, ok = keys.Verifying("old", now.Add(10*time.Minute))
+	assert.False(t, ok)
+	_, ok = keys.Verifying("next", now)
+	assert.False(t, ok)
+	_, ok = keys.Verifying("next", now.Add(time.Hour))
+	assert.True(t, ok)
+
+	// Upcoming keys are published ahead of time, retired ones are dropped
+	var published []string
+	for _, jwk := range keys.JWKS(now.Add(10 * time.Minute)).Keys {
+		published = append(published, jwk.KeyID)
+	}
+	assert.Equal(t, []string{"current", "next"}, published)
+
+	cfg := testTokenConfig
+	cfg.Keys = keys
+	service := application.NewTokenService(cfg, application.NewRevocationService(infrastructure.NewRevocationRepository(db), time.Minute))
+	token, _, err := service.Issue(7, nil, "")
+	assert.NoError(t, err)
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+	assert.NoError(t, err)
+	assert.Equal(t, "current", parsed.Header["kid"])
+	assert.Equal(t, "EdDSA", parsed.Header["alg"])
+	claims, err := service.Validate(token)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, claims.UserID)
+
+	// Another service can verify the token with nothing but the published key
+	var jwk application.JWK
+	for _, k := range service.JWKS().Keys {
+		if k.KeyID == "current" {
+			jwk = k
+		}
+	}
+	assert.Equal(t, "OKP", jwk.KeyType)
+	public, err := base64.RawURLEncoding.DecodeString(jwk.X)
+	assert.NoError(t, err)
+	_, err = jwt.Parse(token, func(*jwt.Token) (interface{}, error) { return ed25519.PublicKey(public), nil })
+	assert.NoError(t, err)
+
+	// A token signed with a known key ID but another algorithm is rejected
+	forged := signTestToken(t, jwt.SigningMethodHS256, "current", []byte("guess"), jwt.MapClaims{"sub": "7", "jti": "x", "iat": now.Unix(), "exp": now.Add(time.Minute).Unix()})
+	_, err = service.Validate(forged)
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+}
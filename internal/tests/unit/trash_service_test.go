@@ -0,0 +1,121 @@
+package unit
+
+import (
+	"context"
+	"strings"
+	"task-manager-app/backend/internal/application"
+	"task-manager-app/backend/internal/domain"
+	"task-manager-app/backend/internal/infrastructure"
+	"task-manager-app/backend/internal/tests"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskTrash(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	assert.NoError(t, err)
+	policy := newAccessPolicy(db)
+	taskRepo := infrastructure.NewTaskRepository(db)
+	blobs, err := infrastructure.NewLocalBlobStore(t.TempDir())
+	assert.NoError(t, err)
+	tasks := application.NewTaskService(taskRepo, infrastructure.NewWorkspaceRepository(db), infrastructure.NewActivityRepository(db), policy)
+	attachments := application.NewAttachmentService(infrastructure.NewAttachmentRepository(db), taskRepo, blobs, application.AttachmentLimits{}, policy)
+	trash := application.NewTrashService(taskRepo, infrastructure.NewUserRepository(db), blobs, 24*time.Hour, policy)
+	ctx := context.Background()
+
+	root := &domain.Task{Title: "Root", UserID: 1}
+	assert.NoError(t, tasks.CreateTask(root, domain.SystemActor()))
+	child := &domain.Task{Title: "Child", UserID: 1, ParentID: root.ID}
+	assert.NoError(t, tasks.CreateTask(child, domain.SystemActor()))
+	attachment, err := attachments.Upload(ctx, child.ID, "notes.txt", strings.NewReader("notes"), 5, domain.Actor{UserID: 1, Source: domain.SourceREST})
+	assert.NoError(t, err)
+
+	assert.NoError(t, tasks.DeleteTask(root.ID, domain.DeleteCascade, domain.SystemActor()))
+	all, err := tasks.GetAllTasks(domain.TaskFilter{}, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Zero(t, all.PageInfo.TotalCount)
+	owned, err := tasks.GetTasksByUserID(1)
+	assert.NoError(t, err)
+	assert.Empty(t, owned)
+	_, err = tasks.GetTaskByID(child.ID, domain.SystemActor())
+	assert.Error(t, err)
+
+	deleted, err := trash.GetDeletedTasks(domain.TaskFilter{Page: 1, Limit: 10}, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, deleted.PageInfo.TotalCount)
+	assert.True(t, deleted.Edges[0].Node.DeletedAt.Valid)
+
+	// Restoring the root brings back the subtask deleted with it
+	restored, err := trash.RestoreTask(root.ID, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.False(t, restored.DeletedAt.Valid)
+	restoredChild, err := tasks.GetTaskByID(child.ID, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Equal(t, root.ID, restoredChild.ParentID)
+	_, err = trash.RestoreTask(root.ID, domain.SystemActor())
+	assert.Error(t, err, "a live task is not in the trash")
+
+	// A subtask restored on its own while its parent is still deleted moves to the root
+	assert.NoError(t, tasks.DeleteTask(root.ID, domain.DeleteCascade, domain.SystemActor()))
+	restoredChild, err = trash.RestoreTask(child.ID, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Zero(t, restoredChild.ParentID)
+
+	// Purging removes the attachment content too
+	assert.NoError(t, tasks.DeleteTask(child.ID, domain.DeleteReparent, domain.SystemActor()))
+	assert.NoError(t, trash.PurgeTask(ctx, child.ID, domain.SystemActor()))
+	_, err = blobs.Get(ctx, attachment.StorageKey, 0, -1)
+	assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+	_, err = trash.RestoreTask(child.ID, domain.SystemActor())
+	assert.Error(t, err)
+
+	// Only items older than the retention are purged automatically
+	assert.NoError(t, trash.PurgeExpired(ctx, time.Now()))
+	deleted, err = trash.GetDeletedTasks(domain.TaskFilter{Page: 1, Limit: 10}, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted.PageInfo.TotalCount)
+	assert.NoError(t, trash.PurgeExpired(ctx, time.Now().Add(25*time.Hour)))
+	deleted, err = trash.GetDeletedTasks(domain.TaskFilter{Page: 1, Limit: 10}, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Zero(t, deleted.PageInfo.TotalCount)
+}
+
+func TestUserTrash(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	assert.NoError(t, err)
+	policy := newAccessPolicy(db)
+	users := infrastructure.NewUserRepository(db)
+	service := application.NewUserService(users, infrastructure.NewRefreshTokenRepository(db), newTokenService(db, time.Minute), time.Hour, policy)
+	trash := application.NewTrashService(infrastructure.NewTaskRepository(db), users, nil, 0, policy)
+
+	user := &domain.User{Email: "gone@example.com", Name: "Gone"}
+	assert.NoError(t, users.Create(user))
+	assert.NoError(t, service.DeleteUser(user.ID, domain.SystemActor()))
+
+	_, err = service.GetUserByEmail("gone@example.com", domain.SystemActor())
+	assert.Error(t, err)
+	all, err := service.GetAllUsers(domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Empty(t, all)
+	deleted, err := trash.GetDeletedUsers(domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Len(t, deleted, 1)
+
+	restored, err := trash.RestoreUser(user.ID, domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Equal(t, "gone@example.com", restored.Email)
+
+	// The email cannot come back once someone else registered it
+	assert.NoError(t, service.DeleteUser(user.ID, domain.SystemActor()))
+	assert.NoError(t, users.Create(&domain.User{Email: "gone@example.com", Name: "New"}))
+	_, err = trash.RestoreUser(user.ID, domain.SystemActor())
+	assert.ErrorIs(t, err, domain.ErrEmailTaken)
+
+	assert.NoError(t, trash.PurgeUser(user.ID, domain.SystemActor()))
+	deleted, err = trash.GetDeletedUsers(domain.SystemActor())
+	assert.NoError(t, err)
+	assert.Empty(t, deleted)
+	assert.NoError(t, trash.PurgeExpired(context.Background(), time.Now()), "no retention means nothing expires")
+}
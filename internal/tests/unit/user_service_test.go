@@ -6,6 +6,7 @@ import (
 	"task-manager-app/backend/internal/infrastructure"
 	"task-manager-app/backend/internal/tests"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -15,7 +16,7 @@ func setupUserService(t *testing.T) *application.UserService {
 	assert.NoError(t, err)
 
 	repo := infrastructure.NewUserRepository(db)
-	return application.NewUserService(repo)
+	return application.NewUserService(repo, infrastructure.NewRefreshTokenRepository(db), newTokenService(db, 15*time.Minute), time.Hour, newAccessPolicy(db))
 }
 
 func TestCreateUser(t *testing.T) {
@@ -45,10 +46,10 @@ func TestUpdateUser(t *testing.T) {
 	assert.NoError(t, err)
 
 	user.Name = "Jane Doe"
-	err = service.UpdateUser(user)
+	err = service.UpdateUser(user, domain.SystemActor())
 	assert.NoError(t, err)
 
-	updatedUser, err := service.GetUserByID(user.ID)
+	updatedUser, err := service.GetUserByID(user.ID, domain.SystemActor())
 	assert.NoError(t, err)
 	assert.Equal(t, "Jane Doe", updatedUser.Name)
 }
@@ -67,10 +68,10 @@ func TestDeleteUser(t *testing.T) {
 	err := service.Register(user)
 	assert.NoError(t, err)
 
-	err = service.DeleteUser(user.ID)
+	err = service.DeleteUser(user.ID, domain.SystemActor())
 	assert.NoError(t, err)
 
-	deletedUser, err := service.GetUserByID(user.ID)
+	deletedUser, err := service.GetUserByID(user.ID, domain.SystemActor())
 	assert.Error(t, err)
 	assert.Nil(t, deletedUser)
 }
@@ -89,7 +90,73 @@ func TestGetUser(t *testing.T) {
 	err := service.Register(user)
 	assert.NoError(t, err)
 
-	retrievedUser, err := service.GetUserByID(user.ID)
+	retrievedUser, err := service.GetUserByID(user.ID, domain.SystemActor())
 	assert.NoError(t, err)
 	assert.Equal(t, "John Doe", retrievedUser.Name)
 }
+
+func TestRefreshTokenRotation(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	assert.NoError(t, err)
+	tokenService := newTokenService(db, 15*time.Minute)
+	service := application.NewUserService(infrastructure.NewUserRepository(db), infrastructure.NewRefreshTokenRepository(db), tokenService, time.Hour, newAccessPolicy(db))
+	user := &domain.User{Name: "John", LastName: "Doe", Email: "john@example.com"}
+	assert.NoError(t, user.HashPassword("secret123"))
+	assert.NoError(t, service.Register(user))
+
+	_, login, err := service.Login("john@example.com", "secret123")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, login.AccessToken)
+	assert.Equal(t, 15*60, login.ExpiresIn)
+	assert.NotEmpty(t, login.RefreshToken)
+
+	// Every refresh hands out a new refresh token and retires the old one
+	refreshed, tokens, err := service.Refresh(login.RefreshToken)
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, refreshed.ID)
+	assert.NotEqual(t, login.RefreshToken, tokens.RefreshToken)
+	_, second, err := service.Refresh(tokens.RefreshToken)
+	assert.NoError(t, err)
+
+	// A second login is a separate family and survives the reuse below
+	_, other, err := service.Login("john@example.com", "secret123")
+	assert.NoError(t, err)
+
+	// Reusing a retired token revokes its whole family, including the latest token
+	_, _, err = service.Refresh(login.RefreshToken)
+	assert.ErrorIs(t, err, domain.ErrRefreshTokenReused)
+	_, _, err = service.Refresh(second.RefreshToken)
+	assert.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+	_, _, err = service.Refresh(other.RefreshToken)
+	assert.NoError(t, err)
+
+	// The access tokens of the family's session stop working too, those of the other one do not
+	for _, pair := range []*domain.TokenPair{login, tokens, second} {
+		_, err = tokenService.Validate(pair.AccessToken)
+		assert.ErrorIs(t, err, domain.ErrTokenRevoked)
+	}
+	_, err = tokenService.Validate(other.AccessToken)
+	assert.NoError(t, err)
+
+	_, _, err = service.Refresh("not-a-token")
+	assert.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+}
+
+func TestRefreshTokenExpiry(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	assert.NoError(t, err)
+	service := application.NewUserService(infrastructure.NewUserRepository(db), infrastructure.NewRefreshTokenRepository(db), newTokenService(db, time.Minute), time.Hour, newAccessPolicy(db))
+	user := &domain.User{Name: "John", LastName: "Doe", Email: "john@example.com"}
+	assert.NoError(t, user.HashPassword("secret123"))
+	assert.NoError(t, service.Register(user))
+
+	_, login, err := service.Login("john@example.com", "secret123")
+	assert.NoError(t, err)
+	assert.NoError(t, db.Model(&domain.RefreshToken{}).Where("user_id = ?", user.ID).Update("expires_at", time.Now().Add(-time.Minute)).Error)
+	_, _, err = service.Refresh(login.RefreshToken)
+	assert.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+
+	pruned, err := service.PruneRefreshTokens(time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, pruned)
+}